@@ -16,13 +16,14 @@ import (
 // @host localhost:8080
 // @BasePath /
 func main() {
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
-
 	cfg, err := config.New()
 	if err != nil {
-		logger.Error("failed to load config", "error", err)
+		slog.Error("failed to load config", "error", err)
+		os.Exit(1)
 	}
 
+	logger := newLogger(cfg.Log)
+
 	application, err := app.New(cfg, logger)
 	if err != nil {
 		logger.Error("failed to create application", "error", err)
@@ -32,3 +33,15 @@ func main() {
 		logger.Error("application finished with error", "error", err)
 	}
 }
+
+// newLogger builds the application logger writing to stdout in the configured
+// format and level.
+func newLogger(cfg config.LogConfig) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: cfg.Level}
+
+	if cfg.Format == "json" {
+		return slog.New(slog.NewJSONHandler(os.Stdout, opts))
+	}
+
+	return slog.New(slog.NewTextHandler(os.Stdout, opts))
+}
@@ -0,0 +1,45 @@
+// Command tixtoken issues a bearer JWT for local development and testing,
+// signed with AUTH_JWT_SECRET.
+//
+//	AUTH_JWT_SECRET=... go run ./cmd/tixtoken -user 42 -role admin -ttl 1h
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kirinyoku/tix-go/internal/auth"
+)
+
+func main() {
+	userID := flag.Int64("user", 0, "user ID to put in the sub claim")
+	role := flag.String("role", auth.RoleUser, "role claim: user or admin")
+	ttl := flag.Duration("ttl", time.Hour, "token lifetime")
+	flag.Parse()
+
+	secret := os.Getenv("AUTH_JWT_SECRET")
+	if secret == "" {
+		fmt.Fprintln(os.Stderr, "AUTH_JWT_SECRET is not set")
+		os.Exit(1)
+	}
+
+	if *userID <= 0 {
+		fmt.Fprintln(os.Stderr, "-user must be a positive user ID")
+		os.Exit(2)
+	}
+
+	if *role != auth.RoleUser && *role != auth.RoleAdmin {
+		fmt.Fprintf(os.Stderr, "-role must be %s or %s\n", auth.RoleUser, auth.RoleAdmin)
+		os.Exit(2)
+	}
+
+	token, err := auth.IssueToken(*userID, *role, *ttl, []byte(secret))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to issue token:", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(token)
+}
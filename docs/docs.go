@@ -15,9 +15,10 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/events": {
+        "/admin/api-keys": {
             "post": {
-                "summary": "Create event and init seats",
+                "description": "Issues a key for a server-to-server client, sent in the ` + "`" + `X-API-Key` + "`" + ` header. Scope ` + "`" + `read` + "`" + ` allows GET requests, ` + "`" + `write` + "`" + ` the others. The key is only returned here.",
+                "summary": "Issue an API key",
                 "parameters": [
                     {
                         "description": "payload",
@@ -25,7 +26,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/httpgin.CreateEventRequest"
+                            "$ref": "#/definitions/httpgin.CreateAPIKeyRequest"
                         }
                     }
                 ],
@@ -33,15 +34,46 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/httpgin.CreateEventResponse"
+                            "$ref": "#/definitions/httpgin.CreateAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/admin/venues": {
+        "/admin/api-keys/{id}": {
+            "delete": {
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "not found or already revoked",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/events": {
             "post": {
-                "summary": "Create venue",
+                "summary": "Create event and init seats",
                 "parameters": [
                     {
                         "description": "payload",
@@ -49,38 +81,53 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/httpgin.CreateVenueRequest"
+                            "$ref": "#/definitions/httpgin.CreateEventRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "validate only; responds 204 without creating the event",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/httpgin.CreateVenueResponse"
+                            "$ref": "#/definitions/httpgin.CreateEventResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "dry run passed"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "venue has no seats",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/admin/venues/{id}/seats": {
+        "/admin/events/recurring": {
             "post": {
-                "summary": "Batch create seats",
+                "description": "Creates one event per time slot. Either all events are created or none.",
+                "summary": "Create recurring events and init seats",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Venue ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "payload",
                         "name": "req",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/httpgin.BatchCreateSeatsRequest"
+                            "$ref": "#/definitions/httpgin.CreateRecurringEventsRequest"
                         }
                     }
                 ],
@@ -88,18 +135,33 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
-                            }
+                            "$ref": "#/definitions/httpgin.CreateRecurringEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/events/{id}": {
-            "get": {
-                "summary": "Get event",
+        "/admin/events/{id}": {
+            "put": {
+                "summary": "Update (reschedule) event",
                 "parameters": [
                     {
                         "type": "integer",
@@ -107,6 +169,21 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.UpdateEventRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET /events/{id}",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -116,18 +193,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.Event"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/httpgin.ErrorResponse"
                         }
+                    },
+                    "412": {
+                        "description": "event changed since the ETag was issued",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/events/{id}/availability": {
-            "get": {
-                "summary": "Get availability counters",
+        "/admin/events/{id}/cancel": {
+            "post": {
+                "summary": "Cancel event and release its holds",
                 "parameters": [
                     {
                         "type": "integer",
@@ -141,15 +230,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.EventCounts"
+                            "$ref": "#/definitions/domain.EventCancellation"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "already cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/events/{id}/holds": {
-            "post": {
-                "summary": "Create hold (idempotent)",
+        "/admin/events/{id}/ga": {
+            "put": {
+                "summary": "Set general-admission capacity of an event",
                 "parameters": [
                     {
                         "type": "integer",
@@ -164,15 +265,15 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/httpgin.CreateHoldRequest"
+                            "$ref": "#/definitions/httpgin.SetGACapacityRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/httpgin.CreateHoldResponse"
+                            "$ref": "#/definitions/domain.GACapacity"
                         }
                     },
                     "400": {
@@ -181,14 +282,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpgin.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "seats unavailable / idem in progress",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/httpgin.ErrorResponse"
                         }
                     },
-                    "429": {
-                        "description": "rate limited",
+                    "409": {
+                        "description": "capacity below tickets held and sold",
                         "schema": {
                             "$ref": "#/definitions/httpgin.ErrorResponse"
                         }
@@ -196,9 +297,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{id}/seats": {
+        "/admin/events/{id}/holds": {
             "get": {
-                "summary": "List event seats",
+                "summary": "List active holds of an event",
                 "parameters": [
                     {
                         "type": "integer",
@@ -208,10 +309,48 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "available",
-                        "name": "only",
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.HoldSummary"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/events/{id}/log": {
+            "get": {
+                "description": "Entries record holds being created, confirmed, cancelled and expired, oldest first.",
+                "summary": "List the hold lifecycle log of an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
@@ -232,36 +371,71 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.SeatWithStatus"
+                                "$ref": "#/definitions/domain.EventLogEntry"
                             }
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/orders/confirm": {
+        "/admin/events/{id}/revenue": {
+            "get": {
+                "description": "Aggregates confirmed orders; refunded orders are not counted. Events without orders report zeros.",
+                "summary": "Get gross sales of an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.EventRevenueResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/holds/expire": {
             "post": {
-                "summary": "Confirm order",
+                "description": "Releases seats of expired holds. Pass ` + "`" + `event_id` + "`" + ` to limit expiry to one event; omit the body to expire holds of every event.",
+                "summary": "Expire holds that exceeded their TTL",
                 "parameters": [
                     {
                         "description": "payload",
                         "name": "req",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/httpgin.ConfirmOrderRequest"
+                            "$ref": "#/definitions/httpgin.ExpireHoldsRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/httpgin.ConfirmOrderResponse"
+                            "$ref": "#/definitions/httpgin.ExpireHoldsResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/httpgin.ErrorResponse"
                         }
@@ -269,297 +443,2766 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}": {
+        "/admin/orders": {
             "get": {
-                "summary": "Get order with tickets",
+                "description": "Lists all orders created within [from, to). The range may span at most 31 days.",
+                "summary": "List orders by creation time",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID (uuid)",
-                        "name": "id",
-                        "in": "path",
+                        "description": "created at or after (RFC3339)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created before (RFC3339)",
+                        "name": "to",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc or desc (default)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.OrderWithTickets"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Order"
+                            }
                         }
-                    }
-                }
-            }
-        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/seats/{id}": {
+            "patch": {
+                "summary": "Move a seat to another section, row or number",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Seat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.UpdateSeatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Seat"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "another seat already has this position, or the seat is held or sold for an upcoming event",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/stats": {
+            "get": {
+                "description": "Reports Postgres and Redis pool usage to spot saturation.",
+                "summary": "Connection pool statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.StatsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/venues": {
+            "get": {
+                "summary": "List venues",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Venue"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "summary": "Create venue",
+                "parameters": [
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.CreateVenueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.CreateVenueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid seating scheme",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/venues/{id}": {
+            "put": {
+                "summary": "Update venue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Venue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.UpdateVenueRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from GET /venues/{id}",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Venue"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "venue changed since the ETag was issued",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/venues/{id}/seats": {
+            "post": {
+                "description": "Seats that already exist are skipped and not counted in ` + "`" + `created` + "`" + `. Send an ` + "`" + `Idempotency-Key` + "`" + ` header to get the original response back when retrying.",
+                "summary": "Batch create seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Venue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Idempotency key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.BatchCreateSeatsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.BatchCreateSeatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid payload / too many seats",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "idem in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "idempotency key reused with a different request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/venues/{id}/seats/generate": {
+            "post": {
+                "description": "Creates every seat described by the stored scheme; existing seats are skipped.",
+                "summary": "Generate seats from the venue's seating scheme",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Venue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer",
+                                "format": "int64"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid seating scheme",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "summary": "List events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only events at this venue",
+                        "name": "venue_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only events starting at or after (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only events starting at or before (RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Event"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/availability": {
+            "post": {
+                "description": "Unknown events are omitted from the result.",
+                "summary": "Get availability counters for several events",
+                "parameters": [
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.AvailabilityBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.AvailabilityBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}": {
+            "get": {
+                "summary": "Get event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Event"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/availability": {
+            "get": {
+                "summary": "Get availability counters",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.EventCounts"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/availability/sections": {
+            "get": {
+                "summary": "Get availability counters per section",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.SectionCounts"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/holds": {
+            "post": {
+                "description": "With ` + "`" + `allow_partial` + "`" + `, the available seats are held and the rest are listed in ` + "`" + `unavailable_seat_ids` + "`" + `.",
+                "summary": "Create hold (idempotent)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.CreateHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.CreateHoldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id does not match the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "seats unavailable / event sold out / seat limit exceeded / idem in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "seats are not contiguous / idempotency key reused",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limited",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/holds/auto": {
+            "post": {
+                "description": "Picks ` + "`" + `count` + "`" + ` available seats, preferring the lowest row and the centre of a row, and holds them.",
+                "summary": "Hold the best available seats (idempotent)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.AutoHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.AutoHoldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id does not match the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "not enough seats available / event sold out / seat limit exceeded / idem in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "idempotency key reused",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limited",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/holds/ga": {
+            "post": {
+                "description": "Holds ` + "`" + `qty` + "`" + ` tickets from the event's general-admission pool, which has no assigned seats.",
+                "summary": "Hold general-admission tickets (idempotent)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.GAHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.GAHoldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id does not match the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "not enough general admission tickets / seat limit exceeded / idem in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "idempotency key reused",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limited",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/seatmap": {
+            "get": {
+                "description": "Anonymous requests get domain.SeatWithStatus items. With a bearer token, each seat also carries ` + "`" + `held_by_me` + "`" + `, marking the authenticated user's own holds.",
+                "summary": "Get full seat map with statuses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.UserSeat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/seats": {
+            "get": {
+                "summary": "List event seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "available",
+                        "name": "only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "keyset cursor; when present (even empty) the response is a SeatPageResponse",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "when true the response is a SeatListResponse with the total count",
+                        "name": "meta",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.SeatWithStatus"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/seats/status": {
+            "post": {
+                "summary": "Check status of selected seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.SeatStatusesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.SeatStatusesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/stream": {
+            "get": {
+                "description": "Sends an ` + "`" + `availability` + "`" + ` event with the current counters on connect and after every change to the event.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "Stream availability changes (Server-Sent Events)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.EventCounts"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/waitlist": {
+            "post": {
+                "summary": "Join event waitlist (idempotent per user)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.JoinWaitlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.WaitlistEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id does not match the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/ws": {
+            "get": {
+                "description": "Upgrades to a WebSocket. Sends a ` + "`" + `snapshot` + "`" + ` message with the full seat map, then ` + "`" + `update` + "`" + ` messages with changed seats. Idle connections are closed.",
+                "summary": "Live seat status updates (WebSocket)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.SeatMapMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/holds/{id}": {
+            "get": {
+                "summary": "Get hold status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hold ID (uuid)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.HoldStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "hold belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "summary": "Cancel hold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hold ID (uuid)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "hold belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/holds/{id}/extend": {
+            "post": {
+                "summary": "Extend hold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hold ID (uuid)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ExtendHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ExtendHoldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "hold belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "hold expired",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/confirm": {
+            "post": {
+                "summary": "Confirm order (idempotent)",
+                "parameters": [
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ConfirmOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ConfirmOrderResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id does not match the authenticated user / hold belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "hold expired / hold already confirmed / nothing to confirm / idem in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "idempotency key reused",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/tickets": {
+            "post": {
+                "description": "Tickets are keyed by order ID; unknown orders, orders of other users and orders without tickets are omitted.",
+                "summary": "Get tickets of several orders",
+                "parameters": [
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.OrderTicketsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.OrderTicketsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limited",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "summary": "Get order with tickets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (uuid)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OrderWithTickets"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "order belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/refund": {
+            "post": {
+                "description": "Releases the order's seats, deletes its tickets and marks it refunded.",
+                "summary": "Refund order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID (uuid)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.RefundOrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "order belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "already refunded",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Pings every dependency (Postgres, Redis) and reports which ones failed.",
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ReadyResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ReadyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/verify": {
+            "post": {
+                "description": "Checks a token read from a ticket QR code. For entry gates: requires an API key with the write scope or the admin role. Tokens of refunded tickets get 404.",
+                "summary": "Verify a scanned ticket token",
+                "parameters": [
+                    {
+                        "description": "payload",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.VerifyTicketTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.VerifyTicketTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not a gate client",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "ticket signing not configured",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/{id}": {
+            "get": {
+                "summary": "Get ticket with its signature",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID (uuid)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.TicketResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ticket of another user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "ticket signing not configured",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/{id}/qr": {
+            "get": {
+                "description": "Returns a QR code encoding the signed ticket token. Sends a PNG when the client accepts ` + "`" + `image/png` + "`" + `, otherwise JSON with the token and a base64 PNG.",
+                "produces": [
+                    "application/json",
+                    "image/png"
+                ],
+                "summary": "Get ticket QR code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID (uuid)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.TicketQRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "ticket of another user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "ticket signing not configured",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tickets/{id}/verify": {
+            "get": {
+                "description": "For entry gates: requires an API key or the admin role.",
+                "summary": "Verify a ticket signature",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID (uuid)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature from GET /tickets/{id}",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.TicketVerifyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "not a gate client",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "ticket signing not configured",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/orders": {
+            "get": {
+                "summary": "List user orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Order"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id does not match the authenticated user",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/venues/{id}": {
+            "get": {
+                "summary": "Get venue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Venue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Venue"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpgin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
     },
     "definitions": {
+        "domain.APIKeyScope": {
+            "type": "string",
+            "enum": [
+                "read",
+                "write"
+            ],
+            "x-enum-varnames": [
+                "ScopeRead",
+                "ScopeWrite"
+            ]
+        },
         "domain.Event": {
             "type": "object",
             "properties": {
-                "ends": {
+                "cancelledAt": {
+                    "type": "string"
+                },
+                "ends": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "starts": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "venueID": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "domain.EventCancellation": {
+            "type": "object",
+            "properties": {
+                "affectedOrders": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "releasedHolds": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "releasedSeats": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "domain.EventCounts": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "held": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "sold": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "total": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "domain.EventLogEntry": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "eventID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "holdID": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "kind": {
+                    "$ref": "#/definitions/domain.EventLogKind"
+                },
+                "orderID": {
+                    "type": "string"
+                },
+                "seatCount": {
+                    "type": "integer"
+                },
+                "userID": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "domain.EventLogKind": {
+            "type": "string",
+            "enum": [
+                "hold_created",
+                "hold_confirmed",
+                "hold_cancelled",
+                "hold_expired",
+                "hold_extended"
+            ],
+            "x-enum-varnames": [
+                "LogHoldCreated",
+                "LogHoldConfirmed",
+                "LogHoldCancelled",
+                "LogHoldExpired",
+                "LogHoldExtended"
+            ]
+        },
+        "domain.GACapacity": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "capacity": {
+                    "type": "integer"
+                },
+                "eventID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "held": {
+                    "type": "integer"
+                },
+                "priceCents": {
+                    "type": "integer"
+                },
+                "sold": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.HoldSummary": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "eventID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "seatCount": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "userID": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "domain.Order": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "eventID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "gaqty": {
+                    "description": "GAQty is the number of general-admission tickets bought.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "refundedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.OrderStatus"
+                },
+                "totalCents": {
+                    "type": "integer"
+                },
+                "userID": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "domain.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "confirmed",
+                "refunded"
+            ],
+            "x-enum-varnames": [
+                "OrderConfirmed",
+                "OrderRefunded"
+            ]
+        },
+        "domain.OrderWithTickets": {
+            "type": "object",
+            "properties": {
+                "order": {
+                    "$ref": "#/definitions/domain.Order"
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Ticket"
+                    }
+                }
+            }
+        },
+        "domain.Seat": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "row": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "venueID": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "domain.SeatStatus": {
+            "type": "string",
+            "enum": [
+                "available",
+                "held",
+                "sold"
+            ],
+            "x-enum-varnames": [
+                "SeatAvailable",
+                "SeatHeld",
+                "SeatSold"
+            ]
+        },
+        "domain.SeatWithStatus": {
+            "type": "object",
+            "properties": {
+                "hold_expires_at": {
+                    "description": "HoldExpiresAt is when the hold on a held seat lapses; nil otherwise.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "row": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.SeatStatus"
+                },
+                "venueID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "version": {
+                    "description": "Version changes whenever the seat changes status. Passing it back when\nholding the seat fails the hold if the seat changed in between.",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SectionCounts": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "held": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "sold": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "total": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "domain.Ticket": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "eventID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orderID": {
+                    "type": "string"
+                },
+                "seatID": {
+                    "description": "SeatID is 0 for general-admission tickets.",
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "domain.UserSeat": {
+            "type": "object",
+            "properties": {
+                "held_by_me": {
+                    "type": "boolean"
+                },
+                "hold_expires_at": {
+                    "description": "HoldExpiresAt is when the hold on a held seat lapses; nil otherwise.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "row": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.SeatStatus"
+                },
+                "venueID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "version": {
+                    "description": "Version changes whenever the seat changes status. Passing it back when\nholding the seat fails the hold if the seat changed in between.",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Venue": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seatingScheme": {
+                    "description": "jsonb raw",
+                    "type": "object"
+                }
+            }
+        },
+        "domain.WaitlistEntry": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "eventID": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "id": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "notifiedAt": {
+                    "type": "string"
+                },
+                "seatCount": {
+                    "type": "integer"
+                },
+                "userID": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "httpgin.AutoHoldRequest": {
+            "type": "object",
+            "required": [
+                "count"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "ttl_sec": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "UserID is optional; when set it must match the authenticated user.",
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.AutoHoldResponse": {
+            "type": "object",
+            "properties": {
+                "hold_id": {
+                    "type": "string"
+                },
+                "seat_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "httpgin.AvailabilityBatchRequest": {
+            "type": "object",
+            "required": [
+                "event_ids"
+            ],
+            "properties": {
+                "event_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "httpgin.AvailabilityBatchResponse": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/domain.EventCounts"
+                    }
+                }
+            }
+        },
+        "httpgin.BatchCreateSeatsRequest": {
+            "type": "object",
+            "required": [
+                "seats"
+            ],
+            "properties": {
+                "seats": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/httpgin.SeatInput"
+                    }
+                }
+            }
+        },
+        "httpgin.BatchCreateSeatsResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.ConfirmOrderRequest": {
+            "type": "object",
+            "required": [
+                "hold_id"
+            ],
+            "properties": {
+                "hold_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "UserID is optional; when set it must match the authenticated user.",
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.ConfirmOrderResponse": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpgin.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "client_name",
+                "scopes"
+            ],
+            "properties": {
+                "client_name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "httpgin.CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "client_name": {
+                    "type": "string"
+                },
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.APIKeyScope"
+                    }
+                }
+            }
+        },
+        "httpgin.CreateEventRequest": {
+            "type": "object",
+            "required": [
+                "ends_at",
+                "starts_at",
+                "title",
+                "venue_id"
+            ],
+            "properties": {
+                "ends_at": {
+                    "type": "string"
+                },
+                "price_cents": {
                     "type": "integer",
-                    "format": "int64"
+                    "minimum": 0
                 },
-                "starts": {
+                "starts_at": {
                     "type": "string"
                 },
                 "title": {
                     "type": "string"
                 },
-                "venueID": {
-                    "type": "integer",
-                    "format": "int64"
+                "venue_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.EventCounts": {
+        "httpgin.CreateEventResponse": {
             "type": "object",
             "properties": {
-                "available": {
-                    "type": "integer",
-                    "format": "int64"
+                "event_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.CreateHoldRequest": {
+            "type": "object",
+            "required": [
+                "seat_ids"
+            ],
+            "properties": {
+                "allow_partial": {
+                    "description": "AllowPartial holds the available seats instead of failing when some\nare taken. Ignored when Contiguous is set.",
+                    "type": "boolean"
                 },
-                "held": {
-                    "type": "integer",
-                    "format": "int64"
+                "contiguous": {
+                    "description": "Contiguous requires the seats to be side by side in one section and row.",
+                    "type": "boolean"
                 },
-                "sold": {
+                "seat_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "seat_versions": {
+                    "description": "SeatVersions optionally pins each seat to the version last read from a\nseat listing, aligned with SeatIDs. A seat that changed since then is\ntreated as taken.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "ttl_sec": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "UserID is optional; when set it must match the authenticated user.",
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.CreateHoldResponse": {
+            "type": "object",
+            "properties": {
+                "hold_id": {
+                    "type": "string"
+                },
+                "seat_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "unavailable_seat_ids": {
+                    "description": "UnavailableSeatIDs lists requested seats left out of a partial hold.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "httpgin.CreateRecurringEventsRequest": {
+            "type": "object",
+            "required": [
+                "slots",
+                "title",
+                "venue_id"
+            ],
+            "properties": {
+                "price_cents": {
                     "type": "integer",
-                    "format": "int64"
+                    "minimum": 0
                 },
-                "total": {
+                "slots": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/httpgin.TimeSlotInput"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "venue_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.CreateRecurringEventsResponse": {
+            "type": "object",
+            "properties": {
+                "event_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "httpgin.CreateVenueRequest": {
+            "type": "object"
+        },
+        "httpgin.CreateVenueResponse": {
+            "type": "object",
+            "properties": {
+                "venue_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpgin.FieldError"
+                    }
+                },
+                "unavailable_seat_ids": {
+                    "description": "UnavailableSeatIDs lists the requested seats that were taken when a\nhold fails with 409.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "httpgin.EventRevenueResponse": {
+            "type": "object",
+            "properties": {
+                "orders_count": {
+                    "type": "integer"
+                },
+                "tickets_sold": {
+                    "type": "integer"
+                },
+                "total_cents": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.ExpireHoldsRequest": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.ExpireHoldsResponse": {
+            "type": "object",
+            "properties": {
+                "released": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.ExtendHoldRequest": {
+            "type": "object",
+            "required": [
+                "extra_sec"
+            ],
+            "properties": {
+                "extra_sec": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.ExtendHoldResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "hold_id": {
+                    "type": "string"
+                },
+                "ttl_remaining_sec": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpgin.GAHoldRequest": {
+            "type": "object",
+            "required": [
+                "qty"
+            ],
+            "properties": {
+                "qty": {
                     "type": "integer",
-                    "format": "int64"
+                    "minimum": 1
+                },
+                "ttl_sec": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "UserID is optional; when set it must match the authenticated user.",
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.GAHoldResponse": {
+            "type": "object",
+            "properties": {
+                "hold_id": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.Order": {
+        "httpgin.HoldStatusResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "created_at": {
                     "type": "string"
                 },
-                "eventID": {
-                    "type": "integer",
-                    "format": "int64"
+                "event_id": {
+                    "type": "integer"
                 },
-                "id": {
+                "expires_at": {
                     "type": "string"
                 },
-                "totalCents": {
+                "ga_qty": {
+                    "description": "GAQty is the number of general-admission tickets held.",
                     "type": "integer"
                 },
-                "userID": {
-                    "type": "integer",
-                    "format": "int64"
+                "hold_id": {
+                    "type": "string"
+                },
+                "seat_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "ttl_remaining_sec": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.OrderWithTickets": {
+        "httpgin.JoinWaitlistRequest": {
             "type": "object",
+            "required": [
+                "seat_count"
+            ],
             "properties": {
-                "order": {
-                    "$ref": "#/definitions/domain.Order"
+                "seat_count": {
+                    "type": "integer"
                 },
-                "tickets": {
+                "user_id": {
+                    "description": "UserID is optional; when set it must match the authenticated user.",
+                    "type": "integer"
+                }
+            }
+        },
+        "httpgin.OrderTicketsRequest": {
+            "type": "object",
+            "required": [
+                "order_ids"
+            ],
+            "properties": {
+                "order_ids": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/domain.Ticket"
+                        "type": "string"
                     }
                 }
             }
         },
-        "domain.SeatStatus": {
-            "type": "string",
-            "enum": [
-                "available",
-                "held",
-                "sold"
-            ],
-            "x-enum-varnames": [
-                "SeatAvailable",
-                "SeatHeld",
-                "SeatSold"
-            ]
+        "httpgin.OrderTicketsResponse": {
+            "type": "object",
+            "properties": {
+                "tickets": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/domain.Ticket"
+                        }
+                    }
+                }
+            }
         },
-        "domain.SeatWithStatus": {
+        "httpgin.PostgresPoolStats": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "number": {
+                "acquire_count": {
                     "type": "integer"
                 },
-                "row": {
-                    "type": "string"
+                "acquire_duration_ms": {
+                    "type": "integer"
                 },
-                "section": {
-                    "type": "string"
+                "acquired_conns": {
+                    "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/domain.SeatStatus"
+                "canceled_acquire_count": {
+                    "type": "integer"
                 },
-                "venueID": {
-                    "type": "integer",
-                    "format": "int64"
-                }
-            }
-        },
-        "domain.Ticket": {
-            "type": "object",
-            "properties": {
-                "created": {
-                    "type": "string"
+                "constructing_conns": {
+                    "type": "integer"
                 },
-                "eventID": {
-                    "type": "integer",
-                    "format": "int64"
+                "empty_acquire_count": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "idle_conns": {
+                    "type": "integer"
                 },
-                "orderID": {
-                    "type": "string"
+                "max_conns": {
+                    "type": "integer"
                 },
-                "seatID": {
-                    "type": "integer",
-                    "format": "int64"
+                "total_conns": {
+                    "type": "integer"
                 }
             }
         },
-        "httpgin.BatchCreateSeatsRequest": {
+        "httpgin.ReadyResponse": {
             "type": "object",
-            "required": [
-                "seats"
-            ],
             "properties": {
-                "seats": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "$ref": "#/definitions/httpgin.SeatInput"
+                "failed": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "httpgin.ConfirmOrderRequest": {
+        "httpgin.RedisPoolStats": {
             "type": "object",
-            "required": [
-                "hold_id",
-                "total_cents"
-            ],
             "properties": {
-                "hold_id": {
-                    "type": "string"
+                "hits": {
+                    "type": "integer"
                 },
-                "total_cents": {
+                "idle_conns": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "stale_conns": {
+                    "type": "integer"
+                },
+                "timeouts": {
+                    "type": "integer"
+                },
+                "total_conns": {
                     "type": "integer"
                 }
             }
         },
-        "httpgin.ConfirmOrderResponse": {
+        "httpgin.RefundOrderResponse": {
             "type": "object",
             "properties": {
-                "event_id": {
+                "released_seats": {
                     "type": "integer"
-                },
-                "order_id": {
-                    "type": "string"
                 }
             }
         },
-        "httpgin.CreateEventRequest": {
+        "httpgin.SeatInput": {
             "type": "object",
             "required": [
-                "ends_at",
-                "starts_at",
-                "title",
-                "venue_id"
+                "number",
+                "row",
+                "section"
             ],
             "properties": {
-                "ends_at": {
-                    "type": "string"
+                "number": {
+                    "type": "integer"
                 },
-                "starts_at": {
+                "row": {
+                    "description": "Row is a positive integer, e.g. \"12\".",
                     "type": "string"
                 },
-                "title": {
+                "section": {
                     "type": "string"
+                }
+            }
+        },
+        "httpgin.SeatMapMessage": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpgin.SeatStatusChange"
+                    }
                 },
-                "venue_id": {
-                    "type": "integer"
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SeatWithStatus"
+                    }
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "httpgin.CreateEventResponse": {
+        "httpgin.SeatStatusChange": {
             "type": "object",
             "properties": {
-                "event_id": {
+                "seat_id": {
                     "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.SeatStatus"
                 }
             }
         },
-        "httpgin.CreateHoldRequest": {
+        "httpgin.SeatStatusesRequest": {
             "type": "object",
             "required": [
-                "seat_ids",
-                "user_id"
+                "seat_ids"
             ],
             "properties": {
                 "seat_ids": {
                     "type": "array",
+                    "maxItems": 1000,
                     "minItems": 1,
                     "items": {
                         "type": "integer"
                     }
+                }
+            }
+        },
+        "httpgin.SeatStatusesResponse": {
+            "type": "object",
+            "properties": {
+                "statuses": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/domain.SeatStatus"
+                    }
+                }
+            }
+        },
+        "httpgin.SetGACapacityRequest": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "ttl_sec": {
-                    "type": "integer"
+                "price_cents": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "httpgin.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "postgres": {
+                    "$ref": "#/definitions/httpgin.PostgresPoolStats"
                 },
-                "user_id": {
-                    "type": "integer"
+                "redis": {
+                    "$ref": "#/definitions/httpgin.RedisPoolStats"
                 }
             }
         },
-        "httpgin.CreateHoldResponse": {
+        "httpgin.TicketQRResponse": {
             "type": "object",
             "properties": {
-                "hold_id": {
+                "png": {
+                    "description": "PNG is the base64-encoded QR code image.",
+                    "type": "string"
+                },
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "httpgin.CreateVenueRequest": {
-            "type": "object"
+        "httpgin.TicketResponse": {
+            "type": "object",
+            "properties": {
+                "signature": {
+                    "type": "string"
+                },
+                "ticket": {
+                    "$ref": "#/definitions/domain.Ticket"
+                }
+            }
         },
-        "httpgin.CreateVenueResponse": {
+        "httpgin.TicketVerifyResponse": {
             "type": "object",
             "properties": {
-                "venue_id": {
-                    "type": "integer"
+                "valid": {
+                    "type": "boolean"
                 }
             }
         },
-        "httpgin.ErrorResponse": {
+        "httpgin.TimeSlotInput": {
             "type": "object",
+            "required": [
+                "ends_at",
+                "starts_at"
+            ],
             "properties": {
-                "error": {
+                "ends_at": {
+                    "type": "string"
+                },
+                "starts_at": {
                     "type": "string"
                 }
             }
         },
-        "httpgin.SeatInput": {
+        "httpgin.UpdateEventRequest": {
             "type": "object",
             "required": [
-                "number",
-                "row",
-                "section"
+                "ends_at",
+                "starts_at",
+                "title"
             ],
+            "properties": {
+                "ends_at": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpgin.UpdateSeatRequest": {
+            "type": "object",
             "properties": {
                 "number": {
                     "type": "integer"
@@ -568,9 +3211,35 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "section": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
+        "httpgin.UpdateVenueRequest": {
+            "type": "object"
+        },
+        "httpgin.VerifyTicketTokenRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
                     "type": "string"
                 }
             }
+        },
+        "httpgin.VerifyTicketTokenResponse": {
+            "type": "object",
+            "properties": {
+                "ticket": {
+                    "$ref": "#/definitions/domain.Ticket"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
         }
     }
 }`
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -10,14 +11,22 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/kirinyoku/tix-go/internal/config"
+	"github.com/kirinyoku/tix-go/internal/metrics"
 	"github.com/kirinyoku/tix-go/internal/postgres"
 	"github.com/kirinyoku/tix-go/internal/redis"
 	postgresrepo "github.com/kirinyoku/tix-go/internal/repository/postgres"
 	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
 	"github.com/kirinyoku/tix-go/internal/service"
+	"github.com/kirinyoku/tix-go/internal/service/orders"
+	"github.com/kirinyoku/tix-go/internal/service/query"
 	"github.com/kirinyoku/tix-go/internal/service/reservation"
+	"github.com/kirinyoku/tix-go/internal/tracing"
 	httpgin "github.com/kirinyoku/tix-go/internal/transport/http/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	goredis "github.com/redis/go-redis/v9"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -25,6 +34,12 @@ type App struct {
 	cfg        *config.Config
 	logger     *slog.Logger
 	httpServer *http.Server
+	pgxPool    *pgxpool.Pool
+	rdb        *goredis.Client
+	pubsub     *redisrepo.EventsPubSub
+	services   *service.Services
+	// shutdownTracing flushes pending spans; a no-op when tracing is off.
+	shutdownTracing func(context.Context) error
 }
 
 func New(cfg *config.Config, logger *slog.Logger) (*App, error) {
@@ -39,30 +54,104 @@ func New(cfg *config.Config, logger *slog.Logger) (*App, error) {
 		cfg.Postgres.SSLMode,
 	)
 
-	pgxPool, err := postgres.New(context.Background(), postgres.Config{DSN: dsn})
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+
+	pgxPool, err := postgres.New(context.Background(), postgres.Config{
+		DSN:             dsn,
+		MaxConns:        cfg.Postgres.MaxConns,
+		MinConns:        cfg.Postgres.MinConns,
+		MaxConnLifetime: cfg.Postgres.MaxConnLifetime,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize postgres: %w", err)
 	}
 
 	rdb, err := redis.New(context.Background(), redis.Config{Addr: cfg.Redis.Addr, Password: cfg.Redis.Password, DB: cfg.Redis.DB})
 	if err != nil {
+		pgxPool.Close()
 		return nil, fmt.Errorf("failed to initialize redis: %w", err)
 	}
 
 	// Initialize repositories
 	store := postgresrepo.NewStore(pgxPool)
-	cache := redisrepo.New(rdb)
+	cache := redisrepo.New(rdb,
+		redisrepo.WithTTLJitter(cfg.Redis.CacheTTLJitter),
+		redisrepo.WithLogger(logger),
+		redisrepo.WithCircuitBreaker(
+			cfg.Redis.CacheBreakerThreshold,
+			cfg.Redis.CacheBreakerCooldown,
+			cfg.Redis.CacheBreakerMaxCooldown,
+		),
+	)
 	pubsub := redisrepo.NewEventsPubSub(rdb)
-	limiter := redisrepo.NewSlidingWindowLimiter(rdb, "rl", 10, 1*time.Minute)
-	idempotencyStore := redisrepo.NewIdempotencyStore(rdb, 2*time.Hour)
+	ipLimiter := redisrepo.NewSlidingWindowLimiter(rdb, "rl:hold:client", cfg.RateLimit.HoldsPerIP, cfg.RateLimit.Window)
+	userLimiter := redisrepo.NewSlidingWindowLimiter(rdb, "rl:hold:user", cfg.RateLimit.HoldsPerUser, cfg.RateLimit.Window)
+
+	var writeLimiter reservation.Limiter
+	if cfg.RateLimit.WritesPerIP > 0 {
+		writeLimiter = redisrepo.NewSlidingWindowLimiter(rdb, "rl:write", cfg.RateLimit.WritesPerIP, cfg.RateLimit.Window)
+	}
+
+	var apiKeyLimiter reservation.Limiter
+	if cfg.RateLimit.APIKeysPerIP > 0 {
+		apiKeyLimiter = redisrepo.NewSlidingWindowLimiter(rdb, "rl:apikey", cfg.RateLimit.APIKeysPerIP, cfg.RateLimit.Window)
+	}
+
+	idempotencyStore := redisrepo.NewIdempotencyStore(rdb, 2*time.Hour, cfg.Redis.IdempotencyLockTTL)
+
+	// Initialize metrics
+	m := metrics.New(prometheus.NewRegistry())
+
+	holdCutoff := reservation.HoldCutoffEventEnd
+	if cfg.Reservation.HoldCutoff == "start" {
+		holdCutoff = reservation.HoldCutoffEventStart
+	}
 
 	// Initialize services
-	services := service.NewServices(store, cache, pubsub, limiter, service.Config{
-		Reservation: reservation.Config{},
+	services := service.NewServices(store, cache, pubsub, ipLimiter, userLimiter, m, service.Config{
+		Query: query.Config{CacheEventSeatMap: true},
+		Reservation: reservation.Config{
+			IsolationLevel:  pgx.TxIsoLevel(cfg.Reservation.IsolationLevel),
+			MaxSeatsPerUser: cfg.Reservation.MaxSeatsPerUser,
+			MaxSeatsPerHold: cfg.Reservation.MaxSeatsPerHold,
+			HoldCutoff:      holdCutoff,
+			MinHoldTTL:      cfg.Reservation.MinHoldTTL,
+			MaxHoldTTL:      cfg.Reservation.MaxHoldTTL,
+			Notifier:        reservation.LogNotifier{Logger: logger},
+		},
+		Orders: orders.Config{
+			TicketSecret: []byte(cfg.Ticket.SigningSecret),
+		},
+		Logger: logger,
 	})
 
 	// Initialize Gin router
-	router := httpgin.NewRouter(services, idempotencyStore, logger)
+	router := httpgin.NewRouter(services, idempotencyStore, logger, httpgin.RouterConfig{
+		AdminToken:         cfg.Admin.Token,
+		Metrics:            m,
+		Events:             pubsub,
+		WSIdleTimeout:      cfg.Server.WSIdleTimeout,
+		MaxBodyBytes:       cfg.Server.MaxBodyBytes,
+		GzipMinBytes:       cfg.Server.GzipMinBytes,
+		TrustedCIDRs:       cfg.RateLimit.TrustedCIDRs,
+		TrustedProxies:     cfg.Server.TrustedProxies,
+		WriteLimiter:       writeLimiter,
+		APIKeyLimiter:      apiKeyLimiter,
+		LogBodyMaxBytes:    cfg.Log.BodyMaxBytes,
+		JWTSecret:          cfg.Auth.JWTSecret,
+		AllowDevUserHeader: cfg.Auth.DevUserHeader,
+		Postgres:           pgxPool,
+		Redis:              rdb,
+		ReadyChecks: map[string]httpgin.ReadyCheck{
+			"postgres": pgxPool.Ping,
+			"redis": func(ctx context.Context) error {
+				return rdb.Ping(ctx).Err()
+			},
+		},
+	})
 
 	return &App{
 		cfg:    cfg,
@@ -71,6 +160,12 @@ func New(cfg *config.Config, logger *slog.Logger) (*App, error) {
 			Addr:    fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
 			Handler: router,
 		},
+		pgxPool:  pgxPool,
+		rdb:      rdb,
+		pubsub:   pubsub,
+		services: services,
+
+		shutdownTracing: shutdownTracing,
 	}, nil
 }
 
@@ -89,14 +184,85 @@ func (a *App) Run(ctx context.Context) error {
 		return nil
 	})
 
+	// Listen for event-changed notifications
+	g.Go(func() error {
+		err := a.pubsub.Subscribe(gCtx, a.handleEventChanged)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			a.logger.Error("events subscriber stopped", "error", err)
+		}
+		return nil
+	})
+
+	// Release expired holds in the background
+	g.Go(func() error {
+		a.runHoldExpiry(gCtx)
+		return nil
+	})
+
 	// Graceful shutdown
 	g.Go(func() error {
 		<-gCtx.Done()
 		a.logger.Info("shutting down HTTP server")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Server.ShutdownTimeout)
 		defer cancel()
 		return a.httpServer.Shutdown(ctx)
 	})
 
-	return g.Wait()
+	err := g.Wait()
+
+	// Close connections only after the HTTP server has drained and the
+	// subscriber has stopped, so in-flight requests can still use them.
+	a.close()
+
+	return err
+}
+
+// runHoldExpiry releases expired holds every HoldExpiryInterval until ctx is
+// cancelled. Failures are logged and retried on the next tick.
+func (a *App) runHoldExpiry(ctx context.Context) {
+	ticker := time.NewTicker(a.cfg.Server.HoldExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, eventIDs, err := a.services.Reservation.Expire(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					a.logger.Error("failed to expire holds", "error", err)
+				}
+				continue
+			}
+			if released > 0 {
+				a.logger.Info("expired holds", "released_seats", released, "event_ids", eventIDs)
+			}
+		}
+	}
+}
+
+func (a *App) close() {
+	a.logger.Info("closing connections")
+
+	if err := a.rdb.Close(); err != nil {
+		a.logger.Error("failed to close redis client", "error", err)
+	}
+
+	a.pgxPool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := a.shutdownTracing(ctx); err != nil {
+		a.logger.Error("failed to flush traces", "error", err)
+	}
+}
+
+func (a *App) handleEventChanged(ctx context.Context, eventID int64) {
+	a.logger.DebugContext(ctx, "event changed", "event_id", eventID)
+
+	if _, err := a.services.Reservation.NotifyWaitlist(ctx, eventID); err != nil {
+		a.logger.ErrorContext(ctx, "failed to process waitlist", "event_id", eventID, "error", err)
+	}
 }
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// apiKeyPrefix marks API keys so they are easy to tell apart from JWTs and
+// to spot in leaked text.
+const apiKeyPrefix = "tix_"
+
+// NewAPIKey returns a random API key and its hash for storage. The key
+// itself is shown to the client once and never stored.
+func NewAPIKey() (string, []byte, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	return key, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the SHA-256 hash under which key is stored.
+func HashAPIKey(key string) []byte {
+	h := sha256.Sum256([]byte(key))
+
+	return h[:]
+}
+
+// apiKeyLen is the length of the keys returned by NewAPIKey.
+var apiKeyLen = len(apiKeyPrefix) + base64.RawURLEncoding.EncodedLen(32)
+
+// WellFormedAPIKey reports whether key has the shape of a key returned by
+// NewAPIKey, so obvious junk can be rejected without a lookup.
+func WellFormedAPIKey(key string) bool {
+	return len(key) == apiKeyLen && strings.HasPrefix(key, apiKeyPrefix)
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, hash, err := NewAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !WellFormedAPIKey(key) {
+		t.Errorf("WellFormedAPIKey(%q) = false", key)
+	}
+	if !bytes.Equal(hash, HashAPIKey(key)) {
+		t.Error("hash does not match HashAPIKey(key)")
+	}
+
+	other, _, err := NewAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == key {
+		t.Error("NewAPIKey returned the same key twice")
+	}
+}
+
+func TestWellFormedAPIKey(t *testing.T) {
+	key, _, err := NewAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{name: "issued", key: key, want: true},
+		{name: "empty", key: "", want: false},
+		{name: "no prefix", key: "xxx_" + strings.TrimPrefix(key, apiKeyPrefix), want: false},
+		{name: "short", key: key[:len(key)-1], want: false},
+		{name: "long", key: key + "a", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WellFormedAPIKey(tt.key); got != tt.want {
+				t.Errorf("WellFormedAPIKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package auth issues and checks the bearer JWTs that identify API users.
+package auth
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Roles carried in the role claim.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// Claims are the claims of an API token. The subject is the decimal user ID.
+type Claims struct {
+	Role string `json:"role,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// IssueToken returns an HS256 JWT for userID with the given role, valid for
+// ttl.
+func IssueToken(userID int64, role string, ttl time.Duration, secret []byte) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(userID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+// ParseToken validates an HS256 JWT signed with secret and returns the user
+// ID from its subject and its role. Tokens must carry an expiry. An empty
+// secret rejects every token.
+//
+// Returns:
+//   - int64: the user ID.
+//   - string: the role claim, empty if absent.
+//   - error: auth.ErrTokenExpired if the token has expired.
+//   - error: auth.ErrInvalidToken for any other malformed or unverifiable token.
+func ParseToken(token string, secret []byte) (int64, string, error) {
+	if len(secret) == 0 {
+		return 0, "", ErrInvalidToken
+	}
+
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims,
+		func(*jwt.Token) (any, error) { return secret, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return 0, "", ErrTokenExpired
+		}
+		return 0, "", ErrInvalidToken
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, "", ErrInvalidToken
+	}
+
+	return userID, claims.Role, nil
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestParseToken(t *testing.T) {
+	secret := []byte("test-secret")
+
+	sign := func(t *testing.T, method jwt.SigningMethod, key any, claims jwt.Claims) string {
+		t.Helper()
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	issue := func(t *testing.T, userID int64, role string, ttl time.Duration) string {
+		t.Helper()
+		token, err := IssueToken(userID, role, ttl, secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		secret   []byte
+		wantUser int64
+		wantRole string
+		wantErr  error
+	}{
+		{name: "valid user", token: issue(t, 42, RoleUser, time.Hour), secret: secret, wantUser: 42, wantRole: RoleUser},
+		{name: "valid admin", token: issue(t, 1, RoleAdmin, time.Hour), secret: secret, wantUser: 1, wantRole: RoleAdmin},
+		{name: "expired", token: issue(t, 42, RoleUser, -time.Minute), secret: secret, wantErr: ErrTokenExpired},
+		{name: "wrong secret", token: issue(t, 42, RoleUser, time.Hour), secret: []byte("other"), wantErr: ErrInvalidToken},
+		{name: "empty secret", token: issue(t, 42, RoleUser, time.Hour), secret: nil, wantErr: ErrInvalidToken},
+		{name: "garbage", token: "not-a-jwt", secret: secret, wantErr: ErrInvalidToken},
+		{
+			name:    "alg none",
+			token:   sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "42", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}}),
+			secret:  secret,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "no expiry",
+			token:   sign(t, jwt.SigningMethodHS256, secret, Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "42"}}),
+			secret:  secret,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "non-numeric subject",
+			token:   sign(t, jwt.SigningMethodHS256, secret, Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "bob", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}}),
+			secret:  secret,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "zero subject",
+			token:   sign(t, jwt.SigningMethodHS256, secret, Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "0", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}}),
+			secret:  secret,
+			wantErr: ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, role, err := ParseToken(tt.token, tt.secret)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if userID != tt.wantUser || role != tt.wantRole {
+				t.Errorf("got (%d, %q), want (%d, %q)", userID, role, tt.wantUser, tt.wantRole)
+			}
+		})
+	}
+}
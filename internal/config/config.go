@@ -2,36 +2,138 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Postgres PostgresConfig
-	Redis    RedisConfig
+	Server      ServerConfig
+	Postgres    PostgresConfig
+	Redis       RedisConfig
+	Admin       AdminConfig
+	RateLimit   RateLimitConfig
+	Ticket      TicketConfig
+	Reservation ReservationConfig
+	Log         LogConfig
+	Auth        AuthConfig
+}
+
+type AuthConfig struct {
+	// JWTSecret is the HS256 key for bearer JWTs. Hold and confirm
+	// requests are rejected when it is empty, unless DevUserHeader is set,
+	// and /admin then only accepts the static admin token.
+	JWTSecret string
+	// DevUserHeader trusts the X-User-ID header on requests without a
+	// token. For development only.
+	DevUserHeader bool
+}
+
+type LogConfig struct {
+	// Format is the log output format: "text" or "json".
+	Format string
+	Level  slog.Level
+	// BodyMaxBytes, when positive, logs request and response bodies of up to
+	// this many bytes at debug level; zero disables body logging.
+	BodyMaxBytes int
 }
 
 type ServerConfig struct {
-	Host string
-	Port int
+	Host            string
+	Port            int
+	WSIdleTimeout   time.Duration
+	ShutdownTimeout time.Duration
+	// HoldExpiryInterval is how often expired holds are released in the
+	// background.
+	HoldExpiryInterval time.Duration
+	// MaxBodyBytes caps the size of request bodies.
+	MaxBodyBytes int64
+	// GzipMinBytes is the smallest read response that is gzip-compressed.
+	GzipMinBytes int
+	// TrustedProxies lists the proxies whose X-Forwarded-For header is
+	// believed when resolving the client IP. Empty trusts no proxy.
+	TrustedProxies []netip.Prefix
 }
 
 type RedisConfig struct {
 	Addr     string
 	Password string
 	DB       int
+	// CacheTTLJitter is the fraction (0..1) by which cache TTLs are randomly
+	// shortened or extended to avoid synchronized expiry.
+	CacheTTLJitter float64
+	// IdempotencyLockTTL bounds how long an in-flight idempotent request
+	// holds its key before retries may run it again.
+	IdempotencyLockTTL time.Duration
+	// CacheBreakerThreshold is the number of consecutive Redis failures
+	// after which cache calls are skipped; zero disables the breaker.
+	CacheBreakerThreshold int
+	// CacheBreakerCooldown is how long cache calls are skipped once the
+	// breaker opens. It doubles after every failed probe, up to
+	// CacheBreakerMaxCooldown.
+	CacheBreakerCooldown    time.Duration
+	CacheBreakerMaxCooldown time.Duration
+}
+
+type AdminConfig struct {
+	Token string
+}
+
+type TicketConfig struct {
+	// SigningSecret is the HMAC key for ticket signatures. Ticket endpoints
+	// are disabled when it is empty.
+	SigningSecret string
+}
+
+type ReservationConfig struct {
+	// IsolationLevel is the PostgreSQL isolation level used for
+	// transactions that change seat state: "serializable" or
+	// "repeatable read".
+	IsolationLevel string
+	// MaxSeatsPerUser caps the seats one user may hold and own per event;
+	// zero disables the cap.
+	MaxSeatsPerUser int
+	// MaxSeatsPerHold caps the seats a single hold request may take.
+	MaxSeatsPerHold int
+	// HoldCutoff is the point in an event's schedule after which no new
+	// holds are accepted: "end" or "start".
+	HoldCutoff string
+	// MinHoldTTL and MaxHoldTTL bound the TTL a client may request for a
+	// hold.
+	MinHoldTTL time.Duration
+	MaxHoldTTL time.Duration
+}
+
+type RateLimitConfig struct {
+	HoldsPerIP   int
+	HoldsPerUser int
+	Window       time.Duration
+	// WritesPerIP caps the other write requests (confirm, refund, cancel,
+	// admin writes) per client IP and window; zero disables the limit.
+	WritesPerIP int
+	// APIKeysPerIP caps the requests carrying an API key per client IP and
+	// window, bounding key guessing; zero disables the limit.
+	APIKeysPerIP int
+	// TrustedCIDRs lists client networks exempt from the per-IP hold limit,
+	// e.g. box office terminals.
+	TrustedCIDRs []netip.Prefix
 }
 
 type PostgresConfig struct {
-	User     string
-	Password string
-	Name     string
-	Host     string
-	Port     int
-	SSLMode  string
+	User            string
+	Password        string
+	Name            string
+	Host            string
+	Port            int
+	SSLMode         string
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
 }
 
 func New() (*Config, error) {
@@ -54,9 +156,90 @@ func New() (*Config, error) {
 		return nil, fmt.Errorf("%s: invalid SERVER_PORT: %w", op, err)
 	}
 
+	wsIdleTimeoutStr := os.Getenv("SERVER_WS_IDLE_TIMEOUT")
+	if wsIdleTimeoutStr == "" {
+		wsIdleTimeoutStr = "60s"
+	}
+
+	wsIdleTimeout, err := time.ParseDuration(wsIdleTimeoutStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid SERVER_WS_IDLE_TIMEOUT: %w", op, err)
+	}
+
+	if wsIdleTimeout <= 0 {
+		return nil, fmt.Errorf("%s: invalid SERVER_WS_IDLE_TIMEOUT: must be positive", op)
+	}
+
+	shutdownTimeoutStr := os.Getenv("SERVER_SHUTDOWN_TIMEOUT")
+	if shutdownTimeoutStr == "" {
+		shutdownTimeoutStr = "5s"
+	}
+
+	shutdownTimeout, err := time.ParseDuration(shutdownTimeoutStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid SERVER_SHUTDOWN_TIMEOUT: %w", op, err)
+	}
+
+	if shutdownTimeout <= 0 {
+		return nil, fmt.Errorf("%s: invalid SERVER_SHUTDOWN_TIMEOUT: must be positive", op)
+	}
+
+	holdExpiryIntervalStr := os.Getenv("SERVER_HOLD_EXPIRY_INTERVAL")
+	if holdExpiryIntervalStr == "" {
+		holdExpiryIntervalStr = "10s"
+	}
+
+	holdExpiryInterval, err := time.ParseDuration(holdExpiryIntervalStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid SERVER_HOLD_EXPIRY_INTERVAL: %w", op, err)
+	}
+
+	if holdExpiryInterval <= 0 {
+		return nil, fmt.Errorf("%s: invalid SERVER_HOLD_EXPIRY_INTERVAL: must be positive", op)
+	}
+
+	maxBodyBytesStr := os.Getenv("SERVER_MAX_BODY_BYTES")
+	if maxBodyBytesStr == "" {
+		maxBodyBytesStr = "1048576"
+	}
+
+	maxBodyBytes, err := strconv.ParseInt(maxBodyBytesStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid SERVER_MAX_BODY_BYTES: %w", op, err)
+	}
+
+	if maxBodyBytes <= 0 {
+		return nil, fmt.Errorf("%s: invalid SERVER_MAX_BODY_BYTES: must be positive", op)
+	}
+
+	gzipMinBytesStr := os.Getenv("SERVER_GZIP_MIN_BYTES")
+	if gzipMinBytesStr == "" {
+		gzipMinBytesStr = "1024"
+	}
+
+	gzipMinBytes, err := strconv.Atoi(gzipMinBytesStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid SERVER_GZIP_MIN_BYTES: %w", op, err)
+	}
+
+	if gzipMinBytes <= 0 {
+		return nil, fmt.Errorf("%s: invalid SERVER_GZIP_MIN_BYTES: must be positive", op)
+	}
+
+	trustedProxies, err := parsePrefixes(os.Getenv("SERVER_TRUSTED_PROXIES"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid SERVER_TRUSTED_PROXIES: %w", op, err)
+	}
+
 	serverCfg := ServerConfig{
-		Host: serverHost,
-		Port: serverPort,
+		Host:               serverHost,
+		Port:               serverPort,
+		WSIdleTimeout:      wsIdleTimeout,
+		ShutdownTimeout:    shutdownTimeout,
+		HoldExpiryInterval: holdExpiryInterval,
+		MaxBodyBytes:       maxBodyBytes,
+		GzipMinBytes:       gzipMinBytes,
+		TrustedProxies:     trustedProxies,
 	}
 
 	postregsHost := os.Getenv("POSTGRES_HOST")
@@ -94,13 +277,57 @@ func New() (*Config, error) {
 		postgresSSLMode = "disable"
 	}
 
+	postgresMaxConnsStr := os.Getenv("POSTGRES_MAX_CONNS")
+	if postgresMaxConnsStr == "" {
+		postgresMaxConnsStr = "10"
+	}
+
+	postgresMaxConns, err := strconv.ParseInt(postgresMaxConnsStr, 10, 32)
+	if err != nil || postgresMaxConns <= 0 {
+		return nil, fmt.Errorf("%s: invalid POSTGRES_MAX_CONNS: must be a positive integer", op)
+	}
+
+	postgresMinConnsStr := os.Getenv("POSTGRES_MIN_CONNS")
+	if postgresMinConnsStr == "" {
+		postgresMinConnsStr = "0"
+	}
+
+	postgresMinConns, err := strconv.ParseInt(postgresMinConnsStr, 10, 32)
+	if err != nil || postgresMinConns < 0 {
+		return nil, fmt.Errorf("%s: invalid POSTGRES_MIN_CONNS: must be a non-negative integer", op)
+	}
+
+	if postgresMinConns > postgresMaxConns {
+		return nil, fmt.Errorf(
+			"%s: invalid POSTGRES_MIN_CONNS: must not exceed POSTGRES_MAX_CONNS (%d > %d)",
+			op, postgresMinConns, postgresMaxConns,
+		)
+	}
+
+	postgresMaxConnLifetimeStr := os.Getenv("POSTGRES_MAX_CONN_LIFETIME")
+	if postgresMaxConnLifetimeStr == "" {
+		postgresMaxConnLifetimeStr = "1h"
+	}
+
+	postgresMaxConnLifetime, err := time.ParseDuration(postgresMaxConnLifetimeStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid POSTGRES_MAX_CONN_LIFETIME: %w", op, err)
+	}
+
+	if postgresMaxConnLifetime <= 0 {
+		return nil, fmt.Errorf("%s: invalid POSTGRES_MAX_CONN_LIFETIME: must be positive", op)
+	}
+
 	postgresCfg := PostgresConfig{
-		User:     postgresUser,
-		Password: postgresPassword,
-		Name:     postgresDB,
-		Host:     postregsHost,
-		Port:     postregsPort,
-		SSLMode:  postgresSSLMode,
+		User:            postgresUser,
+		Password:        postgresPassword,
+		Name:            postgresDB,
+		Host:            postregsHost,
+		Port:            postregsPort,
+		SSLMode:         postgresSSLMode,
+		MaxConns:        int32(postgresMaxConns),
+		MinConns:        int32(postgresMinConns),
+		MaxConnLifetime: postgresMaxConnLifetime,
 	}
 
 	redisAddr := os.Getenv("REDIS_ADDR")
@@ -108,15 +335,349 @@ func New() (*Config, error) {
 		redisAddr = "localhost:6380"
 	}
 
+	redisDBStr := os.Getenv("REDIS_DB")
+	if redisDBStr == "" {
+		redisDBStr = "0"
+	}
+
+	redisDB, err := strconv.Atoi(redisDBStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid REDIS_DB: %w", op, err)
+	}
+
+	if redisDB < 0 {
+		return nil, fmt.Errorf("%s: invalid REDIS_DB: must be non-negative, got %d", op, redisDB)
+	}
+
+	cacheTTLJitterStr := os.Getenv("REDIS_CACHE_TTL_JITTER")
+	if cacheTTLJitterStr == "" {
+		cacheTTLJitterStr = "0.1"
+	}
+
+	cacheTTLJitter, err := strconv.ParseFloat(cacheTTLJitterStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid REDIS_CACHE_TTL_JITTER: %w", op, err)
+	}
+
+	if cacheTTLJitter < 0 || cacheTTLJitter >= 1 {
+		return nil, fmt.Errorf("%s: invalid REDIS_CACHE_TTL_JITTER: must be in [0, 1), got %v", op, cacheTTLJitter)
+	}
+
+	idemLockTTLStr := os.Getenv("REDIS_IDEMPOTENCY_LOCK_TTL")
+	if idemLockTTLStr == "" {
+		idemLockTTLStr = "60s"
+	}
+
+	idemLockTTL, err := time.ParseDuration(idemLockTTLStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid REDIS_IDEMPOTENCY_LOCK_TTL: %w", op, err)
+	}
+
+	if idemLockTTL <= 0 {
+		return nil, fmt.Errorf("%s: invalid REDIS_IDEMPOTENCY_LOCK_TTL: must be positive", op)
+	}
+
+	breakerThresholdStr := os.Getenv("REDIS_CACHE_BREAKER_THRESHOLD")
+	if breakerThresholdStr == "" {
+		breakerThresholdStr = "5"
+	}
+
+	breakerThreshold, err := strconv.Atoi(breakerThresholdStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid REDIS_CACHE_BREAKER_THRESHOLD: %w", op, err)
+	}
+
+	if breakerThreshold < 0 {
+		return nil, fmt.Errorf("%s: invalid REDIS_CACHE_BREAKER_THRESHOLD: must not be negative", op)
+	}
+
+	breakerCooldownStr := os.Getenv("REDIS_CACHE_BREAKER_COOLDOWN")
+	if breakerCooldownStr == "" {
+		breakerCooldownStr = "5s"
+	}
+
+	breakerCooldown, err := time.ParseDuration(breakerCooldownStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid REDIS_CACHE_BREAKER_COOLDOWN: %w", op, err)
+	}
+
+	if breakerCooldown <= 0 {
+		return nil, fmt.Errorf("%s: invalid REDIS_CACHE_BREAKER_COOLDOWN: must be positive", op)
+	}
+
+	breakerMaxCooldownStr := os.Getenv("REDIS_CACHE_BREAKER_MAX_COOLDOWN")
+	if breakerMaxCooldownStr == "" {
+		breakerMaxCooldownStr = "1m"
+	}
+
+	breakerMaxCooldown, err := time.ParseDuration(breakerMaxCooldownStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid REDIS_CACHE_BREAKER_MAX_COOLDOWN: %w", op, err)
+	}
+
+	if breakerMaxCooldown < breakerCooldown {
+		return nil, fmt.Errorf("%s: invalid REDIS_CACHE_BREAKER_MAX_COOLDOWN: must not be less than REDIS_CACHE_BREAKER_COOLDOWN", op)
+	}
+
 	redisCfg := RedisConfig{
-		Addr:     redisAddr,
-		Password: "",
-		DB:       0,
+		Addr:               redisAddr,
+		Password:           os.Getenv("REDIS_PASSWORD"),
+		DB:                 redisDB,
+		CacheTTLJitter:     cacheTTLJitter,
+		IdempotencyLockTTL: idemLockTTL,
+
+		CacheBreakerThreshold:   breakerThreshold,
+		CacheBreakerCooldown:    breakerCooldown,
+		CacheBreakerMaxCooldown: breakerMaxCooldown,
+	}
+
+	adminCfg := AdminConfig{
+		Token: os.Getenv("ADMIN_TOKEN"),
+	}
+
+	holdsPerIPStr := os.Getenv("RATE_LIMIT_HOLDS_PER_IP")
+	if holdsPerIPStr == "" {
+		holdsPerIPStr = "10"
+	}
+
+	holdsPerIP, err := strconv.Atoi(holdsPerIPStr)
+	if err != nil || holdsPerIP <= 0 {
+		return nil, fmt.Errorf("%s: invalid RATE_LIMIT_HOLDS_PER_IP: must be a positive integer", op)
+	}
+
+	holdsPerUserStr := os.Getenv("RATE_LIMIT_HOLDS_PER_USER")
+	if holdsPerUserStr == "" {
+		holdsPerUserStr = "5"
+	}
+
+	holdsPerUser, err := strconv.Atoi(holdsPerUserStr)
+	if err != nil || holdsPerUser <= 0 {
+		return nil, fmt.Errorf("%s: invalid RATE_LIMIT_HOLDS_PER_USER: must be a positive integer", op)
+	}
+
+	rateLimitWindowStr := os.Getenv("RATE_LIMIT_WINDOW")
+	if rateLimitWindowStr == "" {
+		rateLimitWindowStr = "1m"
+	}
+
+	rateLimitWindow, err := time.ParseDuration(rateLimitWindowStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid RATE_LIMIT_WINDOW: %w", op, err)
+	}
+
+	if rateLimitWindow <= 0 {
+		return nil, fmt.Errorf("%s: invalid RATE_LIMIT_WINDOW: must be positive", op)
+	}
+
+	writesPerIPStr := os.Getenv("RATE_LIMIT_WRITES_PER_IP")
+	if writesPerIPStr == "" {
+		writesPerIPStr = "30"
+	}
+
+	writesPerIP, err := strconv.Atoi(writesPerIPStr)
+	if err != nil || writesPerIP < 0 {
+		return nil, fmt.Errorf("%s: invalid RATE_LIMIT_WRITES_PER_IP: must be a non-negative integer", op)
+	}
+
+	apiKeysPerIPStr := os.Getenv("RATE_LIMIT_API_KEYS_PER_IP")
+	if apiKeysPerIPStr == "" {
+		apiKeysPerIPStr = "600"
+	}
+
+	apiKeysPerIP, err := strconv.Atoi(apiKeysPerIPStr)
+	if err != nil || apiKeysPerIP < 0 {
+		return nil, fmt.Errorf("%s: invalid RATE_LIMIT_API_KEYS_PER_IP: must be a non-negative integer", op)
+	}
+
+	trustedCIDRs, err := parsePrefixes(os.Getenv("RATE_LIMIT_TRUSTED_CIDRS"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid RATE_LIMIT_TRUSTED_CIDRS: %w", op, err)
+	}
+
+	rateLimitCfg := RateLimitConfig{
+		HoldsPerIP:   holdsPerIP,
+		HoldsPerUser: holdsPerUser,
+		Window:       rateLimitWindow,
+		WritesPerIP:  writesPerIP,
+		APIKeysPerIP: apiKeysPerIP,
+		TrustedCIDRs: trustedCIDRs,
+	}
+
+	reservationIsolation := strings.ReplaceAll(
+		strings.ToLower(os.Getenv("RESERVATION_ISOLATION_LEVEL")), "_", " ",
+	)
+	if reservationIsolation == "" {
+		reservationIsolation = "serializable"
+	}
+
+	if reservationIsolation != "serializable" && reservationIsolation != "repeatable read" {
+		return nil, fmt.Errorf(
+			"%s: invalid RESERVATION_ISOLATION_LEVEL: must be serializable or repeatable_read, got %q",
+			op, reservationIsolation,
+		)
+	}
+
+	maxSeatsPerUserStr := os.Getenv("RESERVATION_MAX_SEATS_PER_USER")
+	if maxSeatsPerUserStr == "" {
+		maxSeatsPerUserStr = "0"
+	}
+
+	maxSeatsPerUser, err := strconv.Atoi(maxSeatsPerUserStr)
+	if err != nil || maxSeatsPerUser < 0 {
+		return nil, fmt.Errorf("%s: invalid RESERVATION_MAX_SEATS_PER_USER: must be a non-negative integer", op)
+	}
+
+	maxSeatsPerHoldStr := os.Getenv("RESERVATION_MAX_SEATS_PER_HOLD")
+	if maxSeatsPerHoldStr == "" {
+		maxSeatsPerHoldStr = "100"
+	}
+
+	maxSeatsPerHold, err := strconv.Atoi(maxSeatsPerHoldStr)
+	if err != nil || maxSeatsPerHold <= 0 {
+		return nil, fmt.Errorf("%s: invalid RESERVATION_MAX_SEATS_PER_HOLD: must be a positive integer", op)
+	}
+
+	holdCutoff := strings.ToLower(os.Getenv("RESERVATION_HOLD_CUTOFF"))
+	if holdCutoff == "" {
+		holdCutoff = "end"
+	}
+
+	if holdCutoff != "end" && holdCutoff != "start" {
+		return nil, fmt.Errorf(
+			"%s: invalid RESERVATION_HOLD_CUTOFF: must be end or start, got %q",
+			op, holdCutoff,
+		)
+	}
+
+	minHoldTTLStr := os.Getenv("HOLD_MIN_TTL")
+	if minHoldTTLStr == "" {
+		minHoldTTLStr = "15s"
+	}
+
+	minHoldTTL, err := time.ParseDuration(minHoldTTLStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid HOLD_MIN_TTL: %w", op, err)
+	}
+
+	if minHoldTTL <= 0 {
+		return nil, fmt.Errorf("%s: invalid HOLD_MIN_TTL: must be positive", op)
+	}
+
+	maxHoldTTLStr := os.Getenv("HOLD_MAX_TTL")
+	if maxHoldTTLStr == "" {
+		maxHoldTTLStr = "5m"
+	}
+
+	maxHoldTTL, err := time.ParseDuration(maxHoldTTLStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid HOLD_MAX_TTL: %w", op, err)
+	}
+
+	if maxHoldTTL < minHoldTTL {
+		return nil, fmt.Errorf("%s: invalid HOLD_MAX_TTL: must not be less than HOLD_MIN_TTL", op)
+	}
+
+	reservationCfg := ReservationConfig{
+		IsolationLevel:  reservationIsolation,
+		MaxSeatsPerUser: maxSeatsPerUser,
+		MaxSeatsPerHold: maxSeatsPerHold,
+		HoldCutoff:      holdCutoff,
+		MinHoldTTL:      minHoldTTL,
+		MaxHoldTTL:      maxHoldTTL,
+	}
+
+	ticketCfg := TicketConfig{
+		SigningSecret: os.Getenv("TICKET_SIGNING_SECRET"),
+	}
+
+	logFormat := strings.ToLower(os.Getenv("LOG_FORMAT"))
+	if logFormat == "" {
+		logFormat = "text"
+	}
+
+	if logFormat != "text" && logFormat != "json" {
+		return nil, fmt.Errorf("%s: invalid LOG_FORMAT %q: must be text or json", op, logFormat)
+	}
+
+	logLevelStr := os.Getenv("LOG_LEVEL")
+	if logLevelStr == "" {
+		logLevelStr = "info"
+	}
+
+	var logLevel slog.Level
+	if err := logLevel.UnmarshalText([]byte(logLevelStr)); err != nil {
+		return nil, fmt.Errorf("%s: invalid LOG_LEVEL %q: must be debug, info, warn or error", op, logLevelStr)
+	}
+
+	logBodyMaxBytesStr := os.Getenv("LOG_BODY_MAX_BYTES")
+	if logBodyMaxBytesStr == "" {
+		logBodyMaxBytesStr = "0"
+	}
+
+	logBodyMaxBytes, err := strconv.Atoi(logBodyMaxBytesStr)
+	if err != nil || logBodyMaxBytes < 0 {
+		return nil, fmt.Errorf("%s: invalid LOG_BODY_MAX_BYTES: must be a non-negative integer", op)
+	}
+
+	devUserHeaderStr := os.Getenv("AUTH_DEV_USER_HEADER")
+	if devUserHeaderStr == "" {
+		devUserHeaderStr = "false"
+	}
+
+	devUserHeader, err := strconv.ParseBool(devUserHeaderStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid AUTH_DEV_USER_HEADER: %w", op, err)
+	}
+
+	authCfg := AuthConfig{
+		JWTSecret:     os.Getenv("AUTH_JWT_SECRET"),
+		DevUserHeader: devUserHeader,
+	}
+
+	logCfg := LogConfig{
+		Format:       logFormat,
+		Level:        logLevel,
+		BodyMaxBytes: logBodyMaxBytes,
 	}
 
 	return &Config{
-		Server:   serverCfg,
-		Postgres: postgresCfg,
-		Redis:    redisCfg,
+		Server:      serverCfg,
+		Postgres:    postgresCfg,
+		Redis:       redisCfg,
+		Admin:       adminCfg,
+		RateLimit:   rateLimitCfg,
+		Ticket:      ticketCfg,
+		Reservation: reservationCfg,
+		Log:         logCfg,
+		Auth:        authCfg,
 	}, nil
 }
+
+// parsePrefixes parses a comma-separated list of CIDRs. A bare IP address
+// stands for itself. Empty entries are skipped.
+func parsePrefixes(list string) ([]netip.Prefix, error) {
+	var out []netip.Prefix
+	for entry := range strings.SplitSeq(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, prefix.Masked())
+	}
+
+	return out, nil
+}
@@ -0,0 +1,225 @@
+package config
+
+import (
+	"log/slog"
+	"maps"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setEnv sets the variables New requires plus vars, for the duration of
+// the test.
+func setEnv(t *testing.T, vars map[string]string) {
+	t.Helper()
+
+	t.Setenv("POSTGRES_USER", "tix")
+	t.Setenv("POSTGRES_PASSWORD", "secret")
+	t.Setenv("POSTGRES_DB", "tix")
+	for k, v := range vars {
+		t.Setenv(k, v)
+	}
+}
+
+// checkErr checks that err is a config.New error naming want, or nil when
+// want is empty.
+func checkErr(t *testing.T, err error, want string) {
+	t.Helper()
+
+	if want == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("got no error, want one naming %s", want)
+	}
+	if !strings.HasPrefix(err.Error(), "config.New: ") || !strings.Contains(err.Error(), want) {
+		t.Fatalf("err = %v, want a config.New error naming %s", err, want)
+	}
+}
+
+func TestRedisConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		wantPassword string
+		wantDB       int
+		wantErr      string
+	}{
+		{name: "defaults", env: map[string]string{"REDIS_PASSWORD": "", "REDIS_DB": ""}},
+		{
+			name:         "set",
+			env:          map[string]string{"REDIS_PASSWORD": "hunter2", "REDIS_DB": "3"},
+			wantPassword: "hunter2",
+			wantDB:       3,
+		},
+		{name: "db not a number", env: map[string]string{"REDIS_DB": "one"}, wantErr: "REDIS_DB"},
+		{name: "db negative", env: map[string]string{"REDIS_DB": "-1"}, wantErr: "REDIS_DB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+
+			cfg, err := New()
+			checkErr(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+			if cfg.Redis.Password != tt.wantPassword {
+				t.Errorf("Password = %q, want %q", cfg.Redis.Password, tt.wantPassword)
+			}
+			if cfg.Redis.DB != tt.wantDB {
+				t.Errorf("DB = %d, want %d", cfg.Redis.DB, tt.wantDB)
+			}
+		})
+	}
+}
+
+func TestReservationHoldCutoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "default", want: "end"},
+		{name: "start", value: "start", want: "start"},
+		{name: "case insensitive", value: "END", want: "end"},
+		{name: "unknown", value: "doors", wantErr: "RESERVATION_HOLD_CUTOFF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, map[string]string{"RESERVATION_HOLD_CUTOFF": tt.value})
+
+			cfg, err := New()
+			checkErr(t, err, tt.wantErr)
+			if err == nil && cfg.Reservation.HoldCutoff != tt.want {
+				t.Errorf("HoldCutoff = %q, want %q", cfg.Reservation.HoldCutoff, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostgresPoolConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		wantMax      int32
+		wantMin      int32
+		wantLifetime time.Duration
+		wantErr      string
+	}{
+		{name: "defaults", wantMax: 10, wantMin: 0, wantLifetime: time.Hour},
+		{
+			name:         "set",
+			env:          map[string]string{"POSTGRES_MAX_CONNS": "20", "POSTGRES_MIN_CONNS": "5", "POSTGRES_MAX_CONN_LIFETIME": "30m"},
+			wantMax:      20,
+			wantMin:      5,
+			wantLifetime: 30 * time.Minute,
+		},
+		{
+			name:         "min equals max",
+			env:          map[string]string{"POSTGRES_MAX_CONNS": "4", "POSTGRES_MIN_CONNS": "4"},
+			wantMax:      4,
+			wantMin:      4,
+			wantLifetime: time.Hour,
+		},
+		{name: "min above max", env: map[string]string{"POSTGRES_MAX_CONNS": "4", "POSTGRES_MIN_CONNS": "5"}, wantErr: "POSTGRES_MIN_CONNS"},
+		{name: "min above default max", env: map[string]string{"POSTGRES_MIN_CONNS": "11"}, wantErr: "POSTGRES_MIN_CONNS"},
+		{name: "max zero", env: map[string]string{"POSTGRES_MAX_CONNS": "0"}, wantErr: "POSTGRES_MAX_CONNS"},
+		{name: "max not a number", env: map[string]string{"POSTGRES_MAX_CONNS": "many"}, wantErr: "POSTGRES_MAX_CONNS"},
+		{name: "max overflows int32", env: map[string]string{"POSTGRES_MAX_CONNS": "4294967296"}, wantErr: "POSTGRES_MAX_CONNS"},
+		{name: "min negative", env: map[string]string{"POSTGRES_MIN_CONNS": "-1"}, wantErr: "POSTGRES_MIN_CONNS"},
+		{name: "lifetime not a duration", env: map[string]string{"POSTGRES_MAX_CONN_LIFETIME": "1"}, wantErr: "POSTGRES_MAX_CONN_LIFETIME"},
+		{name: "lifetime zero", env: map[string]string{"POSTGRES_MAX_CONN_LIFETIME": "0s"}, wantErr: "POSTGRES_MAX_CONN_LIFETIME"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{"POSTGRES_MAX_CONNS": "", "POSTGRES_MIN_CONNS": "", "POSTGRES_MAX_CONN_LIFETIME": ""}
+			maps.Copy(env, tt.env)
+			setEnv(t, env)
+
+			cfg, err := New()
+			checkErr(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+			pg := cfg.Postgres
+			if pg.MaxConns != tt.wantMax || pg.MinConns != tt.wantMin || pg.MaxConnLifetime != tt.wantLifetime {
+				t.Errorf("got max %d, min %d, lifetime %v; want %d, %d, %v",
+					pg.MaxConns, pg.MinConns, pg.MaxConnLifetime, tt.wantMax, tt.wantMin, tt.wantLifetime)
+			}
+		})
+	}
+}
+
+func TestReservationHoldTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantMin time.Duration
+		wantMax time.Duration
+		wantErr string
+	}{
+		{name: "defaults", wantMin: 15 * time.Second, wantMax: 5 * time.Minute},
+		{name: "set", env: map[string]string{"HOLD_MIN_TTL": "30s", "HOLD_MAX_TTL": "10m"}, wantMin: 30 * time.Second, wantMax: 10 * time.Minute},
+		{name: "min equals max", env: map[string]string{"HOLD_MIN_TTL": "1m", "HOLD_MAX_TTL": "1m"}, wantMin: time.Minute, wantMax: time.Minute},
+		{name: "min not a duration", env: map[string]string{"HOLD_MIN_TTL": "15"}, wantErr: "HOLD_MIN_TTL"},
+		{name: "min zero", env: map[string]string{"HOLD_MIN_TTL": "0s"}, wantErr: "HOLD_MIN_TTL"},
+		{name: "min negative", env: map[string]string{"HOLD_MIN_TTL": "-5s"}, wantErr: "HOLD_MIN_TTL"},
+		{name: "max not a duration", env: map[string]string{"HOLD_MAX_TTL": "five minutes"}, wantErr: "HOLD_MAX_TTL"},
+		{name: "min above max", env: map[string]string{"HOLD_MIN_TTL": "2m", "HOLD_MAX_TTL": "1m"}, wantErr: "HOLD_MAX_TTL"},
+		{name: "min above default max", env: map[string]string{"HOLD_MIN_TTL": "10m"}, wantErr: "HOLD_MAX_TTL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{"HOLD_MIN_TTL": "", "HOLD_MAX_TTL": ""}
+			maps.Copy(env, tt.env)
+			setEnv(t, env)
+
+			cfg, err := New()
+			checkErr(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+			if r := cfg.Reservation; r.MinHoldTTL != tt.wantMin || r.MaxHoldTTL != tt.wantMax {
+				t.Errorf("got min %v, max %v; want %v, %v", r.MinHoldTTL, r.MaxHoldTTL, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestLogConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		wantFormat string
+		wantLevel  slog.Level
+		wantErr    string
+	}{
+		{name: "defaults", wantFormat: "text", wantLevel: slog.LevelInfo},
+		{name: "json debug", env: map[string]string{"LOG_FORMAT": "json", "LOG_LEVEL": "debug"}, wantFormat: "json", wantLevel: slog.LevelDebug},
+		{name: "case insensitive", env: map[string]string{"LOG_FORMAT": "JSON", "LOG_LEVEL": "WARN"}, wantFormat: "json", wantLevel: slog.LevelWarn},
+		{name: "error level", env: map[string]string{"LOG_LEVEL": "error"}, wantFormat: "text", wantLevel: slog.LevelError},
+		{name: "unknown format", env: map[string]string{"LOG_FORMAT": "xml"}, wantErr: "LOG_FORMAT"},
+		{name: "unknown level", env: map[string]string{"LOG_LEVEL": "verbose"}, wantErr: `LOG_LEVEL "verbose": must be debug, info, warn or error`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{"LOG_FORMAT": "", "LOG_LEVEL": ""}
+			maps.Copy(env, tt.env)
+			setEnv(t, env)
+
+			cfg, err := New()
+			checkErr(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+			if cfg.Log.Format != tt.wantFormat || cfg.Log.Level != tt.wantLevel {
+				t.Errorf("got format %q, level %v; want %q, %v", cfg.Log.Format, cfg.Log.Level, tt.wantFormat, tt.wantLevel)
+			}
+		})
+	}
+}
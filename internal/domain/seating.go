@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// SeatingScheme describes a venue layout as sections made of rows, each row
+// holding a contiguous range of seat numbers.
+//
+//	{"sections": [{"name": "A", "rows": [{"name": "1", "from": 1, "to": 20}]}]}
+type SeatingScheme struct {
+	Sections []SeatingSection `json:"sections"`
+}
+
+type SeatingSection struct {
+	Name string       `json:"name"`
+	Rows []SeatingRow `json:"rows"`
+}
+
+type SeatingRow struct {
+	Name string `json:"name"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// ParseSeatingScheme decodes and validates a raw seating scheme. Empty input,
+// `null` and `{}` are accepted and yield an empty scheme.
+func ParseSeatingScheme(raw []byte) (*SeatingScheme, error) {
+	var scheme SeatingScheme
+
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return &scheme, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&scheme); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after seating scheme")
+	}
+
+	if err := scheme.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &scheme, nil
+}
+
+// Validate checks that section names are unique and non-empty, row names are
+// unique valid row numbers (see ValidRow) within their section, and every row
+// has a positive, non-empty seat range.
+func (s SeatingScheme) Validate() error {
+	sections := make(map[string]struct{}, len(s.Sections))
+	for i, sec := range s.Sections {
+		if sec.Name == "" {
+			return fmt.Errorf("sections[%d]: name is required", i)
+		}
+		if _, dup := sections[sec.Name]; dup {
+			return fmt.Errorf("sections[%d]: duplicate section %q", i, sec.Name)
+		}
+		sections[sec.Name] = struct{}{}
+
+		if len(sec.Rows) == 0 {
+			return fmt.Errorf("sections[%d]: at least one row is required", i)
+		}
+
+		rows := make(map[string]struct{}, len(sec.Rows))
+		for j, row := range sec.Rows {
+			if row.Name == "" {
+				return fmt.Errorf("sections[%d].rows[%d]: name is required", i, j)
+			}
+			if !ValidRow(row.Name) {
+				return fmt.Errorf("sections[%d].rows[%d]: row %q is not a positive integer", i, j, row.Name)
+			}
+			if _, dup := rows[row.Name]; dup {
+				return fmt.Errorf("sections[%d].rows[%d]: duplicate row %q", i, j, row.Name)
+			}
+			rows[row.Name] = struct{}{}
+
+			if row.From < 1 || row.To < row.From {
+				return fmt.Errorf("sections[%d].rows[%d]: invalid seat range %d-%d", i, j, row.From, row.To)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Seats expands the scheme into one Seat per seat number of every row.
+func (s SeatingScheme) Seats(venueID int64) []Seat {
+	var n int
+	for _, sec := range s.Sections {
+		for _, row := range sec.Rows {
+			n += row.To - row.From + 1
+		}
+	}
+
+	out := make([]Seat, 0, n)
+	for _, sec := range s.Sections {
+		for _, row := range sec.Rows {
+			for num := row.From; num <= row.To; num++ {
+				out = append(out, Seat{
+					VenueID: venueID,
+					Section: sec.Name,
+					Row:     row.Name,
+					Number:  num,
+				})
+			}
+		}
+	}
+
+	return out
+}
+
+// ValidRow reports whether name is a valid seat row: rows are stored as
+// numbers, so name must be a positive integer without sign or leading zeros.
+func ValidRow(name string) bool {
+	n, err := strconv.ParseInt(name, 10, 32)
+
+	return err == nil && n > 0 && strconv.FormatInt(n, 10) == name
+}
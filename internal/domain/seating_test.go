@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValidRow(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"1", true},
+		{"12", true},
+		{"2147483647", true},
+		{"", false},
+		{"0", false},
+		{"-1", false},
+		{"+1", false},
+		{"01", false},
+		{"A", false},
+		{"1.5", false},
+		{"2147483648", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidRow(tt.name); got != tt.want {
+				t.Errorf("ValidRow(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSeatingSchemeRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr bool
+	}{
+		{name: "numeric", raw: `{"sections":[{"name":"A","rows":[{"name":"1","from":1,"to":2}]}]}`},
+		{name: "letter", raw: `{"sections":[{"name":"A","rows":[{"name":"B","from":1,"to":2}]}]}`, wantErr: true},
+		{name: "zero", raw: `{"sections":[{"name":"A","rows":[{"name":"0","from":1,"to":2}]}]}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSeatingScheme([]byte(tt.raw)); (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSeatingSchemeSeats(t *testing.T) {
+	scheme, err := ParseSeatingScheme([]byte(`{"sections":[
+		{"name":"A","rows":[{"name":"1","from":1,"to":3},{"name":"2","from":5,"to":5}]},
+		{"name":"B","rows":[{"name":"1","from":2,"to":3}]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Seat{
+		{VenueID: 7, Section: "A", Row: "1", Number: 1},
+		{VenueID: 7, Section: "A", Row: "1", Number: 2},
+		{VenueID: 7, Section: "A", Row: "1", Number: 3},
+		{VenueID: 7, Section: "A", Row: "2", Number: 5},
+		{VenueID: 7, Section: "B", Row: "1", Number: 2},
+		{VenueID: 7, Section: "B", Row: "1", Number: 3},
+	}
+	if got := scheme.Seats(7); !slices.Equal(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	empty, err := ParseSeatingScheme([]byte(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := empty.Seats(7); len(got) != 0 {
+		t.Errorf("empty scheme: got %d seats, want none", len(got))
+	}
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"encoding/json"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,18 +16,86 @@ const (
 	SeatSold      SeatStatus = "sold"
 )
 
+type OrderStatus string
+
+const (
+	OrderConfirmed OrderStatus = "confirmed"
+	OrderRefunded  OrderStatus = "refunded"
+)
+
+// EventLogKind is the kind of a hold lifecycle entry in the event log.
+type EventLogKind string
+
+const (
+	LogHoldCreated   EventLogKind = "hold_created"
+	LogHoldConfirmed EventLogKind = "hold_confirmed"
+	LogHoldCancelled EventLogKind = "hold_cancelled"
+	LogHoldExpired   EventLogKind = "hold_expired"
+	LogHoldExtended  EventLogKind = "hold_extended"
+)
+
+// APIKeyScope is a permission of an API key: read for GET requests, write
+// for the others.
+type APIKeyScope string
+
+const (
+	ScopeRead  APIKeyScope = "read"
+	ScopeWrite APIKeyScope = "write"
+)
+
+// APIKey identifies a server-to-server client. Only the SHA-256 hash of the
+// key is stored.
+type APIKey struct {
+	ID         int64
+	ClientName string
+	KeyHash    []byte
+	Scopes     []APIKeyScope
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// HasScope reports whether the key was granted scope.
+func (k APIKey) HasScope(scope APIKeyScope) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
 type Venue struct {
 	ID            int64
 	Name          string
-	SeatingScheme []byte // jsonb raw
+	SeatingScheme json.RawMessage `swaggertype:"object"` // jsonb raw
 }
 
 type Event struct {
-	ID      int64
-	VenueID int64
-	Title   string
-	Starts  time.Time
-	Ends    time.Time
+	ID          int64
+	VenueID     int64
+	Title       string
+	Starts      time.Time
+	Ends        time.Time
+	CancelledAt *time.Time
+}
+
+// GACapacity describes the general-admission tickets of an event: a pool
+// sold without assigned seats.
+type GACapacity struct {
+	EventID    int64
+	Capacity   int
+	Held       int
+	Sold       int
+	Available  int
+	PriceCents int
+}
+
+type EventCancellation struct {
+	ReleasedHolds  int64
+	ReleasedSeats  int64
+	AffectedOrders int64
+}
+
+// EventRevenue is the gross sales of an event over its confirmed orders.
+type EventRevenue struct {
+	OrdersCount int64
+	TicketsSold int64
+	TotalCents  int64
 }
 
 type Seat struct {
@@ -39,6 +109,18 @@ type Seat struct {
 type SeatWithStatus struct {
 	Seat
 	Status SeatStatus
+	// HoldExpiresAt is when the hold on a held seat lapses; nil otherwise.
+	HoldExpiresAt *time.Time `json:"hold_expires_at,omitempty"`
+	// Version changes whenever the seat changes status. Passing it back when
+	// holding the seat fails the hold if the seat changed in between.
+	Version int64 `json:"version"`
+}
+
+// UserSeat is a seat as seen by one user: HeldByMe tells the user's own
+// held seats apart from seats held by others.
+type UserSeat struct {
+	SeatWithStatus
+	HeldByMe bool `json:"held_by_me"`
 }
 
 type EventCounts struct {
@@ -48,18 +130,73 @@ type EventCounts struct {
 	Total     int64
 }
 
+type SectionCounts struct {
+	Section   string
+	Available int64
+	Held      int64
+	Sold      int64
+	Total     int64
+}
+
+type Hold struct {
+	ID        uuid.UUID
+	EventID   int64
+	UserID    int64
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	SeatIDs   []int64
+	// GAQty is the number of general-admission tickets held.
+	GAQty int
+}
+
+type HoldSummary struct {
+	ID        uuid.UUID
+	EventID   int64
+	UserID    int64
+	SeatCount int64
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// EventLogEntry records a change to a hold of an event. OrderID is set for
+// confirmed holds only.
+type EventLogEntry struct {
+	ID        int64
+	EventID   int64
+	HoldID    uuid.UUID
+	UserID    int64
+	Kind      EventLogKind
+	SeatCount int
+	OrderID   *uuid.UUID
+	CreatedAt time.Time
+}
+
+type WaitlistEntry struct {
+	ID         int64
+	EventID    int64
+	UserID     int64
+	SeatCount  int
+	CreatedAt  time.Time
+	NotifiedAt *time.Time
+}
+
 type Order struct {
 	ID         uuid.UUID
 	EventID    int64
 	UserID     int64
 	TotalCents int
+	// GAQty is the number of general-admission tickets bought.
+	GAQty      int
+	Status     OrderStatus
 	CreatedAt  time.Time
+	RefundedAt *time.Time
 }
 
 type Ticket struct {
 	ID      uuid.UUID
 	OrderID uuid.UUID
 	EventID int64
+	// SeatID is 0 for general-admission tickets.
 	SeatID  int64
 	Created time.Time
 }
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tixgo"
+
+// Metrics holds the application collectors. A nil *Metrics is valid and
+// records nothing, so callers do not need to guard every observation.
+type Metrics struct {
+	reg *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	reservations *prometheus.CounterVec
+}
+
+// New registers the application collectors on reg. Passing a fresh registry
+// keeps tests isolated from the global default registry.
+func New(reg *prometheus.Registry) *Metrics {
+	m := &Metrics{
+		reg: reg,
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		reservations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reservation_outcomes_total",
+			Help:      "Reservation operations by operation and outcome.",
+		}, []string{"operation", "outcome"}),
+	}
+
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.reservations,
+	)
+
+	return m
+}
+
+// Handler returns an http.Handler exposing the registry in the Prometheus
+// text format.
+func (m *Metrics) Handler() http.Handler {
+	if m == nil {
+		return http.NotFoundHandler()
+	}
+	return promhttp.HandlerFor(m.reg, promhttp.HandlerOpts{Registry: m.reg})
+}
+
+// ObserveHTTP records a finished HTTP request.
+func (m *Metrics) ObserveHTTP(method, route, status string, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	m.httpRequests.WithLabelValues(method, route, status).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(latency.Seconds())
+}
+
+// IncReservation records the outcome of a reservation operation such as
+// "create_hold" or "confirm".
+func (m *Metrics) IncReservation(operation, outcome string) {
+	if m == nil {
+		return
+	}
+	m.reservations.WithLabelValues(operation, outcome).Inc()
+}
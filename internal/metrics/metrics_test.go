@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+
+	m.IncReservation("create_hold", "success")
+	m.IncReservation("create_hold", "success")
+	m.IncReservation("create_hold", "rate_limited")
+	m.ObserveHTTP(http.MethodGet, "/events/:id", "200", 30*time.Millisecond)
+
+	counters := []struct {
+		name string
+		c    prometheus.Collector
+		want float64
+	}{
+		{name: "create_hold success", c: m.reservations.WithLabelValues("create_hold", "success"), want: 2},
+		{name: "create_hold rate_limited", c: m.reservations.WithLabelValues("create_hold", "rate_limited"), want: 1},
+		{name: "confirm success", c: m.reservations.WithLabelValues("confirm", "success"), want: 0},
+		{name: "GET /events/:id 200", c: m.httpRequests.WithLabelValues(http.MethodGet, "/events/:id", "200"), want: 1},
+	}
+	for _, tt := range counters {
+		if got := testutil.ToFloat64(tt.c); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	want := `tixgo_reservation_outcomes_total{operation="create_hold",outcome="success"} 2`
+	if !strings.Contains(w.Body.String(), want) {
+		t.Errorf("exposition lacks %s", want)
+	}
+}
+
+func TestNilMetrics(t *testing.T) {
+	var m *Metrics
+
+	m.IncReservation("create_hold", "success")
+	m.ObserveHTTP(http.MethodGet, "/events", "200", time.Millisecond)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
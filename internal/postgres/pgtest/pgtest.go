@@ -0,0 +1,181 @@
+// Package pgtest provides a migrated PostgreSQL schema for integration
+// tests. Tests using it are skipped unless TEST_DATABASE_URL points at a
+// database the tests may create schemas in.
+package pgtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// New returns a pool bound to a fresh schema with all migrations applied.
+// The schema is dropped when the test ends.
+func New(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	schema := "test_" + hex.EncodeToString(b)
+
+	admin, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer admin.Close(ctx)
+
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		conn, err := pgx.Connect(context.Background(), dsn)
+		if err != nil {
+			t.Logf("drop schema %s: %v", schema, err)
+			return
+		}
+		defer conn.Close(context.Background())
+		if _, err := conn.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Logf("drop schema %s: %v", schema, err)
+		}
+	})
+
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	for _, stmt := range upMigrations(t) {
+		if _, err := pool.Exec(ctx, stmt); err != nil {
+			t.Fatalf("migrate: %v\n%s", err, stmt)
+		}
+	}
+
+	return pool
+}
+
+// upMigrations returns the Up sections of the goose migrations, oldest
+// first.
+func upMigrations(t testing.TB) []string {
+	t.Helper()
+
+	dir := migrationsDir(t)
+	files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(files)
+
+	out := make([]string, 0, len(files))
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		up, _, _ := strings.Cut(string(b), "-- +goose Down")
+		up = strings.NewReplacer(
+			"-- +goose Up", "",
+			"-- +goose StatementBegin", "",
+			"-- +goose StatementEnd", "",
+		).Replace(up)
+		out = append(out, up)
+	}
+
+	return out
+}
+
+// migrationsDir finds the migrations directory next to go.mod, searching
+// upwards from the test's working directory.
+func migrationsDir(t testing.TB) string {
+	t.Helper()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return filepath.Join(dir, "migrations")
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			t.Fatal("go.mod not found")
+		}
+		dir = parent
+	}
+}
+
+// SeedEvent creates a venue with rows×perRow seats in section "A", priced at
+// 1000 cents each, and an event at it starting tomorrow with gaCapacity
+// general-admission tickets. It returns the event ID and the seat IDs
+// ordered by row and number.
+func SeedEvent(t testing.TB, pool *pgxpool.Pool, rows, perRow, gaCapacity int) (int64, []int64) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var venueID, eventID int64
+	if err := pool.QueryRow(ctx,
+		`INSERT INTO venues (name) VALUES ('Test Hall') RETURNING id`,
+	).Scan(&venueID); err != nil {
+		t.Fatalf("seed venue: %v", err)
+	}
+
+	if err := pool.QueryRow(ctx,
+		`INSERT INTO events (venue_id, title, starts_at, ends_at, ga_capacity, ga_price_cents)
+		 VALUES ($1, 'Test Event', now() + interval '1 day', now() + interval '2 days', $2, 500)
+		 RETURNING id`,
+		venueID, gaCapacity,
+	).Scan(&eventID); err != nil {
+		t.Fatalf("seed event: %v", err)
+	}
+
+	var seatIDs []int64
+	for row := 1; row <= rows; row++ {
+		for number := 1; number <= perRow; number++ {
+			var seatID int64
+			if err := pool.QueryRow(ctx,
+				`INSERT INTO seats (venue_id, section, row, number)
+				 VALUES ($1, 'A', $2, $3)
+				 RETURNING id`,
+				venueID, row, number,
+			).Scan(&seatID); err != nil {
+				t.Fatalf("seed seat: %v", err)
+			}
+			seatIDs = append(seatIDs, seatID)
+		}
+	}
+
+	if _, err := pool.Exec(ctx,
+		`INSERT INTO event_seats (event_id, seat_id, price_cents)
+		 SELECT $1, id, 1000 FROM seats WHERE venue_id = $2`,
+		eventID, venueID,
+	); err != nil {
+		t.Fatalf("seed event seats: %v", err)
+	}
+
+	return eventID, seatIDs
+}
@@ -9,8 +9,10 @@ import (
 )
 
 type Config struct {
-	DSN      string
-	MaxConns int32
+	DSN             string
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
 }
 
 func New(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
@@ -25,6 +27,14 @@ func New(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
 		poolCfg.MaxConns = cfg.MaxConns
 	}
 
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+
 	poolCfg.MaxConnIdleTime = 5 * time.Minute
 	poolCfg.HealthCheckPeriod = 30 * time.Second
 
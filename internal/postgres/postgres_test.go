@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestNewPoolSettings(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	defaults, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		cfg          Config
+		wantMax      int32
+		wantMin      int32
+		wantLifetime time.Duration
+	}{
+		{
+			name:         "set",
+			cfg:          Config{DSN: dsn, MaxConns: 3, MinConns: 1, MaxConnLifetime: 10 * time.Minute},
+			wantMax:      3,
+			wantMin:      1,
+			wantLifetime: 10 * time.Minute,
+		},
+		{
+			name:         "unset keeps pgx defaults",
+			cfg:          Config{DSN: dsn},
+			wantMax:      defaults.MaxConns,
+			wantMin:      defaults.MinConns,
+			wantLifetime: defaults.MaxConnLifetime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := New(context.Background(), tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer pool.Close()
+
+			got := pool.Config()
+			if got.MaxConns != tt.wantMax || got.MinConns != tt.wantMin || got.MaxConnLifetime != tt.wantLifetime {
+				t.Errorf("got max %d, min %d, lifetime %v; want %d, %d, %v",
+					got.MaxConns, got.MinConns, got.MaxConnLifetime, tt.wantMax, tt.wantMin, tt.wantLifetime)
+			}
+		})
+	}
+}
+
+func TestNewInvalidDSN(t *testing.T) {
+	if _, err := New(context.Background(), Config{DSN: "postgres://%zz"}); err == nil {
+		t.Error("want an error for a malformed DSN")
+	}
+}
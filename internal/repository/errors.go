@@ -1,6 +1,9 @@
 package repository
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrSeatsUnavailable = errors.New("some seats unavailable")
@@ -9,4 +12,23 @@ var (
 	ErrNothingToConfirm = errors.New("nothing to confirm")
 	ErrNotFound         = errors.New("not found")
 	ErrConflict         = errors.New("conflict")
+	ErrEventCancelled   = errors.New("event cancelled")
+	ErrAlreadyRefunded  = errors.New("order already refunded")
+	ErrGAUnavailable    = errors.New("general admission capacity unavailable")
+	ErrGACapacityTooLow = errors.New("general admission capacity below tickets held and sold")
 )
+
+// SeatsUnavailableError is ErrSeatsUnavailable naming the requested seats
+// that could not be held.
+type SeatsUnavailableError struct {
+	SeatIDs []int64
+}
+
+func (e SeatsUnavailableError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrSeatsUnavailable, e.SeatIDs)
+}
+
+// Is reports SeatsUnavailableError as ErrSeatsUnavailable.
+func (e SeatsUnavailableError) Is(target error) bool {
+	return target == ErrSeatsUnavailable
+}
@@ -2,11 +2,14 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/repository"
 )
 
 type AdminRepo struct {
@@ -58,7 +61,12 @@ func (r *AdminRepo) CreateVenue(ctx context.Context, name string, seatingSchemeJ
 	return id, nil
 }
 
-// BatchCreateSeats inserts multiple seat rows for the given venue.
+// seatBatchSize is the most INSERT statements queued in one pgx.Batch.
+const seatBatchSize = 1000
+
+// BatchCreateSeats inserts multiple seat rows for the given venue. Seats are
+// sent in batches of seatBatchSize statements, one after another on the same
+// handle, so a call inside a transaction stays atomic.
 //
 // Parameters:
 //   - ctx: request-scoped context.
@@ -66,12 +74,30 @@ func (r *AdminRepo) CreateVenue(ctx context.Context, name string, seatingSchemeJ
 //   - seats: slice of domain.Seat values to be created.
 //
 // Returns:
-//   - error: repository.ErrConflict if a seat with the same attributes exists.
-func (r *AdminRepo) BatchCreateSeats(ctx context.Context, venueID int64, seats []domain.Seat) error {
+//   - int64: number of seats actually inserted; existing seats are skipped.
+//   - error: if an insert fails.
+func (r *AdminRepo) BatchCreateSeats(ctx context.Context, venueID int64, seats []domain.Seat) (int64, error) {
 	const op = "postgres.AdminRepo.BacthCreateSeats"
 
 	db := r.handle()
 
+	var created int64
+	for start := 0; start < len(seats); start += seatBatchSize {
+		end := min(start+seatBatchSize, len(seats))
+
+		n, err := sendSeatBatch(ctx, db, venueID, seats[start:end])
+		created += n
+		if err != nil {
+			return created, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+	}
+
+	return created, nil
+}
+
+// sendSeatBatch inserts seats in a single pgx.Batch and returns how many
+// rows were inserted.
+func sendSeatBatch(ctx context.Context, db DB, venueID int64, seats []domain.Seat) (int64, error) {
 	batch := &pgx.Batch{}
 	for _, s := range seats {
 		batch.Queue(
@@ -81,11 +107,24 @@ func (r *AdminRepo) BatchCreateSeats(ctx context.Context, venueID int64, seats [
 			venueID, s.Section, s.Row, s.Number,
 		)
 	}
-	if err := db.SendBatch(ctx, batch).Close(); err != nil {
-		return fmt.Errorf("%s:%w", op, translateDBErr(err))
+
+	br := db.SendBatch(ctx, batch)
+
+	var created int64
+	for range seats {
+		tag, err := br.Exec()
+		if err != nil {
+			_ = br.Close()
+			return created, err
+		}
+		created += tag.RowsAffected()
 	}
 
-	return nil
+	if err := br.Close(); err != nil {
+		return created, err
+	}
+
+	return created, nil
 }
 
 // CreateEvent inserts a new event for a venue and returns the created
@@ -123,30 +162,374 @@ func (r *AdminRepo) CreateEvent(
 	return id, nil
 }
 
+// GetVenueForUpdate loads a venue and locks its row until the end of the
+// surrounding transaction.
+//
+// Returns:
+//   - *domain.Venue: the current venue.
+//   - error: repository.ErrNotFound if the venue does not exist.
+func (r *AdminRepo) GetVenueForUpdate(ctx context.Context, venueID int64) (*domain.Venue, error) {
+	const op = "postgres.AdminRepo.GetVenueForUpdate"
+
+	db := r.handle()
+
+	var v domain.Venue
+	if err := db.QueryRow(ctx,
+		`SELECT id, name, seating_scheme
+		 FROM venues WHERE id = $1
+		 FOR UPDATE`,
+		venueID,
+	).Scan(&v.ID, &v.Name, &v.SeatingScheme); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return &v, nil
+}
+
+// UpdateVenue changes the name and seating scheme of an existing venue.
+// A nil seatingSchemeJSON keeps the current scheme.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - venueID: ID of the venue to update.
+//   - name: new venue name.
+//   - seatingSchemeJSON: raw JSON bytes of the new seating scheme, or nil.
+//
+// Returns:
+//   - *domain.Venue: the updated venue.
+//   - error: repository.ErrNotFound if the venue does not exist.
+//   - error: repository.ErrConflict if another venue already has the name.
+func (r *AdminRepo) UpdateVenue(
+	ctx context.Context,
+	venueID int64,
+	name string,
+	seatingSchemeJSON []byte,
+) (*domain.Venue, error) {
+	const op = "postgres.AdminRepo.UpdateVenue"
+
+	db := r.handle()
+
+	var v domain.Venue
+	if err := db.QueryRow(ctx,
+		`UPDATE venues
+			 SET name = $2, seating_scheme = COALESCE($3, seating_scheme)
+		 WHERE id = $1
+		 RETURNING id, name, seating_scheme`,
+		venueID, name, seatingSchemeJSON,
+	).Scan(&v.ID, &v.Name, &v.SeatingScheme); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return &v, nil
+}
+
+// UpdateSeat moves a seat to another section, row or number within its
+// venue. Nil arguments keep the current value.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - seatID: ID of the seat to update.
+//   - section, row, number: new position of the seat.
+//
+// Returns:
+//   - *domain.Seat: the updated seat.
+//   - error: repository.ErrNotFound if the seat does not exist.
+//   - error: repository.ErrConflict if another seat of the venue already
+//     has the position.
+func (r *AdminRepo) UpdateSeat(
+	ctx context.Context,
+	seatID int64,
+	section, row *string,
+	number *int,
+) (*domain.Seat, error) {
+	const op = "postgres.AdminRepo.UpdateSeat"
+
+	db := r.handle()
+
+	var seat domain.Seat
+	if err := db.QueryRow(ctx,
+		`UPDATE seats
+			 SET section = COALESCE($2, section),
+			     row = COALESCE($3, row),
+			     number = COALESCE($4, number)
+		 WHERE id = $1
+		 RETURNING id, venue_id, section, row, number`,
+		seatID, section, row, number,
+	).Scan(&seat.ID, &seat.VenueID, &seat.Section, &seat.Row, &seat.Number); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return &seat, nil
+}
+
+// CountSeatBookings counts the upcoming, not cancelled events in which a
+// seat is sold or held by an unexpired hold. The seat's event_seats rows of
+// those events are locked until the end of the surrounding transaction, so
+// no hold can take the seat before the caller moves it.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - seatID: ID of the seat.
+//
+// Returns:
+//   - int64: number of events with the seat booked.
+//   - error: if the query fails.
+func (r *AdminRepo) CountSeatBookings(ctx context.Context, seatID int64) (int64, error) {
+	const op = "postgres.AdminRepo.CountSeatBookings"
+
+	db := r.handle()
+
+	var n int64
+	if err := db.QueryRow(ctx,
+		`SELECT COUNT(*) FILTER (
+		        WHERE status = 'sold'
+		           OR (status = 'held' AND hold_expires_at > now()))
+		 FROM (
+		     SELECT es.status, es.hold_expires_at
+		     FROM event_seats es
+		     JOIN events e ON e.id = es.event_id
+		     WHERE es.seat_id = $1
+		       AND e.cancelled_at IS NULL
+		       AND e.ends_at > now()
+		     FOR UPDATE OF es
+		 ) t`,
+		seatID,
+	).Scan(&n); err != nil {
+		return 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return n, nil
+}
+
+// GetEventForUpdate loads an event and locks its row until the end of the
+// surrounding transaction.
+//
+// Returns:
+//   - *domain.Event: the current event.
+//   - error: repository.ErrNotFound if the event does not exist.
+func (r *AdminRepo) GetEventForUpdate(ctx context.Context, eventID int64) (*domain.Event, error) {
+	const op = "postgres.AdminRepo.GetEventForUpdate"
+
+	db := r.handle()
+
+	var e domain.Event
+	if err := db.QueryRow(ctx,
+		`SELECT id, venue_id, title, starts_at, ends_at, cancelled_at
+		 FROM events WHERE id = $1
+		 FOR UPDATE`,
+		eventID,
+	).Scan(&e.ID, &e.VenueID, &e.Title, &e.Starts, &e.Ends, &e.CancelledAt); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return &e, nil
+}
+
+// UpdateEvent changes the title and schedule of an existing event.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event to update.
+//   - title: new event title.
+//   - starts, ends: new start and end timestamps.
+//
+// Returns:
+//   - *domain.Event: the updated event.
+//   - error: repository.ErrNotFound if the event does not exist.
+func (r *AdminRepo) UpdateEvent(
+	ctx context.Context,
+	eventID int64,
+	title string,
+	starts, ends time.Time,
+) (*domain.Event, error) {
+	const op = "postgres.AdminRepo.UpdateEvent"
+
+	db := r.handle()
+
+	var e domain.Event
+	if err := db.QueryRow(ctx,
+		`UPDATE events
+			 SET title = $2, starts_at = $3, ends_at = $4
+		 WHERE id = $1
+		 RETURNING id, venue_id, title, starts_at, ends_at, cancelled_at`,
+		eventID, title, starts, ends,
+	).Scan(&e.ID, &e.VenueID, &e.Title, &e.Starts, &e.Ends, &e.CancelledAt); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return &e, nil
+}
+
+// CancelEvent marks an event as cancelled, releases all of its held seats
+// and general-admission tickets and removes its holds. Sold seats and their
+// orders are left untouched and only reported, so they can be refunded
+// separately.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event to cancel.
+//
+// Returns:
+//   - *domain.EventCancellation: counts of released holds/seats and affected orders.
+//   - error: repository.ErrNotFound if the event does not exist.
+//   - error: repository.ErrEventCancelled if the event is already cancelled.
+func (r *AdminRepo) CancelEvent(ctx context.Context, eventID int64) (*domain.EventCancellation, error) {
+	const op = "postgres.AdminRepo.CancelEvent"
+
+	db := r.handle()
+
+	var alreadyCancelled bool
+	if err := db.QueryRow(ctx,
+		`SELECT cancelled_at IS NOT NULL
+		 FROM events
+		 WHERE id = $1
+		 FOR UPDATE`,
+		eventID,
+	).Scan(&alreadyCancelled); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if alreadyCancelled {
+		return nil, fmt.Errorf("%s:%w", op, repository.ErrEventCancelled)
+	}
+
+	if _, err := db.Exec(ctx,
+		`UPDATE events SET cancelled_at = now(), ga_held = 0 WHERE id = $1`,
+		eventID,
+	); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	// Log the holds before their seats are released, while seat counts can
+	// still be read.
+	if _, err := db.Exec(ctx,
+		`INSERT INTO event_log(event_id, hold_id, user_id, kind, seat_count)
+		 SELECT h.event_id, h.id, h.user_id, 'hold_cancelled', count(es.seat_id) + h.ga_qty
+		 FROM holds h
+		 LEFT JOIN event_seats es ON es.hold_id = h.id AND es.status = 'held'
+		 WHERE h.event_id = $1
+		 GROUP BY h.id`,
+		eventID,
+	); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	var out domain.EventCancellation
+
+	tag, err := db.Exec(ctx,
+		`UPDATE event_seats
+			 SET status = 'available', hold_id = NULL, hold_expires_at = NULL,
+			     version = version + 1
+		 WHERE event_id = $1 AND status = 'held'`,
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+	out.ReleasedSeats = tag.RowsAffected()
+
+	tag, err = db.Exec(ctx, `DELETE FROM holds WHERE event_id = $1`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+	out.ReleasedHolds = tag.RowsAffected()
+
+	if err := db.QueryRow(ctx,
+		`SELECT count(*) FROM orders WHERE event_id = $1`,
+		eventID,
+	).Scan(&out.AffectedOrders); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return &out, nil
+}
+
+// SetGACapacity sets the number and price of general-admission tickets of
+// an event.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event.
+//   - capacity: total number of general-admission tickets.
+//   - priceCents: price of each general-admission ticket in cents.
+//
+// Returns:
+//   - *domain.GACapacity: the updated capacity and counters.
+//   - error: repository.ErrNotFound if the event does not exist.
+//   - error: repository.ErrGACapacityTooLow if capacity is below the tickets
+//     already held and sold.
+func (r *AdminRepo) SetGACapacity(
+	ctx context.Context,
+	eventID int64,
+	capacity int,
+	priceCents int,
+) (*domain.GACapacity, error) {
+	const op = "postgres.AdminRepo.SetGACapacity"
+
+	db := r.handle()
+
+	ga := domain.GACapacity{EventID: eventID}
+	err := db.QueryRow(ctx,
+		`UPDATE events
+		 SET ga_capacity = $2, ga_price_cents = $3
+		 WHERE id = $1 AND ga_held + ga_sold <= $2
+		 RETURNING ga_capacity, ga_held, ga_sold, ga_price_cents`,
+		eventID, capacity, priceCents,
+	).Scan(&ga.Capacity, &ga.Held, &ga.Sold, &ga.PriceCents)
+	if errors.Is(err, pgx.ErrNoRows) {
+		var exists bool
+		if err := db.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM events WHERE id = $1)`,
+			eventID,
+		).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		if exists {
+			return nil, fmt.Errorf("%s:%w", op, repository.ErrGACapacityTooLow)
+		}
+
+		return nil, fmt.Errorf("%s:%w", op, repository.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	ga.Available = ga.Capacity - ga.Held - ga.Sold
+
+	return &ga, nil
+}
+
 // InitEventSeats materializes seats for a specific event by copying
 // all seats from the venue into the event_seats table with an initial
-// status of 'available'.
+// status of 'available' and the given price.
 //
 // Parameters:
 //   - ctx: request-scoped context.
 //   - eventID: ID of the event to initialize seats for.
 //   - venueID: ID of the venue whose seats will be copied.
+//   - priceCents: price of each seat in cents.
 //
 // Returns:
 //   - int64: number of rows inserted into event_seats.
 //   - error: repository.ErrConflict if an event seat with the same attributes exists.
-func (r *AdminRepo) InitEventSeats(ctx context.Context, eventID int64, venueID int64) (int64, error) {
+func (r *AdminRepo) InitEventSeats(
+	ctx context.Context,
+	eventID int64,
+	venueID int64,
+	priceCents int,
+) (int64, error) {
 	const op = "postgres.AdminRepo.InitEventSeats"
 
 	db := r.handle()
 
 	tag, err := db.Exec(ctx,
-		`INSERT INTO event_seats(event_id, seat_id, status)
-			 SELECT $1, s.id, 'available'
+		`INSERT INTO event_seats(event_id, seat_id, status, price_cents)
+			 SELECT $1, s.id, 'available', $3
 		 FROM seats s
 		 WHERE s.venue_id = $2
 			 ON CONFLICT DO NOTHING`,
-		eventID, venueID,
+		eventID, venueID, priceCents,
 	)
 	if err != nil {
 		return 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
@@ -154,3 +537,70 @@ func (r *AdminRepo) InitEventSeats(ctx context.Context, eventID int64, venueID i
 
 	return tag.RowsAffected(), nil
 }
+
+// CreateAPIKey stores a new API key by its hash.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - clientName: name of the client the key is issued to.
+//   - keyHash: SHA-256 hash of the key.
+//   - scopes: scopes granted to the key.
+//
+// Returns:
+//   - *domain.APIKey: the stored key.
+//   - error: repository.ErrConflict if a key with the same hash exists.
+func (r *AdminRepo) CreateAPIKey(
+	ctx context.Context,
+	clientName string,
+	keyHash []byte,
+	scopes []domain.APIKeyScope,
+) (*domain.APIKey, error) {
+	const op = "postgres.AdminRepo.CreateAPIKey"
+
+	db := r.handle()
+
+	names := make([]string, len(scopes))
+	for i, sc := range scopes {
+		names[i] = string(sc)
+	}
+
+	k := domain.APIKey{ClientName: clientName, KeyHash: keyHash, Scopes: scopes}
+	if err := db.QueryRow(ctx,
+		`INSERT INTO api_keys(client_name, key_hash, scopes)
+			 VALUES ($1, $2, $3)
+			 RETURNING id, created_at`,
+		clientName, keyHash, names,
+	).Scan(&k.ID, &k.CreatedAt); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return &k, nil
+}
+
+// RevokeAPIKey marks an API key as revoked.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - id: ID of the key.
+//
+// Returns:
+//   - error: repository.ErrNotFound if the key does not exist or is already revoked.
+func (r *AdminRepo) RevokeAPIKey(ctx context.Context, id int64) error {
+	const op = "postgres.AdminRepo.RevokeAPIKey"
+
+	db := r.handle()
+
+	tag, err := db.Exec(ctx,
+		`UPDATE api_keys SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s:%w", op, repository.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/postgres/pgtest"
+)
+
+func TestBatchCreateSeatsChunks(t *testing.T) {
+	pool := pgtest.New(t)
+	repo := NewStore(pool).Admin()
+	ctx := context.Background()
+
+	var venueID int64
+	if err := pool.QueryRow(ctx, `INSERT INTO venues (name) VALUES ('Arena') RETURNING id`).Scan(&venueID); err != nil {
+		t.Fatal(err)
+	}
+
+	// 5000 seats span several batches, the last one full.
+	const perRow = 100
+	seats := make([]domain.Seat, 0, 5000)
+	for i := range cap(seats) {
+		seats = append(seats, domain.Seat{
+			Section: "A",
+			Row:     strconv.Itoa(i/perRow + 1),
+			Number:  i%perRow + 1,
+		})
+	}
+
+	created, err := repo.BatchCreateSeats(ctx, venueID, seats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != int64(len(seats)) {
+		t.Errorf("created = %d, want %d", created, len(seats))
+	}
+
+	var stored int
+	if err := pool.QueryRow(ctx, `SELECT count(*) FROM seats WHERE venue_id = $1`, venueID).Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored != len(seats) {
+		t.Errorf("stored seats = %d, want %d", stored, len(seats))
+	}
+}
+
+func TestBatchCreateSeatsSkipsExisting(t *testing.T) {
+	pool := pgtest.New(t)
+	repo := NewStore(pool).Admin()
+	ctx := context.Background()
+
+	var venueID int64
+	if err := pool.QueryRow(ctx, `INSERT INTO venues (name) VALUES ('Hall') RETURNING id`).Scan(&venueID); err != nil {
+		t.Fatal(err)
+	}
+	seat := func(row string, number int) domain.Seat {
+		return domain.Seat{Section: "A", Row: row, Number: number}
+	}
+
+	created, err := repo.BatchCreateSeats(ctx, venueID, []domain.Seat{seat("1", 1), seat("1", 2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != 2 {
+		t.Fatalf("seed: created = %d, want 2", created)
+	}
+
+	// Two of the four seats exist already.
+	created, err = repo.BatchCreateSeats(ctx, venueID, []domain.Seat{
+		seat("1", 1), seat("1", 2), seat("1", 3), seat("2", 1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != 2 {
+		t.Errorf("created = %d, want 2", created)
+	}
+
+	var stored int
+	if err := pool.QueryRow(ctx, `SELECT count(*) FROM seats WHERE venue_id = $1`, venueID).Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored != 4 {
+		t.Errorf("stored seats = %d, want 4", stored)
+	}
+}
@@ -7,8 +7,12 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kirinyoku/tix-go/internal/tracing"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("github.com/kirinyoku/tix-go/internal/repository/postgres")
+
 type DB interface {
 	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
@@ -30,7 +34,10 @@ func (s *Store) RunTx(
 	ctx context.Context,
 	opts *pgx.TxOptions,
 	fn func(ctx context.Context, tx DB) error,
-) error {
+) (err error) {
+	ctx, span := tracer.Start(ctx, "postgres.Store.RunTx")
+	defer tracing.End(span, &err)
+
 	txOpts := pgx.TxOptions{
 		IsoLevel:   pgx.Serializable,
 		AccessMode: pgx.ReadWrite,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/kirinyoku/tix-go/internal/domain"
 )
@@ -42,13 +43,40 @@ func (r *OrderRepo) Get(ctx context.Context, id string) (*domain.Order, error) {
 
 	var o domain.Order
 	err := db.QueryRow(ctx,
-		`SELECT id, event_id, user_id, total_cents, created_at
+		`SELECT id, event_id, user_id, total_cents, ga_qty, status, created_at, refunded_at
 			 FROM orders WHERE id = $1`,
 		id,
-	).Scan(&o.ID, &o.EventID, &o.UserID, &o.TotalCents, &o.CreatedAt)
+	).Scan(&o.ID, &o.EventID, &o.UserID, &o.TotalCents, &o.GAQty, &o.Status, &o.CreatedAt, &o.RefundedAt)
 	if err != nil {
 		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
 	return &o, nil
 }
+
+// GetTicket retrieves a ticket by its ID.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - id: unique identifier of the ticket to retrieve.
+//
+// Returns:
+//   - *domain.Ticket: the ticket when found.
+//   - error: repository.ErrNotFound if the ticket does not exist.
+func (r *OrderRepo) GetTicket(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
+	const op = "postgres.OrderRepo.GetTicket"
+
+	db := r.handle()
+
+	var t domain.Ticket
+	err := db.QueryRow(ctx,
+		`SELECT id, order_id, event_id, COALESCE(seat_id, 0), created_at
+			 FROM tickets WHERE id = $1`,
+		id,
+	).Scan(&t.ID, &t.OrderID, &t.EventID, &t.SeatID, &t.Created)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return &t, nil
+}
@@ -3,8 +3,8 @@ package postgres
 import (
 	"errors"
 
-	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/kirinyoku/tix-go/internal/repository"
 )
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -56,6 +58,49 @@ func (r *QueryRepo) GetVenue(ctx context.Context, id int64) (*domain.Venue, erro
 	return &v, nil
 }
 
+// ListVenues lists venues ordered by ID.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - limit, offset: pagination parameters.
+//
+// Returns:
+//   - []domain.Venue: list of venues, nil if there are none.
+//   - error: if the query fails.
+func (r *QueryRepo) ListVenues(ctx context.Context, limit, offset int) ([]domain.Venue, error) {
+	const op = "postgres.QueryRepo.ListVenues"
+
+	db := r.handle()
+
+	rows, err := db.Query(ctx,
+		`SELECT id, name, seating_scheme
+		 FROM venues
+		 ORDER BY id
+		 LIMIT $1 OFFSET $2`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	var out []domain.Venue
+	for rows.Next() {
+		var v domain.Venue
+		if err := rows.Scan(&v.ID, &v.Name, &v.SeatingScheme); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		out = append(out, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
 // GetEvent retrieves an event by its ID.
 //
 // Parameters:
@@ -72,10 +117,10 @@ func (r *QueryRepo) GetEvent(ctx context.Context, id int64) (*domain.Event, erro
 
 	var e domain.Event
 	err := db.QueryRow(ctx,
-		`SELECT id, venue_id, title, starts_at, ends_at
+		`SELECT id, venue_id, title, starts_at, ends_at, cancelled_at
        	 FROM events WHERE id = $1`,
 		id,
-	).Scan(&e.ID, &e.VenueID, &e.Title, &e.Starts, &e.Ends)
+	).Scan(&e.ID, &e.VenueID, &e.Title, &e.Starts, &e.Ends, &e.CancelledAt)
 	if err != nil {
 		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
@@ -83,27 +128,70 @@ func (r *QueryRepo) GetEvent(ctx context.Context, id int64) (*domain.Event, erro
 	return &e, nil
 }
 
-// ListEvents lists all events.
+// ListEvents lists all events ordered by start time.
 //
 // Parameters:
 //   - ctx: request-scoped context for cancellation and timeouts.
 //   - limit, offset: pagination parameters.
 //
 // Returns:
-//   - []domain.Event: list of events.
-//   - error: repository.ErrNotFound if no events are found.
+//   - []domain.Event: list of events, nil if there are none.
+//   - error: if the query fails.
 func (r *QueryRepo) ListEvents(ctx context.Context, limit, offset int) ([]domain.Event, error) {
-	const op = "postgres.QueryRepo.ListEvents"
+	return r.ListEventsFiltered(ctx, nil, nil, nil, limit, offset)
+}
+
+// ListEventsFiltered lists events ordered by start time, optionally limited
+// to one venue and to events starting within [from, to]. Nil filters are
+// ignored.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - venueID: only events at this venue, if set.
+//   - from: only events starting at or after this time, if set.
+//   - to: only events starting at or before this time, if set.
+//   - limit, offset: pagination parameters.
+//
+// Returns:
+//   - []domain.Event: list of events, nil if there are none.
+//   - error: if the query fails.
+func (r *QueryRepo) ListEventsFiltered(
+	ctx context.Context,
+	venueID *int64,
+	from, to *time.Time,
+	limit, offset int,
+) ([]domain.Event, error) {
+	const op = "postgres.QueryRepo.ListEventsFiltered"
 
 	db := r.handle()
 
-	rows, err := db.Query(ctx,
-		`SELECT id, venue_id, title, starts_at, ends_at
-		 FROM evenets
-		 ORDER BY starts_at
-		 LIMIT $1 OFFSET $2`,
-		limit, offset,
+	var (
+		where []string
+		args  []any
 	)
+	if venueID != nil {
+		args = append(args, *venueID)
+		where = append(where, fmt.Sprintf("venue_id = $%d", len(args)))
+	}
+	if from != nil {
+		args = append(args, *from)
+		where = append(where, fmt.Sprintf("starts_at >= $%d", len(args)))
+	}
+	if to != nil {
+		args = append(args, *to)
+		where = append(where, fmt.Sprintf("starts_at <= $%d", len(args)))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`SELECT id, venue_id, title, starts_at, ends_at, cancelled_at FROM events`)
+	if len(where) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(where, " AND "))
+	}
+	args = append(args, limit, offset)
+	fmt.Fprintf(&sb, " ORDER BY starts_at LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := db.Query(ctx, sb.String(), args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
@@ -113,7 +201,7 @@ func (r *QueryRepo) ListEvents(ctx context.Context, limit, offset int) ([]domain
 	var out []domain.Event
 	for rows.Next() {
 		var e domain.Event
-		if err := rows.Scan(&e.ID, &e.VenueID, &e.Title, &e.Ends); err != nil {
+		if err := rows.Scan(&e.ID, &e.VenueID, &e.Title, &e.Starts, &e.Ends, &e.CancelledAt); err != nil {
 			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 		}
 
@@ -159,6 +247,142 @@ func (r *QueryRepo) CountsByStatus(ctx context.Context, eventID int64) (*domain.
 	return &ec, nil
 }
 
+// CountHoldableSeats counts the seats of an event and those of them that a
+// new hold could take: available seats and seats whose hold has expired.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//
+// Returns:
+//   - int64: number of holdable seats.
+//   - int64: total number of seats.
+//   - error: if the query fails.
+func (r *QueryRepo) CountHoldableSeats(ctx context.Context, eventID int64) (int64, int64, error) {
+	const op = "postgres.QueryRepo.CountHoldableSeats"
+
+	db := r.handle()
+
+	var holdable, total int64
+	err := db.QueryRow(ctx,
+		`SELECT
+       	 	COUNT(*) FILTER (WHERE status = 'available'
+       	 	                    OR (status = 'held' AND hold_expires_at <= now())),
+       	 	COUNT(*)
+     	 FROM event_seats
+     	 WHERE event_id = $1`,
+		eventID,
+	).Scan(&holdable, &total)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return holdable, total, nil
+}
+
+// CountsBySection counts seats by status for each section of an event.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//
+// Returns:
+//   - []domain.SectionCounts: counts per section ordered by section name.
+//   - error: if the query fails.
+func (r *QueryRepo) CountsBySection(ctx context.Context, eventID int64) ([]domain.SectionCounts, error) {
+	const op = "postgres.QueryRepo.CountsBySection"
+
+	db := r.handle()
+
+	rows, err := db.Query(ctx,
+		`SELECT s.section,
+		        COALESCE(SUM(CASE WHEN es.status = 'available' THEN 1 ELSE 0 END), 0),
+		        COALESCE(SUM(CASE WHEN es.status = 'held' THEN 1 ELSE 0 END), 0),
+		        COALESCE(SUM(CASE WHEN es.status = 'sold' THEN 1 ELSE 0 END), 0)
+		 FROM event_seats es
+		 JOIN seats s ON s.id = es.seat_id
+		 WHERE es.event_id = $1
+		 GROUP BY s.section
+		 ORDER BY s.section`,
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	var out []domain.SectionCounts
+	for rows.Next() {
+		var sc domain.SectionCounts
+		if err := rows.Scan(&sc.Section, &sc.Available, &sc.Held, &sc.Sold); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		sc.Total = sc.Available + sc.Held + sc.Sold
+		out = append(out, sc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
+// CountsByStatusBatch counts seats by status for several events in a single
+// query. Events that do not exist are omitted from the result; existing
+// events without seats are reported with zero counts.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventIDs: identifiers of the events to count.
+//
+// Returns:
+//   - map[int64]domain.EventCounts: counts keyed by event ID.
+//   - error: if the query fails.
+func (r *QueryRepo) CountsByStatusBatch(
+	ctx context.Context,
+	eventIDs []int64,
+) (map[int64]domain.EventCounts, error) {
+	const op = "postgres.QueryRepo.CountsByStatusBatch"
+
+	db := r.handle()
+
+	rows, err := db.Query(ctx,
+		`SELECT e.id,
+		        COALESCE(SUM(CASE WHEN es.status = 'available' THEN 1 ELSE 0 END), 0),
+		        COALESCE(SUM(CASE WHEN es.status = 'held' THEN 1 ELSE 0 END), 0),
+		        COALESCE(SUM(CASE WHEN es.status = 'sold' THEN 1 ELSE 0 END), 0)
+		 FROM events e
+		 LEFT JOIN event_seats es ON es.event_id = e.id
+		 WHERE e.id = ANY($1)
+		 GROUP BY e.id`,
+		eventIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	out := make(map[int64]domain.EventCounts, len(eventIDs))
+	for rows.Next() {
+		var id int64
+		var ec domain.EventCounts
+		if err := rows.Scan(&id, &ec.Available, &ec.Held, &ec.Sold); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		ec.Total = ec.Available + ec.Held + ec.Sold
+		out[id] = ec
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
 // ListEventSeats lists seats for an event.
 //
 // Parameters:
@@ -184,8 +408,12 @@ func (r *QueryRepo) ListEventSeats(
 
 	if onlyAvailable {
 		rows, err = db.Query(ctx,
-			`SELECT s.id, s.venue_id, s.section, s.row, s.number, es.status
-			 FROM events_seats es
+			`SELECT s.id, s.venue_id, s.section, s.row, s.number, es.status,
+			        CASE WHEN es.status = 'held' AND es.hold_expires_at > now()
+			             THEN es.hold_expires_at END,
+			        CASE WHEN es.status = 'held' AND es.hold_expires_at <= now()
+			             THEN es.version + 1 ELSE es.version END
+			 FROM event_seats es
 			 JOIN seats s ON s.id = es.seat_id
 			 WHERE es.event_id = $1 AND es.status = 'available'
 			 ORDER BY s.section, s.row, s.number
@@ -194,7 +422,11 @@ func (r *QueryRepo) ListEventSeats(
 		)
 	} else {
 		rows, err = db.Query(ctx,
-			`SELECT s.id, s.venue_id, s.section, s.row, s.number, es.status
+			`SELECT s.id, s.venue_id, s.section, s.row, s.number, es.status,
+			        CASE WHEN es.status = 'held' AND es.hold_expires_at > now()
+			             THEN es.hold_expires_at END,
+			        CASE WHEN es.status = 'held' AND es.hold_expires_at <= now()
+			             THEN es.version + 1 ELSE es.version END
          	 FROM event_seats es
           	 JOIN seats s ON s.id = es.seat_id
         	 WHERE es.event_id = $1
@@ -221,6 +453,8 @@ func (r *QueryRepo) ListEventSeats(
 			&sws.Row,
 			&sws.Number,
 			&status,
+			&sws.HoldExpiresAt,
+			&sws.Version,
 		); err != nil {
 			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 		}
@@ -235,44 +469,136 @@ func (r *QueryRepo) ListEventSeats(
 	return out, nil
 }
 
-// GetOrderWithTickets retrieves an order with its tickets.
+// SeatsByIDs loads the seats with the given IDs that belong to an event.
 //
 // Parameters:
 //   - ctx: request-scoped context for cancellation and timeouts.
-//   - orderID: unique identifier of the order to retrieve.
+//   - eventID: unique identifier of the event.
+//   - seatIDs: identifiers of the seats to load.
 //
 // Returns:
-//   - *domain.OrderWithTickets: the order with its tickets when found.
-//   - error: repository.ErrNotFound if the order is not found.
-func (r *QueryRepo) GetOrderWithTickets(ctx context.Context, orderID string) (*domain.OrderWithTickets, error) {
-	const op = "postgres.QueryRepo.GetOrderWithTickets"
+//   - []domain.Seat: the seats found; seats outside the event are omitted.
+//   - error: if the query fails.
+func (r *QueryRepo) SeatsByIDs(ctx context.Context, eventID int64, seatIDs []int64) ([]domain.Seat, error) {
+	const op = "postgres.QueryRepo.SeatsByIDs"
 
 	db := r.handle()
 
-	var out domain.OrderWithTickets
+	rows, err := db.Query(ctx,
+		`SELECT s.id, s.venue_id, s.section, s.row, s.number
+		 FROM event_seats es
+		 JOIN seats s ON s.id = es.seat_id
+		 WHERE es.event_id = $1 AND es.seat_id = ANY($2)
+		 ORDER BY s.section, s.row, s.number`,
+		eventID, seatIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
 
-	err := db.QueryRow(ctx,
-		`SELECT id, event_id, user_id, total_cents, created_at
-         FROM orders
-         WHERE id = $1`,
-		orderID,
-	).Scan(
-		&out.Order.ID,
-		&out.Order.EventID,
-		&out.Order.UserID,
-		&out.Order.TotalCents,
-		&out.Order.CreatedAt,
+	defer rows.Close()
+
+	var out []domain.Seat
+	for rows.Next() {
+		var st domain.Seat
+		if err := rows.Scan(&st.ID, &st.VenueID, &st.Section, &st.Row, &st.Number); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		out = append(out, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
+// SuggestSeats returns up to count available seats of an event, preferring
+// the lowest row and, within a row, the seats closest to its centre. Holds
+// that have expired but are not yet swept count as available.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//   - count: maximum number of seats to return.
+//
+// Returns:
+//   - []int64: IDs of the suggested seats in preference order.
+//   - error: if the query fails.
+func (r *QueryRepo) SuggestSeats(ctx context.Context, eventID int64, count int) ([]int64, error) {
+	const op = "postgres.QueryRepo.SuggestSeats"
+
+	db := r.handle()
+
+	rows, err := db.Query(ctx,
+		`SELECT id FROM (
+		     SELECT s.id, s.section, s.row, s.number, es.status, es.hold_expires_at,
+		            MIN(s.number) OVER w AS lo,
+		            MAX(s.number) OVER w AS hi
+		     FROM event_seats es
+		     JOIN seats s ON s.id = es.seat_id
+		     WHERE es.event_id = $1
+		     WINDOW w AS (PARTITION BY s.section, s.row)
+		 ) t
+		 WHERE status = 'available'
+		    OR (status = 'held' AND hold_expires_at <= now())
+		 ORDER BY row, ABS(2 * number - (lo + hi)), section, number
+		 LIMIT $2`,
+		eventID, count,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
+	defer rows.Close()
+
+	var out []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		out = append(out, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
+// SeatMap lists every seat of an event together with its status, ordered by
+// (section, row, number). Holds that have expired but are not yet swept are
+// reported as available, at the version releasing them will give the seat,
+// so the version can be passed straight to HoldSeatsAtVersions.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//
+// Returns:
+//   - []domain.SeatWithStatus: all seats of the event with their status.
+//   - error: if the query fails.
+func (r *QueryRepo) SeatMap(ctx context.Context, eventID int64) ([]domain.SeatWithStatus, error) {
+	const op = "postgres.QueryRepo.SeatMap"
+
+	db := r.handle()
+
 	rows, err := db.Query(ctx,
-		`SELECT id, order_id, event_id, seat_id, created_at
-         FROM tickets
-      	 WHERE order_id = $1
-       	 ORDER BY created_at`,
-		orderID,
+		`SELECT s.id, s.venue_id, s.section, s.row, s.number,
+		        CASE WHEN es.status = 'held' AND es.hold_expires_at <= now()
+		             THEN 'available' ELSE es.status::text END,
+		        CASE WHEN es.status = 'held' AND es.hold_expires_at > now()
+		             THEN es.hold_expires_at END,
+		        CASE WHEN es.status = 'held' AND es.hold_expires_at <= now()
+		             THEN es.version + 1 ELSE es.version END
+		 FROM event_seats es
+		 JOIN seats s ON s.id = es.seat_id
+		 WHERE es.event_id = $1
+		 ORDER BY s.section, s.row, s.number`,
+		eventID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
@@ -280,48 +606,830 @@ func (r *QueryRepo) GetOrderWithTickets(ctx context.Context, orderID string) (*d
 
 	defer rows.Close()
 
+	var out []domain.SeatWithStatus
 	for rows.Next() {
-		var t domain.Ticket
+		var sws domain.SeatWithStatus
+		var status string
 
 		if err := rows.Scan(
-			&t.ID,
-			&t.OrderID,
-			&t.EventID,
-			&t.SeatID,
-			&t.Created,
+			&sws.ID,
+			&sws.VenueID,
+			&sws.Section,
+			&sws.Row,
+			&sws.Number,
+			&status,
+			&sws.HoldExpiresAt,
+			&sws.Version,
 		); err != nil {
 			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 		}
 
-		out.Tickets = append(out.Tickets, t)
+		sws.Status = domain.SeatStatus(status)
+		out = append(out, sws)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("%s:%w", op, err)
 	}
 
-	return &out, nil
+	return out, nil
 }
 
-// EventIDByHold retrieves an event ID by its hold ID.
+// ListEventSeatsForUser lists every seat of an event like SeatMap and marks
+// the seats held by userID under a hold that has not expired yet.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//   - userID: the user whose holds are marked.
 //
 // Returns:
-//   - int64: the event ID when found.
-//   - error: repository.ErrNotFound if the hold is not found.
-func (r *QueryRepo) EventIDByHold(ctx context.Context, holdID uuid.UUID) (int64, error) {
-	const op = "postgres.QueryRepo.EventIDByHold"
+//   - []domain.UserSeat: all seats of the event with their status.
+//   - error: if the query fails.
+func (r *QueryRepo) ListEventSeatsForUser(
+	ctx context.Context,
+	eventID, userID int64,
+) ([]domain.UserSeat, error) {
+	const op = "postgres.QueryRepo.ListEventSeatsForUser"
 
 	db := r.handle()
 
-	var eventID int64
-
-	err := db.QueryRow(ctx, `SELECT event_id FROM holds WHERE id = $1`, holdID).Scan(&eventID)
+	rows, err := db.Query(ctx,
+		`SELECT s.id, s.venue_id, s.section, s.row, s.number,
+		        CASE WHEN es.status = 'held' AND es.hold_expires_at <= now()
+		             THEN 'available' ELSE es.status::text END,
+		        CASE WHEN es.status = 'held' AND es.hold_expires_at > now()
+		             THEN es.hold_expires_at END,
+		        CASE WHEN es.status = 'held' AND es.hold_expires_at <= now()
+		             THEN es.version + 1 ELSE es.version END,
+		        COALESCE(es.status = 'held' AND es.hold_expires_at > now()
+		                 AND h.user_id = $2, false)
+		 FROM event_seats es
+		 JOIN seats s ON s.id = es.seat_id
+		 LEFT JOIN holds h ON h.id = es.hold_id
+		 WHERE es.event_id = $1
+		 ORDER BY s.section, s.row, s.number`,
+		eventID, userID,
+	)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	var out []domain.UserSeat
+	for rows.Next() {
+		var us domain.UserSeat
+		var status string
+
+		if err := rows.Scan(
+			&us.ID,
+			&us.VenueID,
+			&us.Section,
+			&us.Row,
+			&us.Number,
+			&status,
+			&us.HoldExpiresAt,
+			&us.Version,
+			&us.HeldByMe,
+		); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 		}
 
-		return 0, fmt.Errorf("%s:%w", op, err)
+		us.Status = domain.SeatStatus(status)
+		out = append(out, us)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
 	}
 
-	return eventID, nil
+	return out, nil
+}
+
+// CountVenueSeats counts the seats of a venue.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - venueID: unique identifier of the venue.
+//
+// Returns:
+//   - int64: number of seats, zero for a venue without seats.
+//   - error: repository.ErrNotFound if the venue does not exist.
+func (r *QueryRepo) CountVenueSeats(ctx context.Context, venueID int64) (int64, error) {
+	const op = "postgres.QueryRepo.CountVenueSeats"
+
+	db := r.handle()
+
+	var n int64
+	err := db.QueryRow(ctx,
+		`SELECT (SELECT count(*) FROM seats s WHERE s.venue_id = v.id)
+         FROM venues v
+         WHERE v.id = $1`,
+		venueID,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return n, nil
+}
+
+// CountEventSeats counts the seats of an event, matching the filter of
+// ListEventSeats.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//   - onlyAvailable: flag to count only available seats.
+//
+// Returns:
+//   - int64: number of matching seats.
+//   - error: if the query fails.
+func (r *QueryRepo) CountEventSeats(ctx context.Context, eventID int64, onlyAvailable bool) (int64, error) {
+	const op = "postgres.QueryRepo.CountEventSeats"
+
+	db := r.handle()
+
+	var n int64
+	err := db.QueryRow(ctx,
+		`SELECT count(*)
+         FROM event_seats
+         WHERE event_id = $1
+           AND ($2 = false OR status = 'available')`,
+		eventID, onlyAvailable,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return n, nil
+}
+
+// ListEventSeatsAfter lists seats for an event using keyset pagination.
+// Seats are ordered by (section, row, number) and only those strictly after
+// the given position are returned, so concurrent changes never cause rows to
+// be skipped or repeated between pages.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event to retrieve.
+//   - onlyAvailable: flag to filter only available seats.
+//   - afterSection, afterRow, afterNumber: position of the last seat of the
+//     previous page.
+//   - limit: maximum number of seats to return.
+//
+// Returns:
+//   - []domain.SeatWithStatus: list of seats with their status.
+//   - error: if the query fails.
+func (r *QueryRepo) ListEventSeatsAfter(
+	ctx context.Context,
+	eventID int64,
+	onlyAvailable bool,
+	afterSection, afterRow string,
+	afterNumber int,
+	limit int,
+) ([]domain.SeatWithStatus, error) {
+	const op = "postgres.QueryRepo.ListEventSeatsAfter"
+
+	db := r.handle()
+
+	rows, err := db.Query(ctx,
+		`SELECT s.id, s.venue_id, s.section, s.row, s.number, es.status,
+		        CASE WHEN es.status = 'held' AND es.hold_expires_at > now()
+		             THEN es.hold_expires_at END,
+		        CASE WHEN es.status = 'held' AND es.hold_expires_at <= now()
+		             THEN es.version + 1 ELSE es.version END
+         FROM event_seats es
+         JOIN seats s ON s.id = es.seat_id
+         WHERE es.event_id = $1
+           AND ($2 = false OR es.status = 'available')
+           AND (s.section, s.row, s.number) > ($3, $4::int, $5)
+         ORDER BY s.section, s.row, s.number
+         LIMIT $6`,
+		eventID, onlyAvailable, afterSection, afterRow, afterNumber, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	var out []domain.SeatWithStatus
+	for rows.Next() {
+		var sws domain.SeatWithStatus
+		var status string
+
+		if err := rows.Scan(
+			&sws.ID,
+			&sws.VenueID,
+			&sws.Section,
+			&sws.Row,
+			&sws.Number,
+			&status,
+			&sws.HoldExpiresAt,
+			&sws.Version,
+		); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		sws.Status = domain.SeatStatus(status)
+		out = append(out, sws)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
+// SeatStatuses returns the current status of the given seats for an event.
+// Held seats whose hold has already lapsed are reported as available.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//   - seatIDs: IDs of the seats to look up.
+//
+// Returns:
+//   - map[int64]domain.SeatStatus: status by seat ID; seats that do not
+//     belong to the event are omitted.
+//   - error: if the query fails.
+func (r *QueryRepo) SeatStatuses(
+	ctx context.Context,
+	eventID int64,
+	seatIDs []int64,
+) (map[int64]domain.SeatStatus, error) {
+	const op = "postgres.QueryRepo.SeatStatuses"
+
+	db := r.handle()
+
+	rows, err := db.Query(ctx,
+		`SELECT seat_id,
+       	 	CASE WHEN status = 'held' AND hold_expires_at <= now()
+       	 	     THEN 'available' ELSE status::text END
+         FROM event_seats
+         WHERE event_id = $1 AND seat_id = ANY($2)`,
+		eventID, seatIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	out := make(map[int64]domain.SeatStatus, len(seatIDs))
+	for rows.Next() {
+		var seatID int64
+		var status string
+		if err := rows.Scan(&seatID, &status); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		out[seatID] = domain.SeatStatus(status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
+// GetOrderWithTickets retrieves an order with its tickets.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - orderID: unique identifier of the order to retrieve.
+//
+// Returns:
+//   - *domain.OrderWithTickets: the order with its tickets when found.
+//   - error: repository.ErrNotFound if the order is not found.
+func (r *QueryRepo) GetOrderWithTickets(ctx context.Context, orderID string) (*domain.OrderWithTickets, error) {
+	const op = "postgres.QueryRepo.GetOrderWithTickets"
+
+	db := r.handle()
+
+	var out domain.OrderWithTickets
+
+	err := db.QueryRow(ctx,
+		`SELECT id, event_id, user_id, total_cents, ga_qty, status, created_at, refunded_at
+         FROM orders
+         WHERE id = $1`,
+		orderID,
+	).Scan(
+		&out.Order.ID,
+		&out.Order.EventID,
+		&out.Order.UserID,
+		&out.Order.TotalCents,
+		&out.Order.GAQty,
+		&out.Order.Status,
+		&out.Order.CreatedAt,
+		&out.Order.RefundedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	rows, err := db.Query(ctx,
+		`SELECT id, order_id, event_id, COALESCE(seat_id, 0), created_at
+         FROM tickets
+      	 WHERE order_id = $1
+       	 ORDER BY created_at`,
+		orderID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var t domain.Ticket
+
+		if err := rows.Scan(
+			&t.ID,
+			&t.OrderID,
+			&t.EventID,
+			&t.SeatID,
+			&t.Created,
+		); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		out.Tickets = append(out.Tickets, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return &out, nil
+}
+
+// TicketsByOrders retrieves the tickets of several orders of one user in
+// one query.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - orderIDs: IDs of the orders.
+//   - userID: owner of the orders; orders of other users are skipped.
+//
+// Returns:
+//   - map[uuid.UUID][]domain.Ticket: tickets keyed by order ID, oldest first;
+//     orders without tickets are omitted.
+//   - error: if the query fails.
+func (r *QueryRepo) TicketsByOrders(
+	ctx context.Context,
+	orderIDs []uuid.UUID,
+	userID int64,
+) (map[uuid.UUID][]domain.Ticket, error) {
+	const op = "postgres.QueryRepo.TicketsByOrders"
+
+	db := r.handle()
+
+	rows, err := db.Query(ctx,
+		`SELECT t.id, t.order_id, t.event_id, COALESCE(t.seat_id, 0), t.created_at
+         FROM tickets t
+         JOIN orders o ON o.id = t.order_id
+         WHERE t.order_id = ANY($1) AND o.user_id = $2
+         ORDER BY t.order_id, t.created_at`,
+		orderIDs, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	out := make(map[uuid.UUID][]domain.Ticket, len(orderIDs))
+	for rows.Next() {
+		var t domain.Ticket
+
+		if err := rows.Scan(
+			&t.ID,
+			&t.OrderID,
+			&t.EventID,
+			&t.SeatID,
+			&t.Created,
+		); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		out[t.OrderID] = append(out[t.OrderID], t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
+// ListOrdersByUser lists orders placed by a user, newest first.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - userID: unique identifier of the user.
+//   - limit, offset: pagination parameters.
+//
+// Returns:
+//   - []domain.Order: list of orders, nil if the user has none.
+//   - error: if the query fails.
+func (r *QueryRepo) ListOrdersByUser(
+	ctx context.Context,
+	userID int64,
+	limit, offset int,
+) ([]domain.Order, error) {
+	const op = "postgres.QueryRepo.ListOrdersByUser"
+
+	db := r.handle()
+
+	rows, err := db.Query(ctx,
+		`SELECT id, event_id, user_id, total_cents, ga_qty, status, created_at, refunded_at
+         FROM orders
+         WHERE user_id = $1
+         ORDER BY created_at DESC, id
+         LIMIT $2 OFFSET $3`,
+		userID, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	var out []domain.Order
+	for rows.Next() {
+		var o domain.Order
+		if err := rows.Scan(
+			&o.ID,
+			&o.EventID,
+			&o.UserID,
+			&o.TotalCents,
+			&o.GAQty,
+			&o.Status,
+			&o.CreatedAt,
+			&o.RefundedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		out = append(out, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
+// ListOrders lists orders created within [from, to), ordered by creation
+// time.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - from, to: bounds on the order creation time; to is exclusive.
+//   - desc: newest first when true, oldest first otherwise.
+//   - limit, offset: pagination parameters.
+//
+// Returns:
+//   - []domain.Order: list of orders, nil if there are none.
+//   - error: if the query fails.
+func (r *QueryRepo) ListOrders(
+	ctx context.Context,
+	from, to time.Time,
+	desc bool,
+	limit, offset int,
+) ([]domain.Order, error) {
+	const op = "postgres.QueryRepo.ListOrders"
+
+	db := r.handle()
+
+	order := "ASC"
+	if desc {
+		order = "DESC"
+	}
+
+	rows, err := db.Query(ctx,
+		`SELECT id, event_id, user_id, total_cents, ga_qty, status, created_at, refunded_at
+         FROM orders
+         WHERE created_at >= $1 AND created_at < $2
+         ORDER BY created_at `+order+`, id `+order+`
+         LIMIT $3 OFFSET $4`,
+		from, to, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	var out []domain.Order
+	for rows.Next() {
+		var o domain.Order
+		if err := rows.Scan(
+			&o.ID,
+			&o.EventID,
+			&o.UserID,
+			&o.TotalCents,
+			&o.GAQty,
+			&o.Status,
+			&o.CreatedAt,
+			&o.RefundedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		out = append(out, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
+// EventRevenue aggregates the confirmed orders of an event. Tickets sold
+// include general-admission tickets. Refunded orders are not counted.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//
+// Returns:
+//   - *domain.EventRevenue: the aggregates, zero if the event has no orders.
+//   - error: repository.ErrNotFound if the event is not found.
+func (r *QueryRepo) EventRevenue(ctx context.Context, eventID int64) (*domain.EventRevenue, error) {
+	const op = "postgres.QueryRepo.EventRevenue"
+
+	db := r.handle()
+
+	var rev domain.EventRevenue
+	err := db.QueryRow(ctx,
+		`SELECT
+			count(o.id),
+			(
+				SELECT count(*)
+				FROM tickets t
+				JOIN orders o2 ON o2.id = t.order_id
+				WHERE t.event_id = e.id AND o2.status = 'confirmed'
+			),
+			COALESCE(sum(o.total_cents), 0)
+		 FROM events e
+		 LEFT JOIN orders o ON o.event_id = e.id AND o.status = 'confirmed'
+		 WHERE e.id = $1
+		 GROUP BY e.id`,
+		eventID,
+	).Scan(&rev.OrdersCount, &rev.TicketsSold, &rev.TotalCents)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return &rev, nil
+}
+
+// EventIDByHold retrieves an event ID by its hold ID.
+//
+// Returns:
+//   - int64: the event ID when found.
+//   - error: repository.ErrNotFound if the hold is not found.
+func (r *QueryRepo) EventIDByHold(ctx context.Context, holdID uuid.UUID) (int64, error) {
+	const op = "postgres.QueryRepo.EventIDByHold"
+
+	db := r.handle()
+
+	var eventID int64
+
+	err := db.QueryRow(ctx, `SELECT event_id FROM holds WHERE id = $1`, holdID).Scan(&eventID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		return 0, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return eventID, nil
+}
+
+// HoldOwner retrieves the event and user IDs of a hold.
+//
+// Returns:
+//   - int64: the event ID when found.
+//   - int64: the ID of the user who placed the hold.
+//   - error: repository.ErrNotFound if the hold is not found.
+func (r *QueryRepo) HoldOwner(ctx context.Context, holdID uuid.UUID) (int64, int64, error) {
+	const op = "postgres.QueryRepo.HoldOwner"
+
+	db := r.handle()
+
+	var eventID, userID int64
+
+	err := db.QueryRow(ctx,
+		`SELECT event_id, user_id FROM holds WHERE id = $1`,
+		holdID,
+	).Scan(&eventID, &userID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return eventID, userID, nil
+}
+
+// OrderOwner retrieves the ID of the user who placed an order.
+//
+// Returns:
+//   - int64: the user ID when found.
+//   - error: repository.ErrNotFound if the order is not found.
+func (r *QueryRepo) OrderOwner(ctx context.Context, orderID uuid.UUID) (int64, error) {
+	const op = "postgres.QueryRepo.OrderOwner"
+
+	db := r.handle()
+
+	var userID int64
+
+	err := db.QueryRow(ctx,
+		`SELECT user_id FROM orders WHERE id = $1`,
+		orderID,
+	).Scan(&userID)
+	if err != nil {
+		return 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return userID, nil
+}
+
+// OrderIDByHold retrieves the ID of the order a hold was confirmed into.
+//
+// Returns:
+//   - uuid.UUID: the order ID when found.
+//   - error: repository.ErrNotFound if the hold was never confirmed.
+func (r *QueryRepo) OrderIDByHold(ctx context.Context, holdID uuid.UUID) (uuid.UUID, error) {
+	const op = "postgres.QueryRepo.OrderIDByHold"
+
+	db := r.handle()
+
+	var orderID uuid.UUID
+
+	err := db.QueryRow(ctx,
+		`SELECT id FROM orders WHERE hold_id = $1`,
+		holdID,
+	).Scan(&orderID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return orderID, nil
+}
+
+// ListActiveHolds lists the unexpired holds of an event, soonest to expire
+// first.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//   - limit, offset: pagination parameters.
+//
+// Returns:
+//   - []domain.HoldSummary: active holds with their seat counts, nil if there are none.
+//   - error: if the query fails.
+func (r *QueryRepo) ListActiveHolds(ctx context.Context, eventID int64, limit, offset int) ([]domain.HoldSummary, error) {
+	const op = "postgres.QueryRepo.ListActiveHolds"
+
+	db := r.handle()
+
+	rows, err := db.Query(ctx,
+		`SELECT h.id, h.event_id, h.user_id, count(es.seat_id) + h.ga_qty, h.created_at, h.expires_at
+		 FROM holds h
+		 LEFT JOIN event_seats es ON es.hold_id = h.id AND es.status = 'held'
+		 WHERE h.event_id = $1 AND h.expires_at > now()
+		 GROUP BY h.id
+		 ORDER BY h.expires_at, h.id
+		 LIMIT $2 OFFSET $3`,
+		eventID, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	var out []domain.HoldSummary
+	for rows.Next() {
+		var h domain.HoldSummary
+		if err := rows.Scan(&h.ID, &h.EventID, &h.UserID, &h.SeatCount, &h.CreatedAt, &h.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
+// ListEventLog lists the hold lifecycle entries of an event, oldest first.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//   - limit, offset: pagination parameters.
+//
+// Returns:
+//   - []domain.EventLogEntry: log entries, nil if there are none.
+//   - error: if the query fails.
+func (r *QueryRepo) ListEventLog(ctx context.Context, eventID int64, limit, offset int) ([]domain.EventLogEntry, error) {
+	const op = "postgres.QueryRepo.ListEventLog"
+
+	db := r.handle()
+
+	rows, err := db.Query(ctx,
+		`SELECT id, event_id, hold_id, user_id, kind, seat_count, order_id, created_at
+		 FROM event_log
+		 WHERE event_id = $1
+		 ORDER BY id
+		 LIMIT $2 OFFSET $3`,
+		eventID, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	var out []domain.EventLogEntry
+	for rows.Next() {
+		var e domain.EventLogEntry
+		if err := rows.Scan(
+			&e.ID, &e.EventID, &e.HoldID, &e.UserID, &e.Kind, &e.SeatCount, &e.OrderID, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return out, nil
+}
+
+// EventIDsBySeat lists the events a seat has been materialized for.
+//
+// Returns:
+//   - []int64: event IDs, nil if there are none.
+//   - error: if the query fails.
+func (r *QueryRepo) EventIDsBySeat(ctx context.Context, seatID int64) ([]int64, error) {
+	const op = "postgres.QueryRepo.EventIDsBySeat"
+
+	db := r.handle()
+
+	rows, err := db.Query(ctx,
+		`SELECT event_id FROM event_seats WHERE seat_id = $1 ORDER BY event_id`,
+		seatID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return ids, nil
+}
+
+// APIKeyByHash retrieves an API key, revoked or not, by its hash.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - keyHash: SHA-256 hash of the key.
+//
+// Returns:
+//   - *domain.APIKey: the key when found.
+//   - error: repository.ErrNotFound if no key has this hash.
+func (r *QueryRepo) APIKeyByHash(ctx context.Context, keyHash []byte) (*domain.APIKey, error) {
+	const op = "postgres.QueryRepo.APIKeyByHash"
+
+	db := r.handle()
+
+	var (
+		k      domain.APIKey
+		scopes []string
+	)
+	err := db.QueryRow(ctx,
+		`SELECT id, client_name, key_hash, scopes, created_at, revoked_at
+		 FROM api_keys
+		 WHERE key_hash = $1`,
+		keyHash,
+	).Scan(&k.ID, &k.ClientName, &k.KeyHash, &scopes, &k.CreatedAt, &k.RevokedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	for _, sc := range scopes {
+		k.Scopes = append(k.Scopes, domain.APIKeyScope(sc))
+	}
+
+	return &k, nil
 }
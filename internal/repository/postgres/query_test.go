@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/postgres/pgtest"
+)
+
+func TestListEvents(t *testing.T) {
+	pool := pgtest.New(t)
+	repo := NewStore(pool).Query()
+	ctx := context.Background()
+
+	var venueID int64
+	if err := pool.QueryRow(ctx,
+		`INSERT INTO venues (name) VALUES ('Hall') RETURNING id`,
+	).Scan(&venueID); err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Date(2030, 1, 1, 18, 0, 0, 0, time.UTC)
+	want := []struct {
+		title        string
+		starts, ends time.Time
+	}{
+		{title: "first", starts: base, ends: base.Add(2 * time.Hour)},
+		{title: "second", starts: base.Add(24 * time.Hour), ends: base.Add(27 * time.Hour)},
+	}
+	// Insert out of order so the result order comes from starts_at.
+	for i := len(want) - 1; i >= 0; i-- {
+		if _, err := pool.Exec(ctx,
+			`INSERT INTO events (venue_id, title, starts_at, ends_at) VALUES ($1, $2, $3, $4)`,
+			venueID, want[i].title, want[i].starts, want[i].ends,
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := repo.ListEvents(ctx, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d events, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		e := got[i]
+		if e.Title != w.title || e.VenueID != venueID {
+			t.Errorf("event %d: got %q at venue %d, want %q at venue %d", i, e.Title, e.VenueID, w.title, venueID)
+		}
+		if !e.Starts.Equal(w.starts) {
+			t.Errorf("event %d: Starts = %v, want %v", i, e.Starts, w.starts)
+		}
+		if !e.Ends.Equal(w.ends) {
+			t.Errorf("event %d: Ends = %v, want %v", i, e.Ends, w.ends)
+		}
+	}
+}
+
+func TestListEventsFiltered(t *testing.T) {
+	pool := pgtest.New(t)
+	repo := NewStore(pool).Query()
+	ctx := context.Background()
+
+	venue := func(name string) int64 {
+		t.Helper()
+		var id int64
+		if err := pool.QueryRow(ctx,
+			`INSERT INTO venues (name) VALUES ($1) RETURNING id`, name,
+		).Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	hall, arena := venue("Hall"), venue("Arena")
+
+	base := time.Date(2030, 1, 1, 18, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return base.Add(time.Duration(n) * 24 * time.Hour) }
+	for i, e := range []struct {
+		title   string
+		venueID int64
+	}{
+		{title: "hall 1", venueID: hall},
+		{title: "arena 1", venueID: arena},
+		{title: "hall 2", venueID: hall},
+		{title: "arena 2", venueID: arena},
+	} {
+		if _, err := pool.Exec(ctx,
+			`INSERT INTO events (venue_id, title, starts_at, ends_at) VALUES ($1, $2, $3, $4)`,
+			e.venueID, e.title, day(i), day(i).Add(2*time.Hour),
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ptr := func(v time.Time) *time.Time { return &v }
+
+	tests := []struct {
+		name          string
+		venueID       *int64
+		from, to      *time.Time
+		limit, offset int
+		want          []string
+	}{
+		{name: "no filters", limit: 10, want: []string{"hall 1", "arena 1", "hall 2", "arena 2"}},
+		{name: "venue", venueID: &hall, limit: 10, want: []string{"hall 1", "hall 2"}},
+		{name: "from is inclusive", from: ptr(day(1)), limit: 10, want: []string{"arena 1", "hall 2", "arena 2"}},
+		{name: "to is inclusive", to: ptr(day(1)), limit: 10, want: []string{"hall 1", "arena 1"}},
+		{name: "range", from: ptr(day(1)), to: ptr(day(2)), limit: 10, want: []string{"arena 1", "hall 2"}},
+		{name: "venue and from", venueID: &arena, from: ptr(day(2)), limit: 10, want: []string{"arena 2"}},
+		{name: "venue and range", venueID: &hall, from: ptr(day(1)), to: ptr(day(3)), limit: 10, want: []string{"hall 2"}},
+		{name: "no match", venueID: &arena, to: ptr(day(0)), limit: 10},
+		{name: "page", limit: 2, offset: 1, want: []string{"arena 1", "hall 2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.ListEventsFiltered(ctx, tt.venueID, tt.from, tt.to, tt.limit, tt.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var titles []string
+			for _, e := range got {
+				titles = append(titles, e.Title)
+			}
+			if !slices.Equal(titles, tt.want) {
+				t.Errorf("events = %v, want %v", titles, tt.want)
+			}
+		})
+	}
+}
+
+func TestListEventSeatsOnlyAvailable(t *testing.T) {
+	pool := pgtest.New(t)
+	repo := NewStore(pool).Query()
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		held      int
+		sold      int
+		available int
+	}{
+		{name: "sold out", sold: 4},
+		{name: "partly held", held: 2, sold: 1, available: 1},
+		{name: "available", available: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+			if _, err := pool.Exec(ctx,
+				`UPDATE event_seats SET status = 'held', hold_id = gen_random_uuid(),
+				        hold_expires_at = now() + interval '1 minute'
+				 WHERE event_id = $1 AND seat_id = ANY($2)`,
+				eventID, seatIDs[:tt.held],
+			); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := pool.Exec(ctx,
+				`UPDATE event_seats SET status = 'sold'
+				 WHERE event_id = $1 AND seat_id = ANY($2)`,
+				eventID, seatIDs[tt.held:tt.held+tt.sold],
+			); err != nil {
+				t.Fatal(err)
+			}
+
+			all, err := repo.ListEventSeats(ctx, eventID, false, 10, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(all) != len(seatIDs) {
+				t.Fatalf("unfiltered: got %d seats, want %d", len(all), len(seatIDs))
+			}
+
+			got, err := repo.ListEventSeats(ctx, eventID, true, 10, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.available {
+				t.Fatalf("filtered: got %d seats, want %d", len(got), tt.available)
+			}
+
+			byID := make(map[int64]domain.SeatWithStatus, len(all))
+			for _, s := range all {
+				byID[s.ID] = s
+			}
+			for _, s := range got {
+				if s.Status != domain.SeatAvailable {
+					t.Errorf("seat %d: status %q, want available", s.ID, s.Status)
+				}
+				if !reflect.DeepEqual(s, byID[s.ID]) {
+					t.Errorf("seat %d: filtered %+v, unfiltered %+v", s.ID, s, byID[s.ID])
+				}
+			}
+		})
+	}
+}
+
+func TestSeatStatuses(t *testing.T) {
+	pool := pgtest.New(t)
+	repo := NewStore(pool).Query()
+	ctx := context.Background()
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+	_, otherSeatIDs := pgtest.SeedEvent(t, pool, 1, 1, 0)
+
+	if _, err := pool.Exec(ctx,
+		`UPDATE event_seats SET status = 'held', hold_id = gen_random_uuid(),
+		        hold_expires_at = now() + interval '1 minute'
+		 WHERE event_id = $1 AND seat_id = $2`,
+		eventID, seatIDs[1],
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Exec(ctx,
+		`UPDATE event_seats SET status = 'sold' WHERE event_id = $1 AND seat_id = $2`,
+		eventID, seatIDs[2],
+	); err != nil {
+		t.Fatal(err)
+	}
+	// A hold that has lapsed but not been swept yet.
+	if _, err := pool.Exec(ctx,
+		`UPDATE event_seats SET status = 'held', hold_id = gen_random_uuid(),
+		        hold_expires_at = now() - interval '1 second'
+		 WHERE event_id = $1 AND seat_id = $2`,
+		eventID, seatIDs[3],
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	const missing = -1
+	got, err := repo.SeatStatuses(ctx, eventID, append(slices.Clone(seatIDs), missing, otherSeatIDs[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[int64]domain.SeatStatus{
+		seatIDs[0]: domain.SeatAvailable,
+		seatIDs[1]: domain.SeatHeld,
+		seatIDs[2]: domain.SeatSold,
+		seatIDs[3]: domain.SeatAvailable,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kirinyoku/tix-go/internal/domain"
 	"github.com/kirinyoku/tix-go/internal/repository"
 )
 
@@ -32,6 +34,8 @@ func (r *ReservationRepo) handle() DB {
 
 // HoldSeats holds seats for a user.
 //
+// It is HoldSeatsAtVersions without a version check.
+//
 // Parameters:
 //   - ctx: request-scoped context for cancellation and timeouts.
 //   - eventID: unique identifier of the event to retrieve.
@@ -41,7 +45,11 @@ func (r *ReservationRepo) handle() DB {
 //
 // Returns:
 //   - uuid.UUID: the hold ID when successful.
-//   - error: repository.ErrSeatsUnavailable if some seats are not available.
+//   - []int64: IDs of the held seats, in ascending order.
+//   - error: repository.ErrNotFound if the event does not exist.
+//   - error: repository.ErrEventCancelled if the event has been cancelled.
+//   - error: repository.SeatsUnavailableError, matching
+//     repository.ErrSeatsUnavailable, if some seats are not available.
 //   - error: repository.ErrConflict if there is a conflict creating the hold.
 func (r *ReservationRepo) HoldSeats(
 	ctx context.Context,
@@ -49,11 +57,178 @@ func (r *ReservationRepo) HoldSeats(
 	userID int64,
 	seatIDs []int64,
 	ttl time.Duration,
+) (uuid.UUID, []int64, error) {
+	return r.HoldSeatsAtVersions(ctx, eventID, userID, seatIDs, nil, ttl)
+}
+
+// HoldSeatsAtVersions holds seats for a user, but only if every seat is
+// still at the version the caller last read. Every status change of a seat
+// bumps its version, so a stale version means another transaction changed
+// the seat in between. This guards against lost updates even when the
+// transaction runs below serializable isolation.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event to retrieve.
+//   - userID: unique identifier of the user holding the seats.
+//   - seatIDs: list of seat IDs to hold.
+//   - versions: expected version of each seat, aligned with seatIDs; nil
+//     skips the check.
+//   - ttl: time-to-live for the hold.
+//
+// Returns:
+//   - uuid.UUID: the hold ID when successful.
+//   - []int64: IDs of the held seats, in ascending order.
+//   - error: repository.ErrNotFound if the event does not exist.
+//   - error: repository.ErrEventCancelled if the event has been cancelled.
+//   - error: repository.SeatsUnavailableError, matching
+//     repository.ErrSeatsUnavailable, if some seats are not available or not
+//     at the expected version.
+//   - error: repository.ErrConflict if there is a conflict creating the hold.
+func (r *ReservationRepo) HoldSeatsAtVersions(
+	ctx context.Context,
+	eventID int64,
+	userID int64,
+	seatIDs []int64,
+	versions []int64,
+	ttl time.Duration,
+) (uuid.UUID, []int64, error) {
+	const op = "postgres.ReservationRepo.HoldSeatsAtVersions"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if versions != nil && len(versions) != len(seatIDs) {
+		return uuid.Nil, nil, fmt.Errorf("%s: got %d versions for %d seats", op, len(versions), len(seatIDs))
+	}
+
+	holdID, held, _, err := r.holdSeatsTx(ctx, eventID, userID, seatIDs, versions, ttl, false)
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return holdID, held, nil
+}
+
+// HoldAvailableSeats holds whichever of the requested seats are available
+// and reports the rest instead of failing the whole hold. Seats that are not
+// at the expected version are reported like taken ones.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event to retrieve.
+//   - userID: unique identifier of the user holding the seats.
+//   - seatIDs: list of seat IDs to hold.
+//   - versions: expected version of each seat, aligned with seatIDs; nil
+//     skips the check.
+//   - ttl: time-to-live for the hold.
+//
+// Returns:
+//   - uuid.UUID: the hold ID when successful.
+//   - []int64: IDs of the held seats, in ascending order.
+//   - []int64: IDs of the requested seats that could not be held, in ascending order.
+//   - error: repository.ErrNotFound if the event does not exist.
+//   - error: repository.ErrEventCancelled if the event has been cancelled.
+//   - error: repository.SeatsUnavailableError, matching
+//     repository.ErrSeatsUnavailable, if none of the seats are available.
+//   - error: repository.ErrConflict if there is a conflict creating the hold.
+func (r *ReservationRepo) HoldAvailableSeats(
+	ctx context.Context,
+	eventID int64,
+	userID int64,
+	seatIDs []int64,
+	versions []int64,
+	ttl time.Duration,
+) (uuid.UUID, []int64, []int64, error) {
+	const op = "postgres.ReservationRepo.HoldAvailableSeats"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if versions != nil && len(versions) != len(seatIDs) {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s: got %d versions for %d seats", op, len(versions), len(seatIDs))
+	}
+
+	holdID, held, unavailable, err := r.holdSeatsTx(ctx, eventID, userID, seatIDs, versions, ttl, true)
+	if err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return holdID, held, unavailable, nil
+}
+
+// holdSeatsTx runs holdSeatsCore on the bound handle, or in its own
+// serializable transaction when none is bound.
+func (r *ReservationRepo) holdSeatsTx(
+	ctx context.Context,
+	eventID int64,
+	userID int64,
+	seatIDs []int64,
+	versions []int64,
+	ttl time.Duration,
+	partial bool,
+) (uuid.UUID, []int64, []int64, error) {
+	if r.db != nil {
+		id, held, unavailable, err := r.holdSeatsCore(ctx, r.db, eventID, userID, seatIDs, versions, ttl, partial)
+		if err != nil {
+			return uuid.Nil, nil, nil, translateDBErr(err)
+		}
+		return id, held, unavailable, nil
+	}
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.Serializable,
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return uuid.Nil, nil, nil, translateDBErr(err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	holdID, held, unavailable, err := r.holdSeatsCore(ctx, tx, eventID, userID, seatIDs, versions, ttl, partial)
+	if err != nil {
+		return uuid.Nil, nil, nil, translateDBErr(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.Nil, nil, nil, translateDBErr(err)
+	}
+
+	return holdID, held, unavailable, nil
+}
+
+// HoldGA holds general-admission tickets of an event for a user. Expired
+// general-admission holds of the event are released first; the event's held
+// counter is then raised in a single conditional update, so concurrent holds
+// can never take more than the remaining capacity.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//   - userID: unique identifier of the user holding the tickets.
+//   - qty: number of tickets to hold.
+//   - ttl: time-to-live for the hold.
+//
+// Returns:
+//   - uuid.UUID: the hold ID when successful.
+//   - error: repository.ErrNotFound if the event does not exist.
+//   - error: repository.ErrEventCancelled if the event has been cancelled.
+//   - error: repository.ErrGAUnavailable if fewer than qty tickets are left.
+func (r *ReservationRepo) HoldGA(
+	ctx context.Context,
+	eventID int64,
+	userID int64,
+	qty int,
+	ttl time.Duration,
 ) (uuid.UUID, error) {
-	const op = "postgres.ReservationRepo.HoldSeats"
+	const op = "postgres.ReservationRepo.HoldGA"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
 	if r.db != nil {
-		id, err := r.holdSeatsCore(ctx, r.db, eventID, userID, seatIDs, ttl)
+		id, err := r.holdGACore(ctx, r.db, eventID, userID, qty, ttl)
 		if err != nil {
 			return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 		}
@@ -70,7 +245,7 @@ func (r *ReservationRepo) HoldSeats(
 
 	defer tx.Rollback(ctx)
 
-	holdID, err := r.holdSeatsCore(ctx, tx, eventID, userID, seatIDs, ttl)
+	holdID, err := r.holdGACore(ctx, tx, eventID, userID, qty, ttl)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
@@ -82,23 +257,26 @@ func (r *ReservationRepo) HoldSeats(
 	return holdID, nil
 }
 
-// ConfirmHold confirms a hold and creates an order.
+// ConfirmHold confirms a hold and creates an order. The order total is the
+// sum of the held seats' price_cents, computed server-side.
 //
 // Parameters:
 //   - ctx: request-scoped context for cancellation and timeouts.
 //   - holdID: unique identifier of the hold to confirm.
-//   - totalCents: total amount in cents to charge for the order.
 //
 // Returns:
 //   - uuid.UUID: the order ID when successful.
 //   - error: repository.ErrHoldExpired if the hold is expired.
 //   - error: repository.ErrNothingToConfirm if there are no seats to confirm.
 //   - error: repository.ConflictError if there is a conflict creating the order or tickets.
-func (r *ReservationRepo) ConfirmHold(ctx context.Context, holdID uuid.UUID, totalCents int) (uuid.UUID, error) {
+func (r *ReservationRepo) ConfirmHold(ctx context.Context, holdID uuid.UUID) (uuid.UUID, error) {
 	const op = "postgres.ReservationRepo.ConfirmHold"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	if r.db != nil {
-		id, err := r.confirmHoldCore(ctx, r.db, holdID, totalCents)
+		id, err := r.confirmHoldCore(ctx, r.db, holdID)
 		if err != nil {
 			return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 		}
@@ -115,7 +293,7 @@ func (r *ReservationRepo) ConfirmHold(ctx context.Context, holdID uuid.UUID, tot
 
 	defer tx.Rollback(ctx)
 
-	orderID, err := r.confirmHoldCore(ctx, tx, holdID, totalCents)
+	orderID, err := r.confirmHoldCore(ctx, tx, holdID)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
@@ -138,6 +316,9 @@ func (r *ReservationRepo) ConfirmHold(ctx context.Context, holdID uuid.UUID, tot
 func (r *ReservationRepo) CancelHold(ctx context.Context, holdID uuid.UUID) error {
 	const op = "postgres.ReservationRepo.CancelHold"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	if r.db != nil {
 		if err := r.cancelHoldCore(ctx, r.db, holdID); err != nil {
 			return fmt.Errorf("%s:%w", op, translateDBErr(err))
@@ -166,85 +347,603 @@ func (r *ReservationRepo) CancelHold(ctx context.Context, holdID uuid.UUID) erro
 	return nil
 }
 
-// ExpireHolds expires old holds.
+// ExtendHold pushes the expiry of an active hold and of all its held seats
+// forward by extra. The new expiry never exceeds maxTTL measured from the
+// moment the hold was created.
 //
 // Parameters:
 //   - ctx: request-scoped context for cancellation and timeouts.
+//   - holdID: unique identifier of the hold to extend.
+//   - extra: duration to add to the current expiry.
+//   - maxTTL: upper bound for the total hold lifetime.
 //
 // Returns:
-//   - int64: the number of expired holds.
-//   - error: if any error occurs while expiring holds.
-func (r *ReservationRepo) ExpireHolds(ctx context.Context) (int64, error) {
-	const op = "postgres.ReservationRepo.ExpireHolds"
+//   - int64: ID of the event the hold belongs to.
+//   - time.Time: the new expiry of the hold.
+//   - error: repository.ErrNotFound if the hold does not exist.
+//   - error: repository.ErrHoldExpired if the hold has already expired.
+func (r *ReservationRepo) ExtendHold(
+	ctx context.Context,
+	holdID uuid.UUID,
+	extra, maxTTL time.Duration,
+) (int64, time.Time, error) {
+	const op = "postgres.ReservationRepo.ExtendHold"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if r.db != nil {
+		eventID, expires, err := r.extendHoldCore(ctx, r.db, holdID, extra, maxTTL)
+		if err != nil {
+			return 0, time.Time{}, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+		return eventID, expires, nil
+	}
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.Serializable,
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer tx.Rollback(ctx)
+
+	eventID, expires, err := r.extendHoldCore(ctx, tx, holdID, extra, maxTTL)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, time.Time{}, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return eventID, expires, nil
+}
+
+// GetHold retrieves an active hold together with the IDs of its held seats.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - holdID: unique identifier of the hold to retrieve.
+//
+// Returns:
+//   - *domain.Hold: the hold when found.
+//   - error: repository.ErrNotFound if the hold does not exist or has expired.
+func (r *ReservationRepo) GetHold(ctx context.Context, holdID uuid.UUID) (*domain.Hold, error) {
+	const op = "postgres.ReservationRepo.GetHold"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
 	db := r.handle()
 
-	var released int64
-	tag, err := db.Exec(ctx,
-		`UPDATE event_seats
-         SET status = 'available', hold_id = NULL, hold_expires_at = NULL
-      	 WHERE status = 'held' AND hold_expires_at <= now()`,
+	h, err := activeHold(ctx, db, holdID)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	rows, err := db.Query(ctx,
+		`SELECT seat_id
+       	 FROM event_seats
+      	 WHERE hold_id = $1
+      	 ORDER BY seat_id`,
+		holdID,
 	)
 	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var sid int64
+		if err := rows.Scan(&sid); err != nil {
+			return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+		h.SeatIDs = append(h.SeatIDs, sid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return h, nil
+}
+
+// activeHold loads an unexpired hold without its seat IDs. It returns
+// pgx.ErrNoRows, untranslated, when the hold is missing or expired.
+func activeHold(ctx context.Context, db DB, holdID uuid.UUID) (*domain.Hold, error) {
+	h := domain.Hold{ID: holdID}
+	if err := db.QueryRow(ctx,
+		`SELECT event_id, user_id, created_at, expires_at, ga_qty
+       	 FROM holds
+      	 WHERE id = $1 AND expires_at > now()`,
+		holdID,
+	).Scan(&h.EventID, &h.UserID, &h.CreatedAt, &h.ExpiresAt, &h.GAQty); err != nil {
+		return nil, err
+	}
+
+	return &h, nil
+}
+
+// CountUserSeats counts the seats a user currently holds or has bought for
+// an event, general-admission tickets included. Expired holds and refunded
+// orders are not counted.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//   - userID: unique identifier of the user.
+//
+// Returns:
+//   - int64: number of held and sold seats.
+//   - error: if the query fails.
+func (r *ReservationRepo) CountUserSeats(ctx context.Context, eventID, userID int64) (int64, error) {
+	const op = "postgres.ReservationRepo.CountUserSeats"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	db := r.handle()
+
+	var n int64
+	if err := db.QueryRow(ctx,
+		`SELECT
+		 	(SELECT count(*)
+		 	 FROM event_seats es
+		 	 JOIN holds h ON h.id = es.hold_id
+		 	 WHERE es.event_id = $1
+		 	   AND es.status = 'held'
+		 	   AND es.hold_expires_at > now()
+		 	   AND h.user_id = $2)
+		 	+
+		 	(SELECT count(*)
+		 	 FROM tickets t
+		 	 JOIN orders o ON o.id = t.order_id
+		 	 WHERE t.event_id = $1
+		 	   AND o.user_id = $2
+		 	   AND o.status = 'confirmed')
+		 	+
+		 	(SELECT coalesce(sum(ga_qty), 0)
+		 	 FROM holds
+		 	 WHERE event_id = $1 AND user_id = $2 AND expires_at > now())`,
+		eventID, userID,
+	).Scan(&n); err != nil {
 		return 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
-	released += tag.RowsAffected()
+	return n, nil
+}
+
+// ExpireHolds expires old holds.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//
+// Returns:
+//   - int64: the number of released seats.
+//   - []int64: IDs of the events that had seats released.
+//   - error: if any error occurs while expiring holds.
+func (r *ReservationRepo) ExpireHolds(ctx context.Context) (int64, []int64, error) {
+	const op = "postgres.ReservationRepo.ExpireHolds"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	released, eventIDs, err := expireHolds(ctx, r.handle(), nil)
+	if err != nil {
+		return released, eventIDs, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return released, eventIDs, nil
+}
+
+// ExpireHoldsForEvent expires old holds of a single event.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: ID of the event whose holds are expired.
+//
+// Returns:
+//   - int64: the number of released seats.
+//   - error: if any error occurs while expiring holds.
+func (r *ReservationRepo) ExpireHoldsForEvent(ctx context.Context, eventID int64) (int64, error) {
+	const op = "postgres.ReservationRepo.ExpireHoldsForEvent"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
-	_, err = db.Exec(ctx, `DELETE FROM holds WHERE expires_at <= now()`)
+	released, _, err := expireHolds(ctx, r.handle(), &eventID)
 	if err != nil {
-		return released, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		return released, fmt.Errorf("%s:%w", op, err)
 	}
 
 	return released, nil
 }
 
+// expireHolds releases seats and general-admission tickets of expired holds,
+// deletes those holds and logs them, all in one statement. A nil eventID
+// expires holds of every event. Only released seats are counted.
+func expireHolds(ctx context.Context, db DB, eventID *int64) (int64, []int64, error) {
+	// Every part of the statement sees the same snapshot, so the logged seat
+	// counts are taken before the seats are released.
+	rows, err := db.Query(ctx,
+		`WITH expired AS (
+		 	DELETE FROM holds
+		 	WHERE expires_at <= now() AND ($1::bigint IS NULL OR event_id = $1)
+		 	RETURNING id, event_id, user_id, ga_qty
+		 ), logged AS (
+		 	INSERT INTO event_log(event_id, hold_id, user_id, kind, seat_count)
+		 	SELECT e.event_id, e.id, e.user_id, 'hold_expired',
+		 	       e.ga_qty + (SELECT count(*) FROM event_seats es
+		 	                   WHERE es.hold_id = e.id AND es.status = 'held')
+		 	FROM expired e
+		 ), ga_released AS (
+		 	UPDATE events ev
+		 	SET ga_held = ev.ga_held - x.qty
+		 	FROM (SELECT event_id, sum(ga_qty) AS qty
+		 	      FROM expired
+		 	      WHERE ga_qty > 0
+		 	      GROUP BY event_id) x
+		 	WHERE ev.id = x.event_id
+		 ), released AS (
+		 	UPDATE event_seats
+		 	SET status = 'available', hold_id = NULL, hold_expires_at = NULL,
+		 	    version = version + 1
+		 	WHERE status = 'held' AND hold_expires_at <= now()
+		 	  AND ($1::bigint IS NULL OR event_id = $1)
+		 	RETURNING event_id
+		 )
+		 SELECT event_id, count(*) FROM released GROUP BY event_id`,
+		eventID,
+	)
+	if err != nil {
+		return 0, nil, translateDBErr(err)
+	}
+
+	defer rows.Close()
+
+	var released int64
+	var eventIDs []int64
+	for rows.Next() {
+		var id, n int64
+		if err := rows.Scan(&id, &n); err != nil {
+			return 0, nil, translateDBErr(err)
+		}
+		released += n
+		eventIDs = append(eventIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, nil, translateDBErr(err)
+	}
+
+	return released, eventIDs, nil
+}
+
+// JoinWaitlist adds a user to the waitlist of an event. Joining is
+// idempotent: if the user is already queued for the event, the existing
+// entry is returned unchanged and keeps its position. A user who was already
+// notified is queued again at the back with the new seat count.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//   - userID: unique identifier of the user.
+//   - seatCount: number of seats the user is waiting for.
+//
+// Returns:
+//   - *domain.WaitlistEntry: the new or existing entry.
+//   - error: repository.ErrNotFound if the event does not exist.
+func (r *ReservationRepo) JoinWaitlist(
+	ctx context.Context,
+	eventID int64,
+	userID int64,
+	seatCount int,
+) (*domain.WaitlistEntry, error) {
+	const op = "postgres.ReservationRepo.JoinWaitlist"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	db := r.handle()
+
+	if _, err := db.Exec(ctx,
+		`INSERT INTO waitlist(event_id, user_id, seat_count)
+       	 SELECT $1, $2, $3
+       	 WHERE EXISTS (SELECT 1 FROM events WHERE id = $1)
+       	 ON CONFLICT (event_id, user_id) DO UPDATE
+         SET seat_count = EXCLUDED.seat_count, created_at = now(), notified_at = NULL
+         WHERE waitlist.notified_at IS NOT NULL`,
+		eventID, userID, seatCount,
+	); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	var e domain.WaitlistEntry
+	if err := db.QueryRow(ctx,
+		`SELECT id, event_id, user_id, seat_count, created_at, notified_at
+       	 FROM waitlist
+      	 WHERE event_id = $1 AND user_id = $2`,
+		eventID, userID,
+	).Scan(&e.ID, &e.EventID, &e.UserID, &e.SeatCount, &e.CreatedAt, &e.NotifiedAt); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return &e, nil
+}
+
+// NotifyNextWaitlisted marks the earliest pending waitlist entry of an event
+// as notified, provided its requested seat count fits into available.
+// Entries are served strictly in FIFO order; a head entry that does not fit
+// blocks later ones.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - eventID: unique identifier of the event.
+//   - available: number of seats currently available.
+//
+// Returns:
+//   - *domain.WaitlistEntry: the notified entry.
+//   - error: repository.ErrNotFound if no entry can be notified.
+func (r *ReservationRepo) NotifyNextWaitlisted(
+	ctx context.Context,
+	eventID int64,
+	available int64,
+) (*domain.WaitlistEntry, error) {
+	const op = "postgres.ReservationRepo.NotifyNextWaitlisted"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	db := r.handle()
+
+	var e domain.WaitlistEntry
+	if err := db.QueryRow(ctx,
+		`WITH head AS (
+       	 	SELECT id, seat_count
+       	 	FROM waitlist
+      	 	WHERE event_id = $1 AND notified_at IS NULL
+      	 	ORDER BY created_at, id
+      	 	LIMIT 1
+      	 	FOR UPDATE SKIP LOCKED
+       	 )
+       	 UPDATE waitlist w
+         SET notified_at = now()
+         FROM head
+      	 WHERE w.id = head.id AND head.seat_count <= $2
+      	 RETURNING w.id, w.event_id, w.user_id, w.seat_count, w.created_at, w.notified_at`,
+		eventID, available,
+	).Scan(&e.ID, &e.EventID, &e.UserID, &e.SeatCount, &e.CreatedAt, &e.NotifiedAt); err != nil {
+		return nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return &e, nil
+}
+
+// ResetWaitlistNotification returns a notified waitlist entry to the queue
+// at its original position, for when the notification could not be
+// delivered.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - id: unique identifier of the waitlist entry.
+//
+// Returns:
+//   - error: repository.ErrNotFound if the entry does not exist.
+func (r *ReservationRepo) ResetWaitlistNotification(ctx context.Context, id int64) error {
+	const op = "postgres.ReservationRepo.ResetWaitlistNotification"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	tag, err := r.handle().Exec(ctx,
+		`UPDATE waitlist SET notified_at = NULL WHERE id = $1`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s:%w", op, repository.ErrNotFound)
+	}
+
+	return nil
+}
+
+// holdSeatsCore creates a hold and moves the available requested seats to
+// it. Unless partial is set, any seat that cannot be held fails the whole
+// hold. It returns the held seat IDs and the requested seat IDs left out.
 func (r *ReservationRepo) holdSeatsCore(
 	ctx context.Context,
 	db DB,
 	eventID int64,
 	userID int64,
 	seatIDs []int64,
+	versions []int64,
 	ttl time.Duration,
-) (uuid.UUID, error) {
+	partial bool,
+) (uuid.UUID, []int64, []int64, error) {
 	const op = "postgres.ReservationRepo.holdSeatsCore"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	holdID := uuid.New()
 	expires := time.Now().Add(ttl)
 
-	if _, err := db.Exec(ctx,
-		`UPDATE event_seats
-        	SET status = 'available', hold_id = NULL, hold_expires_at = NULL
-      	 WHERE event_id = $1
-        	AND status = 'held'
-        	AND hold_expires_at <= now()`,
+	var cancelled bool
+	if err := db.QueryRow(ctx,
+		`SELECT cancelled_at IS NOT NULL FROM events WHERE id = $1`,
 		eventID,
-	); err != nil {
-		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	).Scan(&cancelled); err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if cancelled {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, repository.ErrEventCancelled)
+	}
+
+	// Expired holds of the event are released, logged and deleted here as
+	// the sweeper would, so their seats can be taken before it runs.
+	if _, _, err := expireHolds(ctx, db, &eventID); err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, err)
 	}
 
 	if _, err := db.Exec(ctx,
 		`INSERT INTO holds(id, event_id, user_id, expires_at)
        	 VALUES ($1, $2, $3, $4)`,
 		holdID, eventID, userID, expires,
+	); err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	var rows pgx.Rows
+	var err error
+
+	if versions == nil {
+		rows, err = db.Query(ctx,
+			`UPDATE event_seats
+        	 SET status = 'held', hold_id = $3, hold_expires_at = $4,
+        	     version = version + 1
+      	 	 WHERE event_id = $1
+        	   AND seat_id = ANY($2)
+        	   AND status = 'available'
+        	 RETURNING seat_id`,
+			eventID, seatIDs, holdID, expires,
+		)
+	} else {
+		rows, err = db.Query(ctx,
+			`UPDATE event_seats es
+        	 SET status = 'held', hold_id = $3, hold_expires_at = $4,
+        	     version = es.version + 1
+        	 FROM unnest($2::bigint[], $5::bigint[]) AS v(seat_id, version)
+      	 	 WHERE es.event_id = $1
+        	   AND es.seat_id = v.seat_id
+        	   AND es.version = v.version
+        	   AND es.status = 'available'
+        	 RETURNING es.seat_id`,
+			eventID, seatIDs, holdID, expires, versions,
+		)
+	}
+	if err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	held, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	slices.Sort(held)
+
+	// Each distinct seat is updated once, so duplicates in seatIDs must not
+	// count towards the seats expected to be held.
+	requested := slices.Clone(seatIDs)
+	slices.Sort(requested)
+	requested = slices.Compact(requested)
+
+	var unavailable []int64
+	for _, id := range requested {
+		if _, ok := slices.BinarySearch(held, id); !ok {
+			unavailable = append(unavailable, id)
+		}
+	}
+
+	if len(held) == 0 || (!partial && len(held) != len(requested)) {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, repository.SeatsUnavailableError{SeatIDs: unavailable})
+	}
+
+	if err := appendEventLog(ctx, db, domain.EventLogEntry{
+		EventID:   eventID,
+		HoldID:    holdID,
+		UserID:    userID,
+		Kind:      domain.LogHoldCreated,
+		SeatCount: len(held),
+	}); err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return holdID, held, unavailable, nil
+}
+
+func (r *ReservationRepo) holdGACore(
+	ctx context.Context,
+	db DB,
+	eventID int64,
+	userID int64,
+	qty int,
+	ttl time.Duration,
+) (uuid.UUID, error) {
+	const op = "postgres.ReservationRepo.holdGACore"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	var cancelled bool
+	if err := db.QueryRow(ctx,
+		`SELECT cancelled_at IS NOT NULL FROM events WHERE id = $1`,
+		eventID,
+	).Scan(&cancelled); err != nil {
+		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if cancelled {
+		return uuid.Nil, fmt.Errorf("%s:%w", op, repository.ErrEventCancelled)
+	}
+
+	// Expired holds still count in ga_held until the sweeper runs, so they
+	// are released here first, the way holdSeatsCore releases expired seats.
+	// On failure the whole transaction rolls back, release included.
+	if _, err := db.Exec(ctx,
+		`WITH expired AS (
+		 	DELETE FROM holds
+		 	WHERE event_id = $1 AND ga_qty > 0 AND expires_at <= now()
+		 	RETURNING id, user_id, ga_qty
+		 ), logged AS (
+		 	INSERT INTO event_log(event_id, hold_id, user_id, kind, seat_count)
+		 	SELECT $1, id, user_id, 'hold_expired', ga_qty FROM expired
+		 )
+		 UPDATE events
+		 SET ga_held = ga_held - (SELECT sum(ga_qty) FROM expired)
+		 WHERE id = $1 AND EXISTS (SELECT 1 FROM expired)`,
+		eventID,
 	); err != nil {
 		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
 	tag, err := db.Exec(ctx,
-		`UPDATE event_seats
-        	SET status = 'held', hold_id = $3, hold_expires_at = $4
-      	 WHERE event_id = $1
-        	AND seat_id = ANY($2)
-        	AND status = 'available'`,
-		eventID, seatIDs, holdID, expires,
+		`UPDATE events
+		 SET ga_held = ga_held + $2
+		 WHERE id = $1 AND ga_capacity - ga_held - ga_sold >= $2`,
+		eventID, qty,
 	)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
-	if int(tag.RowsAffected()) != len(seatIDs) {
-		return uuid.Nil, fmt.Errorf("%s:%w", op, repository.ErrSeatsUnavailable)
+	if tag.RowsAffected() == 0 {
+		return uuid.Nil, fmt.Errorf("%s:%w", op, repository.ErrGAUnavailable)
+	}
+
+	holdID := uuid.New()
+	if _, err := db.Exec(ctx,
+		`INSERT INTO holds(id, event_id, user_id, expires_at, ga_qty)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		holdID, eventID, userID, time.Now().Add(ttl), qty,
+	); err != nil {
+		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if err := appendEventLog(ctx, db, domain.EventLogEntry{
+		EventID:   eventID,
+		HoldID:    holdID,
+		UserID:    userID,
+		Kind:      domain.LogHoldCreated,
+		SeatCount: qty,
+	}); err != nil {
+		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
 	return holdID, nil
@@ -254,19 +953,14 @@ func (r *ReservationRepo) confirmHoldCore(
 	ctx context.Context,
 	db DB,
 	holdID uuid.UUID,
-	totalCents int,
 ) (uuid.UUID, error) {
 	const op = "postgres.ReservationRepo.confirmHoldCore"
 
-	var eventID int64
-	var userID int64
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
-	if err := db.QueryRow(ctx,
-		`SELECT event_id, user_id
-       	 FROM holds
-      	 WHERE id = $1 AND expires_at > now()`,
-		holdID,
-	).Scan(&eventID, &userID); err != nil {
+	hold, err := activeHold(ctx, db, holdID)
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return uuid.Nil, fmt.Errorf("%s:%w", op, repository.ErrHoldExpired)
 		}
@@ -275,9 +969,10 @@ func (r *ReservationRepo) confirmHoldCore(
 
 	rows, err := db.Query(ctx,
 		`UPDATE event_seats
-         SET status = 'sold', hold_id = NULL, hold_expires_at = NULL
+         SET status = 'sold', hold_id = NULL, hold_expires_at = NULL,
+             version = version + 1
       	 WHERE hold_id = $1
-      	 RETURNING seat_id`,
+      	 RETURNING seat_id, price_cents`,
 		holdID,
 	)
 	if err != nil {
@@ -286,54 +981,160 @@ func (r *ReservationRepo) confirmHoldCore(
 
 	defer rows.Close()
 
-	var seatIDs []int64
+	var totalCents int
 	for rows.Next() {
 		var sid int64
-		if err := rows.Scan(&sid); err != nil {
+		var price int
+		if err := rows.Scan(&sid, &price); err != nil {
 			return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 		}
-		seatIDs = append(seatIDs, sid)
+		hold.SeatIDs = append(hold.SeatIDs, sid)
+		totalCents += price
 	}
 	if err := rows.Err(); err != nil {
 		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
-	if len(seatIDs) == 0 {
+	if len(hold.SeatIDs) == 0 && hold.GAQty == 0 {
 		return uuid.Nil, fmt.Errorf("%s:%w", op, repository.ErrNothingToConfirm)
 	}
 
+	if hold.GAQty > 0 {
+		var gaPrice int
+		if err := db.QueryRow(ctx,
+			`UPDATE events
+			 SET ga_held = ga_held - $2, ga_sold = ga_sold + $2
+			 WHERE id = $1
+			 RETURNING ga_price_cents`,
+			hold.EventID, hold.GAQty,
+		).Scan(&gaPrice); err != nil {
+			return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+		totalCents += gaPrice * hold.GAQty
+	}
+
 	orderID := uuid.New()
 	if _, err := db.Exec(ctx,
-		`INSERT INTO orders(id, event_id, user_id, total_cents)
-       	 VALUES ($1, $2, $3, $4)`,
-		orderID, eventID, userID, totalCents,
+		`INSERT INTO orders(id, event_id, user_id, total_cents, ga_qty, hold_id)
+       	 VALUES ($1, $2, $3, $4, $5, $6)`,
+		orderID, hold.EventID, hold.UserID, totalCents, hold.GAQty, holdID,
 	); err != nil {
 		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
 	batch := &pgx.Batch{}
-	for _, sid := range seatIDs {
+	for _, sid := range hold.SeatIDs {
 		batch.Queue(
 			`INSERT INTO tickets(id, order_id, event_id, seat_id)
          	 VALUES ($1, $2, $3, $4)`,
-			uuid.New(), orderID, eventID, sid,
+			uuid.New(), orderID, hold.EventID, sid,
+		)
+	}
+	// General-admission tickets carry no seat.
+	for range hold.GAQty {
+		batch.Queue(
+			`INSERT INTO tickets(id, order_id, event_id)
+         	 VALUES ($1, $2, $3)`,
+			uuid.New(), orderID, hold.EventID,
 		)
 	}
 	if err := db.SendBatch(ctx, batch).Close(); err != nil {
 		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
+	if err := appendEventLog(ctx, db, domain.EventLogEntry{
+		EventID:   hold.EventID,
+		HoldID:    holdID,
+		UserID:    hold.UserID,
+		Kind:      domain.LogHoldConfirmed,
+		SeatCount: len(hold.SeatIDs) + hold.GAQty,
+		OrderID:   &orderID,
+	}); err != nil {
+		return uuid.Nil, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
 	_, _ = db.Exec(ctx, `DELETE FROM holds WHERE id = $1`, holdID)
 
 	return orderID, nil
 }
 
+func (r *ReservationRepo) extendHoldCore(
+	ctx context.Context,
+	db DB,
+	holdID uuid.UUID,
+	extra, maxTTL time.Duration,
+) (int64, time.Time, error) {
+	const op = "postgres.ReservationRepo.extendHoldCore"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	var active bool
+	var expires time.Time
+
+	if err := db.QueryRow(ctx,
+		`SELECT expires_at > now(),
+		        LEAST(expires_at + $2 * interval '1 microsecond',
+		              created_at + $3 * interval '1 microsecond')
+		 FROM holds
+		 WHERE id = $1
+		 FOR UPDATE`,
+		holdID, extra.Microseconds(), maxTTL.Microseconds(),
+	).Scan(&active, &expires); err != nil {
+		return 0, time.Time{}, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if !active {
+		return 0, time.Time{}, fmt.Errorf("%s:%w", op, repository.ErrHoldExpired)
+	}
+
+	var (
+		eventID, userID int64
+		gaQty           int
+	)
+	if err := db.QueryRow(ctx,
+		`UPDATE holds
+		 SET expires_at = GREATEST(expires_at, $2)
+		 WHERE id = $1
+		 RETURNING expires_at, event_id, user_id, ga_qty`,
+		holdID, expires,
+	).Scan(&expires, &eventID, &userID, &gaQty); err != nil {
+		return 0, time.Time{}, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	tag, err := db.Exec(ctx,
+		`UPDATE event_seats
+		 SET hold_expires_at = $2
+		 WHERE hold_id = $1 AND status = 'held'`,
+		holdID, expires,
+	)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if err := appendEventLog(ctx, db, domain.EventLogEntry{
+		EventID:   eventID,
+		HoldID:    holdID,
+		UserID:    userID,
+		Kind:      domain.LogHoldExtended,
+		SeatCount: int(tag.RowsAffected()) + gaQty,
+	}); err != nil {
+		return 0, time.Time{}, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return eventID, expires, nil
+}
+
 func (r *ReservationRepo) cancelHoldCore(ctx context.Context, db DB, holdID uuid.UUID) error {
 	const op = "postgres.ReservationRepo.cancelHoldCore"
 
-	_, err := db.Exec(ctx,
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	tag, err := db.Exec(ctx,
 		`UPDATE event_seats
-         SET status = 'available', hold_id = NULL, hold_expires_at = NULL
+         SET status = 'available', hold_id = NULL, hold_expires_at = NULL,
+             version = version + 1
       	 WHERE hold_id = $1`,
 		holdID,
 	)
@@ -341,14 +1142,156 @@ func (r *ReservationRepo) cancelHoldCore(ctx context.Context, db DB, holdID uuid
 		return fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
-	ct, err := db.Exec(ctx, `DELETE FROM holds WHERE id = $1`, holdID)
-	if err != nil {
+	var (
+		eventID, userID int64
+		gaQty           int
+	)
+	if err := db.QueryRow(ctx,
+		`DELETE FROM holds WHERE id = $1 RETURNING event_id, user_id, ga_qty`,
+		holdID,
+	).Scan(&eventID, &userID, &gaQty); err != nil {
 		return fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
-	if ct.RowsAffected() == 0 {
-		return fmt.Errorf("%s:%w", op, repository.ErrNotFound)
+	if gaQty > 0 {
+		if _, err := db.Exec(ctx,
+			`UPDATE events SET ga_held = ga_held - $2 WHERE id = $1`,
+			eventID, gaQty,
+		); err != nil {
+			return fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+	}
+
+	if err := appendEventLog(ctx, db, domain.EventLogEntry{
+		EventID:   eventID,
+		HoldID:    holdID,
+		UserID:    userID,
+		Kind:      domain.LogHoldCancelled,
+		SeatCount: int(tag.RowsAffected()) + gaQty,
+	}); err != nil {
+		return fmt.Errorf("%s:%w", op, translateDBErr(err))
 	}
 
 	return nil
 }
+
+// RefundOrder refunds a confirmed order: its seats become available again,
+// its tickets are deleted and the order is marked refunded.
+//
+// Parameters:
+//   - ctx: request-scoped context for cancellation and timeouts.
+//   - orderID: unique identifier of the order to refund.
+//
+// Returns:
+//   - int64: ID of the event the order belongs to.
+//   - int64: number of seats released.
+//   - error: repository.ErrNotFound if the order is not found.
+//   - error: repository.ErrAlreadyRefunded if the order was refunded before.
+func (r *ReservationRepo) RefundOrder(ctx context.Context, orderID uuid.UUID) (int64, int64, error) {
+	const op = "postgres.ReservationRepo.RefundOrder"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if r.db != nil {
+		eventID, released, err := r.refundOrderCore(ctx, r.db, orderID)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+		return eventID, released, nil
+	}
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.Serializable,
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	defer tx.Rollback(ctx)
+
+	eventID, released, err := r.refundOrderCore(ctx, tx, orderID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return eventID, released, nil
+}
+
+func (r *ReservationRepo) refundOrderCore(ctx context.Context, db DB, orderID uuid.UUID) (int64, int64, error) {
+	const op = "postgres.ReservationRepo.refundOrderCore"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	var (
+		eventID  int64
+		gaQty    int
+		refunded bool
+	)
+	if err := db.QueryRow(ctx,
+		`SELECT event_id, ga_qty, refunded_at IS NOT NULL
+		 FROM orders
+		 WHERE id = $1
+		 FOR UPDATE`,
+		orderID,
+	).Scan(&eventID, &gaQty, &refunded); err != nil {
+		return 0, 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if refunded {
+		return 0, 0, fmt.Errorf("%s:%w", op, repository.ErrAlreadyRefunded)
+	}
+
+	tag, err := db.Exec(ctx,
+		`UPDATE event_seats
+		 SET status = 'available', hold_id = NULL, hold_expires_at = NULL,
+		     version = version + 1
+		 WHERE event_id = $1
+		   AND status = 'sold'
+		   AND seat_id IN (SELECT seat_id FROM tickets WHERE order_id = $2)`,
+		eventID, orderID,
+	)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if _, err := db.Exec(ctx, `DELETE FROM tickets WHERE order_id = $1`, orderID); err != nil {
+		return 0, 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	if gaQty > 0 {
+		if _, err := db.Exec(ctx,
+			`UPDATE events SET ga_sold = ga_sold - $2 WHERE id = $1`,
+			eventID, gaQty,
+		); err != nil {
+			return 0, 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+		}
+	}
+
+	if _, err := db.Exec(ctx,
+		`UPDATE orders SET status = 'refunded', refunded_at = now() WHERE id = $1`,
+		orderID,
+	); err != nil {
+		return 0, 0, fmt.Errorf("%s:%w", op, translateDBErr(err))
+	}
+
+	return eventID, tag.RowsAffected(), nil
+}
+
+// appendEventLog writes e to the event log on db, so the entry commits or
+// rolls back together with the change it records.
+func appendEventLog(ctx context.Context, db DB, e domain.EventLogEntry) error {
+	_, err := db.Exec(ctx,
+		`INSERT INTO event_log(event_id, hold_id, user_id, kind, seat_count, order_id)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		e.EventID, e.HoldID, e.UserID, e.Kind, e.SeatCount, e.OrderID,
+	)
+
+	return err
+}
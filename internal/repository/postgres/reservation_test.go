@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/postgres/pgtest"
+	"github.com/kirinyoku/tix-go/internal/repository"
+)
+
+func TestConfirmHoldTotal(t *testing.T) {
+	pool := pgtest.New(t)
+	repo := NewStore(pool).Reservations()
+	ctx := context.Background()
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+
+	prices := []int{1000, 1500, 2500, 9900}
+	for i, seatID := range seatIDs {
+		if _, err := pool.Exec(ctx,
+			`UPDATE event_seats SET price_cents = $3 WHERE event_id = $1 AND seat_id = $2`,
+			eventID, seatID, prices[i],
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The last, most expensive seat is not held and must not be charged.
+	holdID, _, err := repo.HoldSeats(ctx, eventID, 1, seatIDs[:3], time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderID, err := repo.ConfirmHold(ctx, holdID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	if err := pool.QueryRow(ctx, `SELECT total_cents FROM orders WHERE id = $1`, orderID).Scan(&total); err != nil {
+		t.Fatal(err)
+	}
+	if want := prices[0] + prices[1] + prices[2]; total != want {
+		t.Errorf("total_cents = %d, want %d", total, want)
+	}
+
+	o, err := NewStore(pool).Query().GetOrderWithTickets(ctx, orderID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Order.Status != domain.OrderConfirmed {
+		t.Errorf("Status = %q, want %q", o.Order.Status, domain.OrderConfirmed)
+	}
+	if o.Order.CreatedAt.IsZero() || o.Order.RefundedAt != nil {
+		t.Errorf("CreatedAt = %v, RefundedAt = %v, want a creation time and no refund", o.Order.CreatedAt, o.Order.RefundedAt)
+	}
+	if len(o.Tickets) != 3 {
+		t.Errorf("tickets = %d, want 3", len(o.Tickets))
+	}
+}
+
+func TestGetHold(t *testing.T) {
+	pool := pgtest.New(t)
+	repo := NewStore(pool).Reservations()
+	ctx := context.Background()
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+
+	holdID, _, err := repo.HoldSeats(ctx, eventID, 7, []int64{seatIDs[2], seatIDs[0]}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := repo.GetHold(ctx, holdID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.ID != holdID || h.EventID != eventID || h.UserID != 7 || h.GAQty != 0 {
+		t.Errorf("hold = %+v, want ID %s, event %d, user 7, no GA tickets", h, holdID, eventID)
+	}
+	if want := []int64{seatIDs[0], seatIDs[2]}; !slices.Equal(h.SeatIDs, want) {
+		t.Errorf("SeatIDs = %v, want %v", h.SeatIDs, want)
+	}
+	var created, expires time.Time
+	if err := pool.QueryRow(ctx,
+		`SELECT created_at, expires_at FROM holds WHERE id = $1`, holdID,
+	).Scan(&created, &expires); err != nil {
+		t.Fatal(err)
+	}
+	if h.CreatedAt.IsZero() || !h.CreatedAt.Equal(created) {
+		t.Errorf("CreatedAt = %v, want %v", h.CreatedAt, created)
+	}
+	if !h.ExpiresAt.Equal(expires) {
+		t.Errorf("ExpiresAt = %v, want %v", h.ExpiresAt, expires)
+	}
+
+	if _, err := pool.Exec(ctx,
+		`UPDATE holds SET expires_at = now() - interval '1 second' WHERE id = $1`, holdID,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetHold(ctx, holdID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("expired: err = %v, want %v", err, repository.ErrNotFound)
+	}
+	if _, err := repo.GetHold(ctx, uuid.New()); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("unknown: err = %v, want %v", err, repository.ErrNotFound)
+	}
+}
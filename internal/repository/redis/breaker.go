@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by Cache calls that were short-circuited
+// because Redis recently kept failing.
+var ErrCircuitOpen = errors.New("redis: cache circuit open")
+
+// breaker is a consecutive-failure circuit breaker. After threshold failures
+// in a row it opens for a cool-down window; once the window has passed a
+// single probe call is let through. A failed probe reopens the breaker with
+// the cool-down doubled, up to maxCooldown; a successful call closes it and
+// resets the cool-down.
+type breaker struct {
+	threshold   int
+	baseCool    time.Duration
+	maxCooldown time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	cooldown  time.Duration
+	openUntil time.Time
+	probing   bool
+}
+
+func newBreaker(threshold int, cooldown, maxCooldown time.Duration) *breaker {
+	if maxCooldown < cooldown {
+		maxCooldown = cooldown
+	}
+
+	return &breaker{
+		threshold:   threshold,
+		baseCool:    cooldown,
+		maxCooldown: maxCooldown,
+		cooldown:    cooldown,
+		now:         time.Now,
+	}
+}
+
+// allow reports whether a call may go to Redis. A nil breaker allows
+// everything.
+func (b *breaker) allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return true
+	}
+
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+
+	b.probing = true
+
+	return true
+}
+
+// done records the outcome of a call admitted by allow. Cancellation by the
+// caller says nothing about Redis and is not counted.
+func (b *breaker) done(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err != nil && errors.Is(err, context.Canceled) {
+		b.probing = false
+		return
+	}
+
+	if err == nil {
+		b.failures = 0
+		b.cooldown = b.baseCool
+		b.openUntil = time.Time{}
+		b.probing = false
+		return
+	}
+
+	if b.probing {
+		b.probing = false
+		b.cooldown = min(2*b.cooldown, b.maxCooldown)
+		b.openUntil = b.now().Add(b.cooldown)
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,111 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	errRedis := errors.New("redis down")
+
+	// step is one call through the breaker at the given offset from the
+	// start; err is its outcome if the breaker lets it through, unless the
+	// call is still in flight.
+	type step struct {
+		at        time.Duration
+		err       error
+		inFlight  bool
+		wantAllow bool
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "opens after threshold failures",
+			steps: []step{
+				{at: 0, err: errRedis, wantAllow: true},
+				{at: 0, err: errRedis, wantAllow: true},
+				{at: time.Second, wantAllow: false},
+			},
+		},
+		{
+			name: "success resets the failure count",
+			steps: []step{
+				{at: 0, err: errRedis, wantAllow: true},
+				{at: 0, wantAllow: true},
+				{at: 0, err: errRedis, wantAllow: true},
+				{at: 0, wantAllow: true},
+			},
+		},
+		{
+			name: "cancellation is not counted",
+			steps: []step{
+				{at: 0, err: context.Canceled, wantAllow: true},
+				{at: 0, err: context.Canceled, wantAllow: true},
+				{at: 0, wantAllow: true},
+			},
+		},
+		{
+			name: "one probe after the cool-down closes on success",
+			steps: []step{
+				{at: 0, err: errRedis, wantAllow: true},
+				{at: 0, err: errRedis, wantAllow: true},
+				{at: 10 * time.Second, wantAllow: true},
+				{at: 10 * time.Second, wantAllow: true},
+			},
+		},
+		{
+			name: "only one probe at a time",
+			steps: []step{
+				{at: 0, err: errRedis, wantAllow: true},
+				{at: 0, err: errRedis, wantAllow: true},
+				{at: 10 * time.Second, inFlight: true, wantAllow: true},
+				{at: 11 * time.Second, wantAllow: false},
+			},
+		},
+		{
+			name: "failed probe doubles the cool-down up to the maximum",
+			steps: []step{
+				{at: 0, err: errRedis, wantAllow: true},
+				{at: 0, err: errRedis, wantAllow: true},
+				// Reopens for 20s.
+				{at: 10 * time.Second, err: errRedis, wantAllow: true},
+				{at: 25 * time.Second, wantAllow: false},
+				// Reopens for 30s, the maximum, not 40s.
+				{at: 30 * time.Second, err: errRedis, wantAllow: true},
+				{at: 59 * time.Second, wantAllow: false},
+				{at: 60 * time.Second, wantAllow: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			now := start
+			b := newBreaker(2, 10*time.Second, 30*time.Second)
+			b.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = start.Add(s.at)
+				if got := b.allow(); got != s.wantAllow {
+					t.Fatalf("step %d: allow = %t, want %t", i, got, s.wantAllow)
+				}
+				if s.wantAllow && !s.inFlight {
+					b.done(s.err)
+				}
+			}
+		})
+	}
+}
+
+func TestNilBreakerAllows(t *testing.T) {
+	var b *breaker
+	if !b.allow() {
+		t.Fatal("nil breaker rejected a call")
+	}
+	b.done(errors.New("ignored"))
+}
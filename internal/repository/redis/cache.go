@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"log/slog"
+	"math/rand/v2"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -11,25 +13,115 @@ import (
 )
 
 type Cache struct {
-	rdb *redis.Client
-	sf  singleflight.Group
+	rdb     *redis.Client
+	sf      singleflight.Group
+	jitter  float64
+	rand    func() float64
+	breaker *breaker
+	logger  *slog.Logger
 }
 
-func New(client *redis.Client) *Cache {
-	return &Cache{rdb: client}
+// Option configures optional Cache behaviour.
+type Option func(*Cache)
+
+// WithTTLJitter randomizes TTLs written by SetJSON and GetOrSetJSON by up to
+// ±fraction of their nominal value, so keys written together do not expire
+// together. A fraction of 0 disables jitter.
+func WithTTLJitter(fraction float64) Option {
+	return func(c *Cache) {
+		c.jitter = fraction
+	}
 }
 
-func (c *Cache) GetString(ctx context.Context, key string) (string, bool, error) {
-	s, err := c.rdb.Get(ctx, key).Result()
-	if err == redis.Nil {
-		return "", false, nil
+// WithRandSource overrides the source of randomness used for TTL jitter.
+// The function must return values in [0, 1).
+func WithRandSource(fn func() float64) Option {
+	return func(c *Cache) {
+		c.rand = fn
 	}
+}
 
+// WithCircuitBreaker stops calling Redis for cooldown after threshold
+// consecutive failures, so an unavailable Redis does not cost every request
+// a full timeout. Reads and writes made while the breaker is open fail fast
+// with ErrCircuitOpen; invalidations are still attempted. Each failed probe
+// after a cool-down doubles it, up to maxCooldown. A threshold below 1
+// disables the breaker.
+func WithCircuitBreaker(threshold int, cooldown, maxCooldown time.Duration) Option {
+	return func(c *Cache) {
+		if threshold < 1 || cooldown <= 0 {
+			c.breaker = nil
+			return
+		}
+		c.breaker = newBreaker(threshold, cooldown, maxCooldown)
+	}
+}
+
+// WithLogger sets the logger used to report cache read failures. A nil
+// logger is ignored.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Cache) {
+		if l != nil {
+			c.logger = l
+		}
+	}
+}
+
+func New(client *redis.Client, opts ...Option) *Cache {
+	c := &Cache{rdb: client, rand: rand.Float64, logger: slog.Default()}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// jitteredTTL returns ttl scaled by a random factor in [1-jitter, 1+jitter).
+func (c *Cache) jitteredTTL(ttl time.Duration) time.Duration {
+	if c.jitter <= 0 || ttl <= 0 {
+		return ttl
+	}
+
+	factor := 1 + c.jitter*(2*c.rand()-1)
+	out := time.Duration(float64(ttl) * factor)
+	if out <= 0 {
+		return ttl
+	}
+
+	return out
+}
+
+// guard runs fn unless the circuit breaker is open and records its outcome.
+func (c *Cache) guard(fn func() error) error {
+	if !c.breaker.allow() {
+		return ErrCircuitOpen
+	}
+
+	err := fn()
+	c.breaker.done(err)
+
+	return err
+}
+
+func (c *Cache) GetString(ctx context.Context, key string) (string, bool, error) {
+	var (
+		s     string
+		found bool
+	)
+	err := c.guard(func() error {
+		var err error
+		s, err = c.rdb.Get(ctx, key).Result()
+		if err == redis.Nil {
+			return nil
+		}
+		found = err == nil
+		return err
+	})
 	if err != nil {
 		return "", false, err
 	}
 
-	return s, true, nil
+	return s, found, nil
 }
 
 func (c *Cache) SetString(
@@ -38,15 +130,27 @@ func (c *Cache) SetString(
 	val string,
 	ttl time.Duration,
 ) error {
-	return c.rdb.Set(ctx, key, val, ttl).Err()
+	return c.guard(func() error {
+		return c.rdb.Set(ctx, key, val, ttl).Err()
+	})
 }
 
+// Del is attempted even while the breaker is open: skipping it would leave
+// stale entries in place once Redis recovers. Only calls the breaker admitted
+// count towards its state.
 func (c *Cache) Del(ctx context.Context, keys ...string) error {
 	if len(keys) == 0 {
 		return nil
 	}
 
-	return c.rdb.Del(ctx, keys...).Err()
+	del := func() error {
+		return c.rdb.Del(ctx, keys...).Err()
+	}
+	if err := c.guard(del); !errors.Is(err, ErrCircuitOpen) {
+		return err
+	}
+
+	return del()
 }
 
 func GetJSON[T any](ctx context.Context, c *Cache, key string) (T, bool, error) {
@@ -77,9 +181,16 @@ func SetJSON(
 		return err
 	}
 
-	return c.SetString(ctx, key, string(b), ttl)
+	return c.SetString(ctx, key, string(b), c.jitteredTTL(ttl))
 }
 
+// negativeSentinel is stored in place of a value to remember that the
+// loader reported "not found".
+const negativeSentinel = "\x00not-found"
+
+// GetOrSetJSON returns the value cached under key, or loads and caches it.
+// A failing Redis is treated as a miss: the loader still serves the request
+// and the write-back is still attempted.
 func GetOrSetJSON[T any](
 	ctx context.Context,
 	c *Cache,
@@ -87,16 +198,75 @@ func GetOrSetJSON[T any](
 	ttl time.Duration,
 	loader func(ctx context.Context) (T, error),
 ) (T, error) {
-	if v, ok, err := GetJSON[T](ctx, c, key); err != nil || ok {
+	return getOrSetJSON(ctx, c, key, ttl, 0, nil, loader)
+}
+
+// GetOrSetJSONNegative behaves like GetOrSetJSON, but when the loader fails
+// with an error matching notFound, a sentinel is cached for negativeTTL and
+// subsequent lookups return notFound without calling the loader. Deleting
+// the key (e.g. via InvalidateEvent) clears the negative entry.
+func GetOrSetJSONNegative[T any](
+	ctx context.Context,
+	c *Cache,
+	key string,
+	ttl time.Duration,
+	negativeTTL time.Duration,
+	notFound error,
+	loader func(ctx context.Context) (T, error),
+) (T, error) {
+	return getOrSetJSON(ctx, c, key, ttl, negativeTTL, notFound, loader)
+}
+
+func getOrSetJSON[T any](
+	ctx context.Context,
+	c *Cache,
+	key string,
+	ttl time.Duration,
+	negativeTTL time.Duration,
+	notFound error,
+	loader func(ctx context.Context) (T, error),
+) (T, error) {
+	// Short-circuited reads are not logged: the failures that opened the
+	// breaker already were.
+	lookup := func() (T, bool, error) {
+		var zero T
+
+		s, ok, err := c.GetString(ctx, key)
+		if err != nil {
+			if !errors.Is(err, ErrCircuitOpen) {
+				c.logger.WarnContext(ctx, "cache read failed, using loader", "key", key, "error", err)
+			}
+			return zero, false, nil
+		}
+		if !ok {
+			return zero, false, nil
+		}
+
+		if notFound != nil && s == negativeSentinel {
+			return zero, true, notFound
+		}
+
+		var out T
+		if err := json.Unmarshal([]byte(s), &out); err != nil {
+			return zero, false, err
+		}
+
+		return out, true, nil
+	}
+
+	if v, ok, err := lookup(); err != nil || ok {
 		return v, err
 	}
 
 	vAny, err, _ := c.sf.Do(key, func() (any, error) {
-		if v2, ok2, err2 := GetJSON[T](ctx, c, key); err2 != nil || ok2 {
+		if v2, ok2, err2 := lookup(); err2 != nil || ok2 {
 			return v2, err2
 		}
 		v3, err3 := loader(ctx)
 		if err3 != nil {
+			if notFound != nil && negativeTTL > 0 && errors.Is(err3, notFound) {
+				_ = c.SetString(ctx, key, negativeSentinel, negativeTTL)
+			}
 			return nil, err3
 		}
 		_ = SetJSON(ctx, c, key, v3, ttl)
@@ -121,6 +291,7 @@ func (c *Cache) InvalidateEvent(ctx context.Context, eventID int64) error {
 		ctx,
 		KeyEventSummary(eventID),
 		KeyEventAvailability(eventID),
+		KeyEventSectionAvailability(eventID),
 		KeyEventSeatMap(eventID),
 	)
 }
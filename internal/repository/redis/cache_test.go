@@ -0,0 +1,148 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestCache(t *testing.T, opts ...Option) (*Cache, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	return New(rdb, opts...), mr
+}
+
+func TestDelWhileBreakerOpen(t *testing.T) {
+	c, mr := newTestCache(t, WithCircuitBreaker(1, time.Hour, time.Hour))
+	ctx := context.Background()
+
+	if err := c.SetString(ctx, "k", "v", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	mr.SetError("boom")
+	if _, _, err := c.GetString(ctx, "k"); err == nil {
+		t.Fatal("get: want error while Redis fails")
+	}
+	mr.SetError("")
+
+	if _, _, err := c.GetString(ctx, "k"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("get: err = %v, want %v", err, ErrCircuitOpen)
+	}
+	if err := c.Del(ctx, "k"); err != nil {
+		t.Fatalf("del: %v", err)
+	}
+	if mr.Exists("k") {
+		t.Error("key still cached after Del")
+	}
+}
+
+func TestGetOrSetJSONReadFailure(t *testing.T) {
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+
+	mr.SetError("boom")
+	got, err := GetOrSetJSON(ctx, c, "k", time.Minute, func(context.Context) (int, error) {
+		return 42, nil
+	})
+	if err != nil || got != 42 {
+		t.Fatalf("got %d, %v; want 42 from the loader", got, err)
+	}
+}
+
+func TestGetOrSetJSONNegative(t *testing.T) {
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+	errNotFound := errors.New("not found")
+	errOther := errors.New("boom")
+
+	calls := 0
+	loaderErr := errNotFound
+	get := func() error {
+		_, err := GetOrSetJSONNegative(ctx, c, "k", time.Minute, 10*time.Second, errNotFound,
+			func(context.Context) (int, error) {
+				calls++
+				return 0, loaderErr
+			})
+		return err
+	}
+
+	for i := range 2 {
+		if err := get(); !errors.Is(err, errNotFound) {
+			t.Fatalf("miss %d: err = %v, want %v", i, err, errNotFound)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("loader called %d times, want 1", calls)
+	}
+	if ttl := mr.TTL("k"); ttl != 10*time.Second {
+		t.Errorf("negative entry TTL = %v, want 10s", ttl)
+	}
+
+	// The entry lapses after the negative TTL.
+	mr.FastForward(10 * time.Second)
+	if err := get(); !errors.Is(err, errNotFound) || calls != 2 {
+		t.Fatalf("after TTL: err = %v, calls = %d; want %v from the loader", err, calls, errNotFound)
+	}
+
+	// Invalidation clears it, and other errors are not cached.
+	if err := c.Del(ctx, "k"); err != nil {
+		t.Fatal(err)
+	}
+	loaderErr = errOther
+	for i := range 2 {
+		if err := get(); !errors.Is(err, errOther) {
+			t.Fatalf("failure %d: err = %v, want %v", i, err, errOther)
+		}
+	}
+	if calls != 4 {
+		t.Errorf("loader called %d times, want 4", calls)
+	}
+}
+
+func TestTTLJitter(t *testing.T) {
+	const ttl = 100 * time.Second
+
+	tests := []struct {
+		name string
+		rand float64
+		want time.Duration
+	}{
+		{name: "low end", rand: 0, want: 90 * time.Second},
+		{name: "middle", rand: 0.5, want: ttl},
+		{name: "high end", rand: 0.999, want: 109980 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mr := newTestCache(t, WithTTLJitter(0.1), WithRandSource(func() float64 { return tt.rand }))
+
+			if err := SetJSON(context.Background(), c, "k", 1, ttl); err != nil {
+				t.Fatal(err)
+			}
+			got := mr.TTL("k")
+			if got < 90*time.Second || got >= 110*time.Second {
+				t.Fatalf("TTL = %v, want within [90s, 110s)", got)
+			}
+			// miniredis keeps TTLs at millisecond precision.
+			if diff := got - tt.want; diff < -time.Millisecond || diff > time.Millisecond {
+				t.Errorf("TTL = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	c, mr := newTestCache(t)
+	if err := SetJSON(context.Background(), c, "k", 1, ttl); err != nil {
+		t.Fatal(err)
+	}
+	if got := mr.TTL("k"); got != ttl {
+		t.Errorf("without jitter: TTL = %v, want %v", got, ttl)
+	}
+}
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -15,17 +16,97 @@ func KeyIdemHold(eventID int64, idemKey string) string {
 	return fmt.Sprintf("%s:holds:%d:%s", idemNS, eventID, idemKey)
 }
 
+func KeyIdemAutoHold(eventID int64, idemKey string) string {
+	return fmt.Sprintf("%s:holds:auto:%d:%s", idemNS, eventID, idemKey)
+}
+
+func KeyIdemGAHold(eventID int64, idemKey string) string {
+	return fmt.Sprintf("%s:holds:ga:%d:%s", idemNS, eventID, idemKey)
+}
+
+func KeyIdemConfirm(holdID string, idemKey string) string {
+	return fmt.Sprintf("%s:orders:confirm:%s:%s", idemNS, holdID, idemKey)
+}
+
+func KeyIdemBatchSeats(venueID int64, idemKey string) string {
+	return fmt.Sprintf("%s:venues:seats:%d:%s", idemNS, venueID, idemKey)
+}
+
+// ErrFingerprintMismatch is returned by CheckFingerprint when an idempotency
+// key is reused for a request with a different body.
+var ErrFingerprintMismatch = errors.New("idempotency key reused with a different request")
+
+func keyFingerprint(key string) string {
+	return key + ":fp"
+}
+
+// Lua script that takes an idempotency lock and records the fingerprint of
+// the request holding it in one step, so a concurrent request never sees
+// the lock without the fingerprint.
+// KEYS[1] = key
+// KEYS[2] = fingerprint key
+// ARGV[1] = fingerprint
+// ARGV[2] = lock_ttl_ms
+// ARGV[3] = fingerprint_ttl_ms (0 keeps it without expiry)
+const luaAcquireLock = `
+if not redis.call('SET', KEYS[1], 'LOCK', 'NX', 'PX', ARGV[2]) then
+  return 0
+end
+if tonumber(ARGV[3]) > 0 then
+  redis.call('SET', KEYS[2], ARGV[1], 'PX', ARGV[3])
+else
+  redis.call('SET', KEYS[2], ARGV[1])
+end
+return 1
+`
+
+// DefaultIdempotencyLockTTL is used when NewIdempotencyStore gets a
+// non-positive lock TTL.
+const DefaultIdempotencyLockTTL = 60 * time.Second
+
 type IdempotencyStore struct {
-	rdb *redis.Client
-	ttl time.Duration
+	rdb     *redis.Client
+	ttl     time.Duration
+	lockTTL time.Duration
+	acquire *redis.Script
+}
+
+// NewIdempotencyStore creates a store keeping results for ttl. lockTTL bounds
+// how long an in-flight request holds its key; it should exceed the slowest
+// expected request so a lock never expires mid-flight.
+func NewIdempotencyStore(rdb *redis.Client, ttl, lockTTL time.Duration) *IdempotencyStore {
+	if lockTTL <= 0 {
+		lockTTL = DefaultIdempotencyLockTTL
+	}
+
+	return &IdempotencyStore{
+		rdb:     rdb,
+		ttl:     ttl,
+		lockTTL: lockTTL,
+		acquire: redis.NewScript(luaAcquireLock),
+	}
 }
 
-func NewIdempotencyStore(rdb *redis.Client, ttl time.Duration) *IdempotencyStore {
-	return &IdempotencyStore{rdb: rdb, ttl: ttl}
+// LockTTL returns the TTL to use for in-flight request locks.
+func (s *IdempotencyStore) LockTTL() time.Duration {
+	return s.lockTTL
 }
 
-func (s *IdempotencyStore) AcquireLock(ctx context.Context, key string, lockTTL time.Duration) (bool, error) {
-	return s.rdb.SetNX(ctx, key, "LOCK", lockTTL).Result()
+// AcquireLock locks key for an in-flight request and records its
+// fingerprint, which is kept for as long as results are. It reports false
+// if key is already locked or holds a result.
+func (s *IdempotencyStore) AcquireLock(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (bool, error) {
+	ok, err := s.acquire.Run(
+		ctx,
+		s.rdb,
+		[]string{key, keyFingerprint(key)},
+		fingerprint, lockTTL.Milliseconds(), s.ttl.Milliseconds(),
+	).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return ok == 1, nil
 }
 
 func (s *IdempotencyStore) SaveResult(ctx context.Context, key string, jsonPayload string) error {
@@ -59,6 +140,24 @@ func (s *IdempotencyStore) IsLocked(ctx context.Context, key string) (bool, erro
 	return v == "LOCK", nil
 }
 
+// CheckFingerprint returns ErrFingerprintMismatch if key was first used with
+// a different fingerprint. Keys without a stored fingerprint match anything.
+func (s *IdempotencyStore) CheckFingerprint(ctx context.Context, key string, fingerprint string) error {
+	v, err := s.rdb.Get(ctx, keyFingerprint(key)).Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if v != fingerprint {
+		return ErrFingerprintMismatch
+	}
+
+	return nil
+}
+
+// Release drops the lock (or result) for key together with its fingerprint.
 func (s *IdempotencyStore) Release(ctx context.Context, key string) error {
-	return s.rdb.Del(ctx, key).Err()
+	return s.rdb.Del(ctx, key, keyFingerprint(key)).Err()
 }
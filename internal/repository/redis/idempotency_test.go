@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestAcquireLockSavesFingerprint(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	s := NewIdempotencyStore(rdb, time.Hour, time.Minute)
+	ctx := context.Background()
+
+	locked, err := s.AcquireLock(ctx, "k", "fp1", s.LockTTL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !locked {
+		t.Fatal("first lock not acquired")
+	}
+
+	// The fingerprint is in place as soon as the lock is, so a different
+	// request arriving mid-flight is told apart from a retry.
+	if err := s.CheckFingerprint(ctx, "k", "fp2"); !errors.Is(err, ErrFingerprintMismatch) {
+		t.Errorf("other fingerprint: err = %v, want %v", err, ErrFingerprintMismatch)
+	}
+	if err := s.CheckFingerprint(ctx, "k", "fp1"); err != nil {
+		t.Errorf("same fingerprint: err = %v", err)
+	}
+
+	locked, err = s.AcquireLock(ctx, "k", "fp2", s.LockTTL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if locked {
+		t.Error("second lock acquired while the first is held")
+	}
+	if err := s.CheckFingerprint(ctx, "k", "fp1"); err != nil {
+		t.Errorf("failed lock replaced the fingerprint: err = %v", err)
+	}
+
+	if ttl := mr.TTL("k"); ttl != time.Minute {
+		t.Errorf("lock TTL = %v, want %v", ttl, time.Minute)
+	}
+	if ttl := mr.TTL("k:fp"); ttl != time.Hour {
+		t.Errorf("fingerprint TTL = %v, want %v", ttl, time.Hour)
+	}
+}
@@ -12,6 +12,10 @@ func KeyEventAvailability(eventID int64) string {
 	return fmt.Sprintf("%s:event:%d:availability", ns, eventID)
 }
 
+func KeyEventSectionAvailability(eventID int64) string {
+	return fmt.Sprintf("%s:event:%d:availability:sections", ns, eventID)
+}
+
 func KeyEventSeatMap(eventID int64) string {
 	return fmt.Sprintf("%s:event:%d:seatmap", ns, eventID)
 }
@@ -3,6 +3,7 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -59,3 +60,104 @@ func (p *EventsPubSub) Subscribe(ctx context.Context, handler func(ctx context.C
 		}
 	}
 }
+
+// EventsHub shares a single subscription to the events channel among every
+// watcher in the process and forwards each notification to the watchers of
+// that event. The subscription is opened with the first watcher and closed
+// with the last one.
+type EventsHub struct {
+	pubsub *EventsPubSub
+
+	mu       sync.Mutex
+	watchers map[int64]map[chan struct{}]struct{}
+	// cancel stops the running subscription; nil when none is running.
+	cancel context.CancelFunc
+}
+
+func NewEventsHub(pubsub *EventsPubSub) *EventsHub {
+	return &EventsHub{
+		pubsub:   pubsub,
+		watchers: make(map[int64]map[chan struct{}]struct{}),
+	}
+}
+
+// Watch registers interest in eventID. The returned channel receives a value
+// after changes to the event; bursts are coalesced into one pending value.
+// It is closed if the shared subscription ends. Call stop once done.
+func (h *EventsHub) Watch(eventID int64) (changed <-chan struct{}, stop func()) {
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cancel == nil {
+		h.start()
+	}
+	if h.watchers[eventID] == nil {
+		h.watchers[eventID] = make(map[chan struct{}]struct{})
+	}
+	h.watchers[eventID][ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() { h.remove(eventID, ch) })
+	}
+}
+
+// start opens the shared subscription. Callers must hold h.mu.
+func (h *EventsHub) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+
+	go func() {
+		_ = h.pubsub.Subscribe(ctx, h.dispatch)
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		// Stopped by remove; a newer subscription may already be running.
+		if ctx.Err() != nil {
+			return
+		}
+
+		// The subscription ended on its own, so no watcher will hear about
+		// changes any more.
+		for id, set := range h.watchers {
+			for ch := range set {
+				close(ch)
+			}
+			delete(h.watchers, id)
+		}
+		h.cancel = nil
+		cancel()
+	}()
+}
+
+func (h *EventsHub) dispatch(_ context.Context, eventID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.watchers[eventID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (h *EventsHub) remove(eventID int64, ch chan struct{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if set := h.watchers[eventID]; set != nil {
+		delete(set, ch)
+		if len(set) == 0 {
+			delete(h.watchers, eventID)
+		}
+	}
+
+	if len(h.watchers) == 0 && h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
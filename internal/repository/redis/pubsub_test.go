@@ -0,0 +1,140 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestSubscribe(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	pubsub := NewEventsPubSub(rdb)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got := make(chan int64, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- pubsub.Subscribe(ctx, func(_ context.Context, eventID int64) {
+			got <- eventID
+		})
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for mr.PubSubNumSub(ChannelEventsChanged())[ChannelEventsChanged()] != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscriber did not subscribe")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Malformed messages are skipped without reaching the handler.
+	mr.Publish(ChannelEventsChanged(), "not json")
+	if err := pubsub.PublishEventChanged(ctx, 42); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case id := <-got:
+		if id != 42 {
+			t.Errorf("handler got event %d, want 42", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler not invoked")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Subscribe returned %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Subscribe did not return after cancel")
+	}
+}
+
+func TestEventsHub(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	pubsub := NewEventsPubSub(rdb)
+	hub := NewEventsHub(pubsub)
+	ctx := context.Background()
+
+	subscribers := func() int {
+		return mr.PubSubNumSub(ChannelEventsChanged())[ChannelEventsChanged()]
+	}
+	waitFor := func(t *testing.T, want int) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for subscribers() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("subscribers = %d, want %d", subscribers(), want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	a, stopA := hub.Watch(1)
+	b, stopB := hub.Watch(1)
+	other, stopOther := hub.Watch(2)
+	waitFor(t, 1)
+
+	if err := pubsub.PublishEventChanged(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	for name, ch := range map[string]<-chan struct{}{"a": a, "b": b} {
+		select {
+		case <-ch:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("watcher %s not notified", name)
+		}
+	}
+	select {
+	case <-other:
+		t.Error("watcher of another event notified")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	stopA()
+	stopB()
+	stopOther()
+	waitFor(t, 0)
+
+	// A later watcher opens a fresh subscription.
+	c, stopC := hub.Watch(1)
+	waitFor(t, 1)
+	if err := pubsub.PublishEventChanged(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-c:
+	case <-time.After(2 * time.Second):
+		t.Fatal("watcher after restart not notified")
+	}
+
+	stopC()
+	waitFor(t, 0)
+
+	// Watchers are released when the subscription ends on its own.
+	d, stopD := hub.Watch(1)
+	defer stopD()
+	waitFor(t, 1)
+	_ = rdb.Close()
+	select {
+	case _, ok := <-d:
+		if ok {
+			t.Fatal("watcher notified instead of closed")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("watcher not closed after the subscription ended")
+	}
+}
@@ -41,12 +41,119 @@ end
 return {1, count, 0}
 `
 
+// Lua script that reports what luaSlidingWindow would decide without
+// recording a hit.
+// KEYS[1] = key
+// ARGV[1] = now_ms
+// ARGV[2] = window_ms
+// ARGV[3] = limit
+const luaSlidingWindowPeek = `
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+local count = redis.call('ZCOUNT', key, '(' .. (now - window), '+inf')
+if count >= limit then
+  local earliest = redis.call('ZRANGEBYSCORE', key, '(' .. (now - window), '+inf', 'WITHSCORES', 'LIMIT', 0, 1)
+  local earliestScore = tonumber(earliest[2]) or (now - window)
+  local retry_ms = window - (now - earliestScore)
+  if retry_ms < 0 then retry_ms = 0 end
+  return {0, count, retry_ms}
+end
+return {1, count, 0}
+`
+
+// Lua script for a token bucket stored in a hash.
+// KEYS[1] = key
+// ARGV[1] = now_ms
+// ARGV[2] = capacity
+// ARGV[3] = refill_ms (time to refill one token)
+const luaTokenBucket = `
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local refill_ms = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', key, 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+
+-- refill whole tokens for the elapsed time
+local elapsed = now - ts
+if elapsed < 0 then elapsed = 0 end
+local refilled = math.floor(elapsed / refill_ms)
+if refilled > 0 then
+  tokens = math.min(capacity, tokens + refilled)
+  ts = ts + refilled * refill_ms
+end
+if tokens >= capacity then
+  ts = now
+end
+
+local allowed = 0
+local retry_ms = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  retry_ms = refill_ms - (now - ts)
+  if retry_ms < 0 then retry_ms = 0 end
+end
+
+redis.call('HSET', key, 'tokens', tokens, 'ts', ts)
+-- keep TTL ~ time to refill the whole bucket
+redis.call('PEXPIRE', key, capacity * refill_ms)
+
+return {allowed, tokens, retry_ms}
+`
+
+// Lua script that reports what luaTokenBucket would decide without taking
+// a token.
+// KEYS[1] = key
+// ARGV[1] = now_ms
+// ARGV[2] = capacity
+// ARGV[3] = refill_ms
+const luaTokenBucketPeek = `
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local refill_ms = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', key, 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  return {1, capacity, 0}
+end
+
+local elapsed = now - ts
+if elapsed < 0 then elapsed = 0 end
+local refilled = math.floor(elapsed / refill_ms)
+if refilled > 0 then
+  tokens = math.min(capacity, tokens + refilled)
+  ts = ts + refilled * refill_ms
+end
+
+if tokens >= 1 then
+  return {1, tokens, 0}
+end
+local retry_ms = refill_ms - (now - ts)
+if retry_ms < 0 then retry_ms = 0 end
+return {0, tokens, retry_ms}
+`
+
 type SlidingWindowLimiter struct {
 	rdb    *redis.Client
 	prefix string
 	limit  int
 	window time.Duration
 	script *redis.Script
+	peek   *redis.Script
 }
 
 func NewSlidingWindowLimiter(
@@ -61,6 +168,7 @@ func NewSlidingWindowLimiter(
 		limit:  limit,
 		window: window,
 		script: redis.NewScript(luaSlidingWindow),
+		peek:   redis.NewScript(luaSlidingWindowPeek),
 	}
 }
 
@@ -96,6 +204,106 @@ func (l *SlidingWindowLimiter) Allow(ctx context.Context, suffix string) (allowe
 	return
 }
 
+// Peek reports whether Allow would currently let a request for suffix
+// through, without counting one.
+func (l *SlidingWindowLimiter) Peek(ctx context.Context, suffix string) (allowed bool, current int64, retryAfter time.Duration, err error) {
+	nowMs := time.Now().UnixNano() / 1e6
+
+	return runLimiterScript(ctx, l.rdb, l.peek, l.key(suffix), nowMs, l.window.Milliseconds(), l.limit)
+}
+
+// TokenBucketLimiter allows bursts of up to capacity requests and then
+// refills one token every refillEvery, smoothing sustained traffic.
+type TokenBucketLimiter struct {
+	rdb         *redis.Client
+	prefix      string
+	capacity    int
+	refillEvery time.Duration
+	script      *redis.Script
+	peek        *redis.Script
+}
+
+func NewTokenBucketLimiter(
+	rdb *redis.Client,
+	prefix string,
+	capacity int,
+	refillEvery time.Duration,
+) *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		rdb:         rdb,
+		prefix:      prefix,
+		capacity:    capacity,
+		refillEvery: refillEvery,
+		script:      redis.NewScript(luaTokenBucket),
+		peek:        redis.NewScript(luaTokenBucketPeek),
+	}
+}
+
+func (l *TokenBucketLimiter) key(suffix string) string {
+	return fmt.Sprintf("%s:tb:%s", l.prefix, suffix)
+}
+
+// Allow takes a token from the bucket identified by suffix. The returned
+// current value is the number of tokens left after this call, and retryAfter
+// is the time until the next token is available when the call is rejected.
+func (l *TokenBucketLimiter) Allow(ctx context.Context, suffix string) (allowed bool, current int64, retryAfter time.Duration, err error) {
+	key := l.key(suffix)
+	nowMs := time.Now().UnixNano() / 1e6
+	refillMs := l.refillEvery.Milliseconds()
+	if refillMs <= 0 {
+		refillMs = 1
+	}
+
+	res, err := l.script.Run(
+		ctx,
+		l.rdb,
+		[]string{key},
+		nowMs, l.capacity, refillMs,
+	).Result()
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	arr, ok := res.([]any)
+	if !ok || len(arr) != 3 {
+		return false, 0, 0, fmt.Errorf("bad script result: %v", res)
+	}
+
+	allowed = toInt(arr[0]) == 1
+	current = toInt(arr[1])
+	retryAfter = time.Duration(toInt(arr[2])) * time.Millisecond
+
+	return
+}
+
+// Peek reports whether Allow would currently take a token for suffix,
+// without taking one.
+func (l *TokenBucketLimiter) Peek(ctx context.Context, suffix string) (allowed bool, current int64, retryAfter time.Duration, err error) {
+	nowMs := time.Now().UnixNano() / 1e6
+	refillMs := l.refillEvery.Milliseconds()
+	if refillMs <= 0 {
+		refillMs = 1
+	}
+
+	return runLimiterScript(ctx, l.rdb, l.peek, l.key(suffix), nowMs, l.capacity, refillMs)
+}
+
+// runLimiterScript runs a limiter script returning {allowed, current,
+// retry_ms}.
+func runLimiterScript(ctx context.Context, rdb *redis.Client, script *redis.Script, key string, args ...any) (bool, int64, time.Duration, error) {
+	res, err := script.Run(ctx, rdb, []string{key}, args...).Result()
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	arr, ok := res.([]any)
+	if !ok || len(arr) != 3 {
+		return false, 0, 0, fmt.Errorf("bad script result: %v", res)
+	}
+
+	return toInt(arr[0]) == 1, toInt(arr[1]), time.Duration(toInt(arr[2])) * time.Millisecond, nil
+}
+
 func toInt(v any) int64 {
 	switch t := v.(type) {
 	case int64:
@@ -0,0 +1,84 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// limiter is the method set the services depend on.
+type limiter interface {
+	Allow(ctx context.Context, suffix string) (bool, int64, time.Duration, error)
+	Peek(ctx context.Context, suffix string) (bool, int64, time.Duration, error)
+}
+
+func TestLimiters(t *testing.T) {
+	tests := []struct {
+		name string
+		new  func(rdb *redis.Client) limiter
+	}{
+		{
+			name: "sliding window",
+			new:  func(rdb *redis.Client) limiter { return NewSlidingWindowLimiter(rdb, "rl", 2, time.Minute) },
+		},
+		{
+			name: "token bucket",
+			new:  func(rdb *redis.Client) limiter { return NewTokenBucketLimiter(rdb, "rl", 2, time.Minute) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+			t.Cleanup(func() { _ = rdb.Close() })
+			l := tt.new(rdb)
+			ctx := context.Background()
+
+			// Peeking never uses up the budget.
+			for range 3 {
+				if ok, _, _, err := l.Peek(ctx, "ip:a"); err != nil || !ok {
+					t.Fatalf("peek: allowed = %t, err = %v", ok, err)
+				}
+			}
+
+			for i := range 2 {
+				ok, _, _, err := l.Allow(ctx, "ip:a")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !ok {
+					t.Fatalf("call %d rejected within the limit", i+1)
+				}
+			}
+
+			ok, _, retry, err := l.Peek(ctx, "ip:a")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok {
+				t.Fatal("peek over the limit allowed")
+			}
+			if retry <= 0 || retry > time.Minute {
+				t.Errorf("peek retryAfter = %v, want in (0, %v]", retry, time.Minute)
+			}
+
+			ok, _, retry, err = l.Allow(ctx, "ip:a")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok {
+				t.Fatal("call over the limit allowed")
+			}
+			if retry <= 0 || retry > time.Minute {
+				t.Errorf("retryAfter = %v, want in (0, %v]", retry, time.Minute)
+			}
+
+			// Other keys have their own budget.
+			if ok, _, _, err := l.Allow(ctx, "ip:b"); err != nil || !ok {
+				t.Errorf("other key: allowed = %t, err = %v", ok, err)
+			}
+		})
+	}
+}
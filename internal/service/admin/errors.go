@@ -2,11 +2,44 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 )
 
 var (
 	ErrVenueConflict          = errors.New("venue already exists")
-	ErrSeatsConflict          = errors.New("some seats already exist")
+	ErrTooManySeats           = errors.New("too many seats in one batch")
 	ErrEventConflict          = errors.New("event already exists")
 	ErrFailedToInitEventSeats = errors.New("event or venue does not exist")
+	ErrEventNotFound          = errors.New("event not found")
+	ErrInvalidEventTime       = errors.New("event must end after it starts")
+	ErrEventAlreadyCancelled  = errors.New("event already cancelled")
+	ErrVenueNotFound          = errors.New("venue not found")
+	ErrInvalidSeatingScheme   = errors.New("invalid seating scheme")
+	ErrGACapacityTooLow       = errors.New("general admission capacity below tickets held and sold")
+	ErrSeatNotFound           = errors.New("seat not found")
+	ErrSeatConflict           = errors.New("another seat already has this position")
+	ErrSeatBooked             = errors.New("seat is held or sold for an upcoming event")
+	ErrInvalidSeatNumber      = errors.New("seat number must be positive")
+	ErrInvalidSeatRow         = errors.New("seat row must be a positive integer")
+	ErrNoTimeSlots            = errors.New("at least one time slot is required")
+	ErrTooManyEvents          = errors.New("too many events in one batch")
+	ErrPreconditionFailed     = errors.New("resource has changed")
+	ErrVenueHasNoSeats        = errors.New("venue has no seats")
+	ErrAPIKeyNotFound         = errors.New("api key not found")
+	ErrInvalidAPIKeyScope     = errors.New("invalid api key scope")
+	ErrNoAPIKeyScopes         = errors.New("at least one api key scope is required")
 )
+
+// InvalidSeatingSchemeError explains why a seating scheme was rejected. It
+// matches ErrInvalidSeatingScheme with errors.Is.
+type InvalidSeatingSchemeError struct {
+	Reason string
+}
+
+func (e InvalidSeatingSchemeError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidSeatingScheme, e.Reason)
+}
+
+func (e InvalidSeatingSchemeError) Is(target error) bool {
+	return target == ErrInvalidSeatingScheme
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kirinyoku/tix-go/internal/auth"
 	"github.com/kirinyoku/tix-go/internal/domain"
 	"github.com/kirinyoku/tix-go/internal/repository"
 	postgresrepo "github.com/kirinyoku/tix-go/internal/repository/postgres"
@@ -13,6 +14,19 @@ import (
 	"github.com/kirinyoku/tix-go/internal/uow"
 )
 
+// MaxBatchSeats is the most seats BatchCreateSeats accepts in one call.
+const MaxBatchSeats = 10000
+
+// MaxRecurringEvents is the most events CreateRecurringEvents accepts in one
+// call.
+const MaxRecurringEvents = 100
+
+// TimeSlot is the schedule of a single occurrence of a recurring show.
+type TimeSlot struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
 type Service struct {
 	store  *postgresrepo.Store
 	cache  *redisrepo.Cache
@@ -20,16 +34,22 @@ type Service struct {
 	uow    *uow.UoW
 }
 
-func New(store *postgresrepo.Store, cache *redisrepo.Cache, pubsub *redisrepo.EventsPubSub) *Service {
+func New(
+	store *postgresrepo.Store,
+	cache *redisrepo.Cache,
+	pubsub *redisrepo.EventsPubSub,
+	uowOpts ...uow.Option,
+) *Service {
 	return &Service{
 		store:  store,
 		cache:  cache,
 		pubsub: pubsub,
-		uow:    uow.NewUoW(store),
+		uow:    uow.NewUoW(store, uowOpts...),
 	}
 }
 
-// CreateVenue creates a venue record and returns its ID.
+// CreateVenue creates a venue record and returns its ID. The seating scheme
+// is validated against domain.SeatingScheme; an empty scheme is stored as {}.
 //
 // Parameters:
 //   - ctx: request-scoped context.
@@ -38,10 +58,19 @@ func New(store *postgresrepo.Store, cache *redisrepo.Cache, pubsub *redisrepo.Ev
 //
 // Returns:
 //   - int64: the created venue ID on success.
+//   - error: admin.ErrInvalidSeatingScheme if the scheme is malformed.
 //   - error: admin.ErrVenueConflict if a venue with the same name already exists.
 func (s *Service) CreateVenue(ctx context.Context, name string, seatingSchemeJSON []byte) (int64, error) {
 	const op = "service.admin.CreateVenue"
 
+	if err := validateSeatingScheme(seatingSchemeJSON); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(seatingSchemeJSON) == 0 {
+		seatingSchemeJSON = []byte("{}")
+	}
+
 	var id int64
 	err := s.uow.Do(ctx, func(ctx context.Context, tx postgresrepo.DB, after func(uow.AfterCommit)) error {
 		var err error
@@ -59,31 +88,91 @@ func (s *Service) CreateVenue(ctx context.Context, name string, seatingSchemeJSO
 }
 
 // BatchCreateSeats inserts multiple seats for a venue within a
-// transactional Unit of Work.
+// transactional Unit of Work. Seats that already exist are skipped.
 //
 // Parameters:
 //   - ctx: request-scoped context.
 //   - venueID: ID of the venue to add seats to.
-//   - seats: list of domain.Seat objects to create.
+//   - seats: list of domain.Seat objects to create, at most MaxBatchSeats.
 //
 // Returns:
-//   - error: admin.ErrSeatsConflict if a seat with the same identifying data
-//     already exists.
-func (s *Service) BatchCreateSeats(ctx context.Context, venueID int64, seats []domain.Seat) error {
+//   - int64: number of seats newly created.
+//   - error: admin.ErrTooManySeats if more than MaxBatchSeats seats are given.
+//   - error: admin.ErrInvalidSeatRow if a row is not a positive integer.
+func (s *Service) BatchCreateSeats(ctx context.Context, venueID int64, seats []domain.Seat) (int64, error) {
 	const op = "service.admin.BatchCreateSeats"
 
+	if len(seats) > MaxBatchSeats {
+		return 0, fmt.Errorf("%s: %w", op, ErrTooManySeats)
+	}
+
+	for _, seat := range seats {
+		if !domain.ValidRow(seat.Row) {
+			return 0, fmt.Errorf("%s: %w", op, ErrInvalidSeatRow)
+		}
+	}
+
+	var created int64
+
 	err := s.uow.Do(ctx, func(ctx context.Context, tx postgresrepo.DB, after func(uow.AfterCommit)) error {
-		err := s.store.Admin().With(tx).BatchCreateSeats(ctx, venueID, seats)
+		n, err := s.store.Admin().With(tx).BatchCreateSeats(ctx, venueID, seats)
 		if err != nil {
-			if errors.Is(err, repository.ErrConflict) {
-				return fmt.Errorf("%s: %w", op, ErrSeatsConflict)
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		created = n
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return created, nil
+}
+
+// GenerateSeatsFromScheme creates the seats described by a venue's stored
+// seating scheme. Seats that already exist are left untouched, so the call
+// is safe to repeat after the scheme grows.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - venueID: ID of the venue whose scheme is expanded.
+//
+// Returns:
+//   - int64: number of seats newly created.
+//   - error: admin.ErrVenueNotFound if the venue does not exist.
+//   - error: admin.ErrInvalidSeatingScheme if the stored scheme is malformed.
+func (s *Service) GenerateSeatsFromScheme(ctx context.Context, venueID int64) (int64, error) {
+	const op = "service.admin.GenerateSeatsFromScheme"
+
+	var created int64
+
+	err := s.uow.Do(ctx, func(ctx context.Context, tx postgresrepo.DB, after func(uow.AfterCommit)) error {
+		v, err := s.store.Query().With(tx).GetVenue(ctx, venueID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%s: %w", op, ErrVenueNotFound)
 			}
 			return fmt.Errorf("%s: %w", op, err)
 		}
+
+		scheme, err := domain.ParseSeatingScheme(v.SeatingScheme)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, InvalidSeatingSchemeError{Reason: err.Error()})
+		}
+
+		seats := scheme.Seats(venueID)
+		if len(seats) == 0 {
+			return nil
+		}
+
+		created, err = s.store.Admin().With(tx).BatchCreateSeats(ctx, venueID, seats)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
 		return nil
 	})
 
-	return err
+	return created, err
 }
 
 // CreateEventWithInit creates an event and initializes event seats by
@@ -94,9 +183,13 @@ func (s *Service) BatchCreateSeats(ctx context.Context, venueID int64, seats []d
 //   - venueID: the venue the event belongs to.
 //   - title: event title.
 //   - starts, ends: start and end times for the event.
+//   - priceCents: price of each event seat in cents.
+//   - dryRun: when true, only validate the input; nothing is written.
 //
 // Returns:
-//   - int64: the created event ID.
+//   - int64: the created event ID, zero on a dry run.
+//   - error: admin.ErrVenueNotFound if the venue does not exist.
+//   - error: admin.ErrVenueHasNoSeats if the venue has no seats to copy.
 //   - error: admin.ErrEventConflict if the event creation violates a uniqueness
 //     constraint.
 //   - error: admin.ErrFailedToInitEventSeats if initializing event seats fails.
@@ -105,9 +198,15 @@ func (s *Service) CreateEventWithInit(
 	venueID int64,
 	title string,
 	starts, ends time.Time,
+	priceCents int,
+	dryRun bool,
 ) (int64, error) {
 	const op = "service.admin.CreateEventWithInit"
 
+	if priceCents < 0 {
+		return 0, fmt.Errorf("%s: price must not be negative", op)
+	}
+
 	var eventID int64
 	var err error
 
@@ -116,6 +215,13 @@ func (s *Service) CreateEventWithInit(
 		tx postgresrepo.DB,
 		after func(uow.AfterCommit),
 	) error {
+		if err := s.checkVenueSeats(ctx, tx, venueID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if dryRun {
+			return nil
+		}
+
 		eventID, err = s.store.Admin().
 			With(tx).
 			CreateEvent(ctx, venueID, title, starts, ends)
@@ -128,18 +234,547 @@ func (s *Service) CreateEventWithInit(
 
 		if _, err := s.store.Admin().
 			With(tx).
-			InitEventSeats(ctx, eventID, venueID); err != nil {
+			InitEventSeats(ctx, eventID, venueID, priceCents); err != nil {
 			if errors.Is(err, repository.ErrNotFound) {
 				return fmt.Errorf("%s: %w", op, ErrFailedToInitEventSeats)
 			}
 			return fmt.Errorf("%s: %w", op, err)
 		}
 
-		after(func(ctx context.Context) {
-			_ = s.cache.InvalidateEvent(ctx, eventID)
-			_ = s.pubsub.PublishEventChanged(ctx, eventID)
+		after(func(ctx context.Context) error {
+			return errors.Join(
+				s.cache.InvalidateEvent(ctx, eventID),
+				s.pubsub.PublishEventChanged(ctx, eventID),
+			)
 		})
 		return nil
 	})
 	return eventID, err
 }
+
+// CreateRecurringEvents creates one event per time slot, all with the same
+// venue, title and price, and initializes the seats of each. Everything runs
+// in a single transaction: if any event fails, none is created.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - venueID: the venue the events belong to.
+//   - title: title shared by all events.
+//   - slots: start and end times, one per event, at most MaxRecurringEvents.
+//   - priceCents: price of each event seat in cents.
+//
+// Returns:
+//   - []int64: the created event IDs, in the order of slots.
+//   - error: admin.ErrNoTimeSlots if slots is empty.
+//   - error: admin.ErrTooManyEvents if more than MaxRecurringEvents slots are given.
+//   - error: admin.ErrInvalidEventTime if a slot does not end after it starts.
+//   - error: admin.ErrVenueNotFound if the venue does not exist.
+//   - error: admin.ErrVenueHasNoSeats if the venue has no seats to copy.
+//   - error: admin.ErrEventConflict if an event violates a uniqueness constraint.
+//   - error: admin.ErrFailedToInitEventSeats if initializing event seats fails.
+func (s *Service) CreateRecurringEvents(
+	ctx context.Context,
+	venueID int64,
+	title string,
+	slots []TimeSlot,
+	priceCents int,
+) ([]int64, error) {
+	const op = "service.admin.CreateRecurringEvents"
+
+	if len(slots) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoTimeSlots)
+	}
+	if len(slots) > MaxRecurringEvents {
+		return nil, fmt.Errorf("%s: %w", op, ErrTooManyEvents)
+	}
+	for _, slot := range slots {
+		if !slot.EndsAt.After(slot.StartsAt) {
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidEventTime)
+		}
+	}
+	if priceCents < 0 {
+		return nil, fmt.Errorf("%s: price must not be negative", op)
+	}
+
+	var eventIDs []int64
+
+	err := s.uow.Do(ctx, func(
+		ctx context.Context,
+		tx postgresrepo.DB,
+		after func(uow.AfterCommit),
+	) error {
+		if err := s.checkVenueSeats(ctx, tx, venueID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		ids := make([]int64, 0, len(slots))
+		for _, slot := range slots {
+			eventID, err := s.store.Admin().
+				With(tx).
+				CreateEvent(ctx, venueID, title, slot.StartsAt, slot.EndsAt)
+			if err != nil {
+				if errors.Is(err, repository.ErrConflict) {
+					return fmt.Errorf("%s: %w", op, ErrEventConflict)
+				}
+				return fmt.Errorf("%s: %w", op, err)
+			}
+
+			if _, err := s.store.Admin().
+				With(tx).
+				InitEventSeats(ctx, eventID, venueID, priceCents); err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					return fmt.Errorf("%s: %w", op, ErrFailedToInitEventSeats)
+				}
+				return fmt.Errorf("%s: %w", op, err)
+			}
+
+			ids = append(ids, eventID)
+		}
+
+		eventIDs = ids
+
+		after(func(ctx context.Context) error {
+			var errs []error
+			for _, eventID := range ids {
+				errs = append(errs,
+					s.cache.InvalidateEvent(ctx, eventID),
+					s.pubsub.PublishEventChanged(ctx, eventID),
+				)
+			}
+			return errors.Join(errs...)
+		})
+		return nil
+	})
+
+	return eventIDs, err
+}
+
+// UpdateVenue changes the name and seating scheme of a venue. An empty
+// seatingSchemeJSON keeps the current scheme.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - venueID: ID of the venue to update.
+//   - name: new venue name.
+//   - seatingSchemeJSON: raw JSON of the new seating layout, or empty.
+//   - ifMatch: optional precondition on the current venue; nil skips it.
+//
+// Returns:
+//   - *domain.Venue: the updated venue.
+//   - error: admin.ErrInvalidSeatingScheme if the scheme is malformed.
+//   - error: admin.ErrVenueNotFound if the venue does not exist.
+//   - error: admin.ErrPreconditionFailed if ifMatch rejects the current venue.
+//   - error: admin.ErrVenueConflict if another venue already has the name.
+func (s *Service) UpdateVenue(
+	ctx context.Context,
+	venueID int64,
+	name string,
+	seatingSchemeJSON []byte,
+	ifMatch func(current *domain.Venue) bool,
+) (*domain.Venue, error) {
+	const op = "service.admin.UpdateVenue"
+
+	if len(seatingSchemeJSON) == 0 {
+		seatingSchemeJSON = nil
+	} else if err := validateSeatingScheme(seatingSchemeJSON); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var venue *domain.Venue
+
+	err := s.uow.Do(ctx, func(ctx context.Context, tx postgresrepo.DB, after func(uow.AfterCommit)) error {
+		if ifMatch != nil {
+			cur, err := s.store.Admin().With(tx).GetVenueForUpdate(ctx, venueID)
+			if err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					return fmt.Errorf("%s: %w", op, ErrVenueNotFound)
+				}
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			if !ifMatch(cur) {
+				return fmt.Errorf("%s: %w", op, ErrPreconditionFailed)
+			}
+		}
+
+		v, err := s.store.Admin().With(tx).UpdateVenue(ctx, venueID, name, seatingSchemeJSON)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%s: %w", op, ErrVenueNotFound)
+			}
+			if errors.Is(err, repository.ErrConflict) {
+				return fmt.Errorf("%s: %w", op, ErrVenueConflict)
+			}
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		venue = v
+		return nil
+	})
+
+	return venue, err
+}
+
+// UpdateSeat moves a seat to another section, row or number within its
+// venue. Nil arguments keep the current value. Seats sold or held for an
+// upcoming event cannot be moved, since their tickets name the old position.
+// On success the cached views of every event using the seat are invalidated
+// and event-changed notifications are published.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - seatID: ID of the seat to update.
+//   - section, row, number: new position of the seat.
+//
+// Returns:
+//   - *domain.Seat: the updated seat.
+//   - error: admin.ErrInvalidSeatNumber if number is not positive.
+//   - error: admin.ErrInvalidSeatRow if row is not a positive integer.
+//   - error: admin.ErrSeatNotFound if the seat does not exist.
+//   - error: admin.ErrSeatConflict if another seat of the venue already has the position.
+//   - error: admin.ErrSeatBooked if the seat is held or sold for an upcoming event.
+func (s *Service) UpdateSeat(
+	ctx context.Context,
+	seatID int64,
+	section, row *string,
+	number *int,
+) (*domain.Seat, error) {
+	const op = "service.admin.UpdateSeat"
+
+	if number != nil && *number <= 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidSeatNumber)
+	}
+
+	if row != nil && !domain.ValidRow(*row) {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidSeatRow)
+	}
+
+	var seat *domain.Seat
+
+	err := s.uow.Do(ctx, func(
+		ctx context.Context,
+		tx postgresrepo.DB,
+		after func(uow.AfterCommit),
+	) error {
+		booked, err := s.store.Admin().With(tx).CountSeatBookings(ctx, seatID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if booked > 0 {
+			return fmt.Errorf("%s: %w", op, ErrSeatBooked)
+		}
+
+		st, err := s.store.Admin().
+			With(tx).
+			UpdateSeat(ctx, seatID, section, row, number)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%s: %w", op, ErrSeatNotFound)
+			}
+			if errors.Is(err, repository.ErrConflict) {
+				return fmt.Errorf("%s: %w", op, ErrSeatConflict)
+			}
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		eventIDs, err := s.store.Query().With(tx).EventIDsBySeat(ctx, seatID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		seat = st
+
+		after(func(ctx context.Context) error {
+			var errs []error
+			for _, eventID := range eventIDs {
+				errs = append(errs,
+					s.cache.InvalidateEvent(ctx, eventID),
+					s.pubsub.PublishEventChanged(ctx, eventID),
+				)
+			}
+			return errors.Join(errs...)
+		})
+		return nil
+	})
+
+	return seat, err
+}
+
+// UpdateEvent changes the title and schedule of an event. On success the
+// event cache is invalidated and an event-changed notification is published.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event to update.
+//   - title: new event title.
+//   - starts, ends: new start and end times for the event.
+//   - ifMatch: optional precondition on the current event; nil skips it.
+//
+// Returns:
+//   - *domain.Event: the updated event.
+//   - error: admin.ErrInvalidEventTime if ends is not after starts.
+//   - error: admin.ErrEventNotFound if the event does not exist.
+//   - error: admin.ErrPreconditionFailed if ifMatch rejects the current event.
+func (s *Service) UpdateEvent(
+	ctx context.Context,
+	eventID int64,
+	title string,
+	starts, ends time.Time,
+	ifMatch func(current *domain.Event) bool,
+) (*domain.Event, error) {
+	const op = "service.admin.UpdateEvent"
+
+	if !ends.After(starts) {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidEventTime)
+	}
+
+	var event *domain.Event
+
+	err := s.uow.Do(ctx, func(
+		ctx context.Context,
+		tx postgresrepo.DB,
+		after func(uow.AfterCommit),
+	) error {
+		if ifMatch != nil {
+			cur, err := s.store.Admin().With(tx).GetEventForUpdate(ctx, eventID)
+			if err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					return fmt.Errorf("%s: %w", op, ErrEventNotFound)
+				}
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			if !ifMatch(cur) {
+				return fmt.Errorf("%s: %w", op, ErrPreconditionFailed)
+			}
+		}
+
+		e, err := s.store.Admin().
+			With(tx).
+			UpdateEvent(ctx, eventID, title, starts, ends)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%s: %w", op, ErrEventNotFound)
+			}
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		event = e
+
+		after(func(ctx context.Context) error {
+			return errors.Join(
+				s.cache.InvalidateEvent(ctx, eventID),
+				s.pubsub.PublishEventChanged(ctx, eventID),
+			)
+		})
+		return nil
+	})
+
+	return event, err
+}
+
+// CancelEvent cancels an event and releases all of its held seats. Sold
+// seats are kept; the number of affected orders is reported so they can be
+// refunded. On success the event cache is invalidated and an event-changed
+// notification is published.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event to cancel.
+//
+// Returns:
+//   - *domain.EventCancellation: counts of released holds/seats and affected orders.
+//   - error: admin.ErrEventNotFound if the event does not exist.
+//   - error: admin.ErrEventAlreadyCancelled if the event is already cancelled.
+func (s *Service) CancelEvent(ctx context.Context, eventID int64) (*domain.EventCancellation, error) {
+	const op = "service.admin.CancelEvent"
+
+	var res *domain.EventCancellation
+
+	err := s.uow.Do(ctx, func(
+		ctx context.Context,
+		tx postgresrepo.DB,
+		after func(uow.AfterCommit),
+	) error {
+		r, err := s.store.Admin().With(tx).CancelEvent(ctx, eventID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%s: %w", op, ErrEventNotFound)
+			}
+			if errors.Is(err, repository.ErrEventCancelled) {
+				return fmt.Errorf("%s: %w", op, ErrEventAlreadyCancelled)
+			}
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		res = r
+
+		after(func(ctx context.Context) error {
+			return errors.Join(
+				s.cache.InvalidateEvent(ctx, eventID),
+				s.pubsub.PublishEventChanged(ctx, eventID),
+			)
+		})
+		return nil
+	})
+
+	return res, err
+}
+
+// EventRevenue returns the gross sales of an event: the number of confirmed
+// orders, the tickets they contain and their total. An event without orders
+// yields zeros.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event.
+//
+// Returns:
+//   - *domain.EventRevenue: the revenue aggregates.
+//   - error: admin.ErrEventNotFound if the event does not exist.
+func (s *Service) EventRevenue(ctx context.Context, eventID int64) (*domain.EventRevenue, error) {
+	const op = "service.admin.EventRevenue"
+
+	rev, err := s.store.Query().EventRevenue(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rev, nil
+}
+
+// SetGACapacity sets how many general-admission tickets an event sells and
+// at what price. The capacity cannot drop below the tickets already held and
+// sold.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event.
+//   - capacity: total number of general-admission tickets.
+//   - priceCents: price of each general-admission ticket in cents.
+//
+// Returns:
+//   - *domain.GACapacity: the updated capacity and counters.
+//   - error: admin.ErrEventNotFound if the event does not exist.
+//   - error: admin.ErrGACapacityTooLow if capacity is below the tickets held and sold.
+func (s *Service) SetGACapacity(
+	ctx context.Context,
+	eventID int64,
+	capacity int,
+	priceCents int,
+) (*domain.GACapacity, error) {
+	const op = "service.admin.SetGACapacity"
+
+	if capacity < 0 || priceCents < 0 {
+		return nil, fmt.Errorf("%s: capacity and price must not be negative", op)
+	}
+
+	ga, err := s.store.Admin().SetGACapacity(ctx, eventID, capacity, priceCents)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+		if errors.Is(err, repository.ErrGACapacityTooLow) {
+			return nil, fmt.Errorf("%s: %w", op, ErrGACapacityTooLow)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ga, nil
+}
+
+// validateSeatingScheme parses raw as a domain.SeatingScheme, reporting
+// failures as InvalidSeatingSchemeError.
+func validateSeatingScheme(raw []byte) error {
+	if _, err := domain.ParseSeatingScheme(raw); err != nil {
+		return InvalidSeatingSchemeError{Reason: err.Error()}
+	}
+
+	return nil
+}
+
+// CreateAPIKey issues an API key for a server-to-server client. Only the
+// key's hash is stored, so the returned key cannot be retrieved again.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - clientName: name of the client the key is issued to.
+//   - scopes: scopes granted to the key.
+//
+// Returns:
+//   - *domain.APIKey: the stored key.
+//   - string: the key to hand to the client.
+//   - error: admin.ErrNoAPIKeyScopes if scopes is empty.
+//   - error: admin.ErrInvalidAPIKeyScope if a scope is unknown.
+func (s *Service) CreateAPIKey(
+	ctx context.Context,
+	clientName string,
+	scopes []domain.APIKeyScope,
+) (*domain.APIKey, string, error) {
+	const op = "service.admin.CreateAPIKey"
+
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("%s: %w", op, ErrNoAPIKeyScopes)
+	}
+
+	for _, sc := range scopes {
+		if sc != domain.ScopeRead && sc != domain.ScopeWrite {
+			return nil, "", fmt.Errorf("%s: %w", op, ErrInvalidAPIKeyScope)
+		}
+	}
+
+	key, hash, err := auth.NewAPIKey()
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	k, err := s.store.Admin().CreateAPIKey(ctx, clientName, hash, scopes)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return k, key, nil
+}
+
+// RevokeAPIKey revokes an API key; requests using it are rejected from then
+// on.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - id: ID of the key.
+//
+// Returns:
+//   - error: admin.ErrAPIKeyNotFound if the key does not exist or is already revoked.
+func (s *Service) RevokeAPIKey(ctx context.Context, id int64) error {
+	const op = "service.admin.RevokeAPIKey"
+
+	if err := s.store.Admin().RevokeAPIKey(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrAPIKeyNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// checkVenueSeats makes sure an event created at venueID gets seats.
+//
+// Returns:
+//   - error: admin.ErrVenueNotFound if the venue does not exist.
+//   - error: admin.ErrVenueHasNoSeats if the venue has no seats.
+func (s *Service) checkVenueSeats(ctx context.Context, tx postgresrepo.DB, venueID int64) error {
+	n, err := s.store.Query().With(tx).CountVenueSeats(ctx, venueID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrVenueNotFound
+		}
+		return err
+	}
+	if n == 0 {
+		return ErrVenueHasNoSeats
+	}
+
+	return nil
+}
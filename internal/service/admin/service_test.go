@@ -0,0 +1,441 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/postgres/pgtest"
+	postgresrepo "github.com/kirinyoku/tix-go/internal/repository/postgres"
+	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestService(t *testing.T) (*Service, *postgresrepo.Store, *pgxpool.Pool, *miniredis.Miniredis) {
+	t.Helper()
+
+	pool := pgtest.New(t)
+	store := postgresrepo.NewStore(pool)
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	return New(store, redisrepo.New(rdb), redisrepo.NewEventsPubSub(rdb)), store, pool, mr
+}
+
+func TestUpdateSeat(t *testing.T) {
+	svc, store, pool, _ := newTestService(t)
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+	ctx := context.Background()
+
+	held, sold := seatIDs[0], seatIDs[1]
+	if _, _, err := store.Reservations().HoldSeats(ctx, eventID, 1, []int64{held}, time.Minute); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	holdID, _, err := store.Reservations().HoldSeats(ctx, eventID, 1, []int64{sold}, time.Minute)
+	if err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if _, err := store.Reservations().ConfirmHold(ctx, holdID); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+
+	ptr := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		seatID  int64
+		row     *string
+		number  *int
+		wantErr error
+	}{
+		{name: "held", seatID: held, number: num(10), wantErr: ErrSeatBooked},
+		{name: "sold", seatID: sold, number: num(11), wantErr: ErrSeatBooked},
+		{name: "letter row", seatID: seatIDs[2], row: ptr("B"), wantErr: ErrInvalidSeatRow},
+		{name: "taken position", seatID: seatIDs[2], number: num(4), wantErr: ErrSeatConflict},
+		{name: "unknown", seatID: -1, number: num(12), wantErr: ErrSeatNotFound},
+		{name: "free", seatID: seatIDs[2], row: ptr("2"), number: num(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seat, err := svc.UpdateSeat(ctx, tt.seatID, nil, tt.row, tt.number)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (seat.Row != *tt.row || seat.Number != *tt.number) {
+				t.Errorf("seat = %+v, want row %s number %d", seat, *tt.row, *tt.number)
+			}
+		})
+	}
+}
+
+func TestUpdateEventTimes(t *testing.T) {
+	starts := time.Date(2030, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		ends time.Time
+	}{
+		{name: "ends before start", ends: starts.Add(-time.Minute)},
+		{name: "ends at start", ends: starts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The times are checked before the store is reached.
+			_, err := (&Service{}).UpdateEvent(context.Background(), 1, "x", starts, tt.ends, nil)
+			if !errors.Is(err, ErrInvalidEventTime) {
+				t.Errorf("err = %v, want %v", err, ErrInvalidEventTime)
+			}
+		})
+	}
+}
+
+func TestUpdateEventInvalidatesCache(t *testing.T) {
+	svc, _, pool, mr := newTestService(t)
+	eventID, _ := pgtest.SeedEvent(t, pool, 1, 1, 0)
+
+	keys := []string{
+		redisrepo.KeyEventSummary(eventID),
+		redisrepo.KeyEventAvailability(eventID),
+		redisrepo.KeyEventSeatMap(eventID),
+	}
+	for _, k := range keys {
+		if err := mr.Set(k, "stale"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	starts := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	e, err := svc.UpdateEvent(context.Background(), eventID, "Moved", starts, starts.Add(2*time.Hour), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Title != "Moved" || !e.Starts.Equal(starts) {
+		t.Errorf("event = %+v, want title Moved starting %v", e, starts)
+	}
+	for _, k := range keys {
+		if mr.Exists(k) {
+			t.Errorf("%s still cached", k)
+		}
+	}
+}
+
+func TestCancelEvent(t *testing.T) {
+	svc, store, pool, _ := newTestService(t)
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+	ctx := context.Background()
+
+	if _, _, err := store.Reservations().HoldSeats(ctx, eventID, 1, seatIDs[:2], time.Minute); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	holdID, _, err := store.Reservations().HoldSeats(ctx, eventID, 2, seatIDs[2:3], time.Minute)
+	if err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if _, err := store.Reservations().ConfirmHold(ctx, holdID); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+
+	got, err := svc.CancelEvent(ctx, eventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := domain.EventCancellation{ReleasedHolds: 1, ReleasedSeats: 2, AffectedOrders: 1}
+	if *got != want {
+		t.Errorf("got %+v, want %+v", *got, want)
+	}
+
+	statuses, err := store.Query().SeatStatuses(ctx, eventID, seatIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range []domain.SeatStatus{domain.SeatAvailable, domain.SeatAvailable, domain.SeatSold, domain.SeatAvailable} {
+		if statuses[seatIDs[i]] != s {
+			t.Errorf("seat %d: status %q, want %q", seatIDs[i], statuses[seatIDs[i]], s)
+		}
+	}
+
+	var cancelled bool
+	if err := pool.QueryRow(ctx,
+		`SELECT cancelled_at IS NOT NULL FROM events WHERE id = $1`, eventID,
+	).Scan(&cancelled); err != nil {
+		t.Fatal(err)
+	}
+	if !cancelled {
+		t.Error("event not flagged cancelled")
+	}
+
+	if _, err := svc.CancelEvent(ctx, eventID); !errors.Is(err, ErrEventAlreadyCancelled) {
+		t.Errorf("second cancel: err = %v, want %v", err, ErrEventAlreadyCancelled)
+	}
+	if _, err := svc.CancelEvent(ctx, -1); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("unknown event: err = %v, want %v", err, ErrEventNotFound)
+	}
+}
+
+func TestUpdateVenue(t *testing.T) {
+	svc, _, pool, _ := newTestService(t)
+	ctx := context.Background()
+
+	const scheme = `{"sections":[{"name":"A","rows":[{"name":"1","from":1,"to":10}]}]}`
+	var venueID int64
+	if err := pool.QueryRow(ctx,
+		`INSERT INTO venues (name, seating_scheme) VALUES ('Hall', $1) RETURNING id`, scheme,
+	).Scan(&venueID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Exec(ctx, `INSERT INTO venues (name) VALUES ('Taken')`); err != nil {
+		t.Fatal(err)
+	}
+
+	const moved = `{"sections":[{"name":"B","rows":[{"name":"1","from":1,"to":5}]}]}`
+	tests := []struct {
+		name       string
+		venueID    int64
+		venueName  string
+		scheme     string
+		wantErr    error
+		wantScheme string
+	}{
+		{name: "not json", venueID: venueID, venueName: "Hall", scheme: `{"sections":`, wantErr: ErrInvalidSeatingScheme},
+		{name: "bad structure", venueID: venueID, venueName: "Hall", scheme: `{"sections":[{"rows":[]}]}`, wantErr: ErrInvalidSeatingScheme},
+		{name: "unknown venue", venueID: -1, venueName: "Gone", scheme: moved, wantErr: ErrVenueNotFound},
+		{name: "name taken", venueID: venueID, venueName: "Taken", wantErr: ErrVenueConflict},
+		{name: "rename keeps scheme", venueID: venueID, venueName: "Hall 2", wantScheme: scheme},
+		{name: "new scheme", venueID: venueID, venueName: "Hall 3", scheme: moved, wantScheme: moved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := svc.UpdateVenue(ctx, tt.venueID, tt.venueName, []byte(tt.scheme), nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if v.Name != tt.venueName {
+				t.Errorf("Name = %q, want %q", v.Name, tt.venueName)
+			}
+			var got, want any
+			if err := json.Unmarshal(v.SeatingScheme, &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.wantScheme), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("SeatingScheme = %s, want %s", v.SeatingScheme, tt.wantScheme)
+			}
+		})
+	}
+
+	// A rejected update leaves the venue as it was.
+	var name string
+	if err := pool.QueryRow(ctx, `SELECT name FROM venues WHERE id = $1`, venueID).Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+	if name != "Hall 3" {
+		t.Errorf("stored name = %q, want Hall 3", name)
+	}
+}
+
+func TestGenerateSeatsFromScheme(t *testing.T) {
+	svc, _, pool, _ := newTestService(t)
+	ctx := context.Background()
+
+	const scheme = `{"sections":[
+		{"name":"A","rows":[{"name":"1","from":1,"to":4},{"name":"2","from":1,"to":3}]},
+		{"name":"B","rows":[{"name":"1","from":1,"to":2}]}
+	]}`
+	var venueID, emptyVenueID int64
+	if err := pool.QueryRow(ctx,
+		`INSERT INTO venues (name, seating_scheme) VALUES ('Hall', $1) RETURNING id`, scheme,
+	).Scan(&venueID); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.QueryRow(ctx,
+		`INSERT INTO venues (name) VALUES ('Empty') RETURNING id`,
+	).Scan(&emptyVenueID); err != nil {
+		t.Fatal(err)
+	}
+	// One seat exists before generation and must not be counted again.
+	if _, err := pool.Exec(ctx,
+		`INSERT INTO seats (venue_id, section, row, number) VALUES ($1, 'A', 1, 1)`, venueID,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	countSeats := func(venueID int64) int {
+		t.Helper()
+		var n int
+		if err := pool.QueryRow(ctx, `SELECT count(*) FROM seats WHERE venue_id = $1`, venueID).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	created, err := svc.GenerateSeatsFromScheme(ctx, venueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != 8 {
+		t.Errorf("first run created %d seats, want 8", created)
+	}
+	if n := countSeats(venueID); n != 9 {
+		t.Errorf("after first run: %d seats, want 9", n)
+	}
+
+	created, err = svc.GenerateSeatsFromScheme(ctx, venueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != 0 {
+		t.Errorf("second run created %d seats, want 0", created)
+	}
+	if n := countSeats(venueID); n != 9 {
+		t.Errorf("after second run: %d seats, want 9", n)
+	}
+
+	created, err = svc.GenerateSeatsFromScheme(ctx, emptyVenueID)
+	if err != nil || created != 0 {
+		t.Errorf("empty scheme: created %d, err %v; want 0, nil", created, err)
+	}
+
+	if _, err := svc.GenerateSeatsFromScheme(ctx, -1); !errors.Is(err, ErrVenueNotFound) {
+		t.Errorf("unknown venue: err = %v, want %v", err, ErrVenueNotFound)
+	}
+}
+
+func TestCreateRecurringEvents(t *testing.T) {
+	svc, _, pool, _ := newTestService(t)
+	seedID, _ := pgtest.SeedEvent(t, pool, 2, 3, 0)
+	ctx := context.Background()
+
+	var venueID int64
+	if err := pool.QueryRow(ctx, `SELECT venue_id FROM events WHERE id = $1`, seedID).Scan(&venueID); err != nil {
+		t.Fatal(err)
+	}
+	// Events starting after 2100 are rejected by the database, so a slot
+	// there fails after the earlier slots have been written.
+	if _, err := pool.Exec(ctx,
+		`ALTER TABLE events ADD CONSTRAINT test_starts_before_2100 CHECK (starts_at < '2100-01-01')`,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	slot := func(year int) TimeSlot {
+		starts := time.Date(year, 1, 1, 18, 0, 0, 0, time.UTC)
+		return TimeSlot{StartsAt: starts, EndsAt: starts.Add(2 * time.Hour)}
+	}
+	count := func(t *testing.T) (events, seats int) {
+		t.Helper()
+		if err := pool.QueryRow(ctx,
+			`SELECT (SELECT count(*) FROM events WHERE id <> $1),
+			        (SELECT count(*) FROM event_seats WHERE event_id <> $1)`,
+			seedID,
+		).Scan(&events, &seats); err != nil {
+			t.Fatal(err)
+		}
+		return events, seats
+	}
+
+	t.Run("one slot fails", func(t *testing.T) {
+		_, err := svc.CreateRecurringEvents(ctx, venueID, "Show", []TimeSlot{slot(2030), slot(2031), slot(2101)}, 1500)
+		if err == nil {
+			t.Fatal("err = nil, want the failing slot's error")
+		}
+		if events, seats := count(t); events != 0 || seats != 0 {
+			t.Errorf("after failure: %d events and %d seats written, want none", events, seats)
+		}
+	})
+
+	t.Run("all slots succeed", func(t *testing.T) {
+		ids, err := svc.CreateRecurringEvents(ctx, venueID, "Show", []TimeSlot{slot(2030), slot(2031)}, 1500)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 2 {
+			t.Fatalf("created %d events, want 2", len(ids))
+		}
+		for _, id := range ids {
+			var seats, priced int
+			if err := pool.QueryRow(ctx,
+				`SELECT count(*), count(*) FILTER (WHERE price_cents = 1500)
+				 FROM event_seats WHERE event_id = $1 AND status = 'available'`,
+				id,
+			).Scan(&seats, &priced); err != nil {
+				t.Fatal(err)
+			}
+			if seats != 6 || priced != 6 {
+				t.Errorf("event %d: %d available seats, %d at 1500, want 6 each", id, seats, priced)
+			}
+		}
+	})
+}
+
+func TestCreateEventWithInitChecksSeats(t *testing.T) {
+	svc, _, pool, _ := newTestService(t)
+	seedID, _ := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	ctx := context.Background()
+
+	var seatedID int64
+	if err := pool.QueryRow(ctx, `SELECT venue_id FROM events WHERE id = $1`, seedID).Scan(&seatedID); err != nil {
+		t.Fatal(err)
+	}
+	emptyID, err := svc.CreateVenue(ctx, "Empty Hall", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	starts := time.Date(2030, 1, 1, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		venueID    int64
+		dryRun     bool
+		wantErr    error
+		wantEvents int
+	}{
+		{name: "empty venue", venueID: emptyID, wantErr: ErrVenueHasNoSeats},
+		{name: "empty venue dry run", venueID: emptyID, dryRun: true, wantErr: ErrVenueHasNoSeats},
+		{name: "unknown venue dry run", venueID: -1, dryRun: true, wantErr: ErrVenueNotFound},
+		{name: "dry run", venueID: seatedID, dryRun: true},
+		{name: "create", venueID: seatedID, wantEvents: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := pool.Exec(ctx, `DELETE FROM events WHERE id <> $1`, seedID); err != nil {
+				t.Fatal(err)
+			}
+
+			id, err := svc.CreateEventWithInit(ctx, tt.venueID, "Show", starts, starts.Add(2*time.Hour), 1000, tt.dryRun)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantEvents == 0 && id != 0 {
+				t.Errorf("event ID = %d, want 0", id)
+			}
+
+			var events, seats int
+			if err := pool.QueryRow(ctx,
+				`SELECT (SELECT count(*) FROM events WHERE id <> $1),
+				        (SELECT count(*) FROM event_seats WHERE event_id <> $1)`,
+				seedID,
+			).Scan(&events, &seats); err != nil {
+				t.Fatal(err)
+			}
+			if events != tt.wantEvents {
+				t.Errorf("events = %d, want %d", events, tt.wantEvents)
+			}
+			if wantSeats := tt.wantEvents * 2; seats != wantSeats {
+				t.Errorf("event seats = %d, want %d", seats, wantSeats)
+			}
+		})
+	}
+}
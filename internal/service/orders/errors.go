@@ -3,5 +3,10 @@ package orders
 import "errors"
 
 var (
-	ErrOrderNotFound = errors.New("order not found")
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrOrderNotOwned         = errors.New("order belongs to another user")
+	ErrOrderAlreadyRefunded  = errors.New("order already refunded")
+	ErrTicketNotFound        = errors.New("ticket not found")
+	ErrTicketNotOwned        = errors.New("ticket belongs to another user")
+	ErrTicketSigningDisabled = errors.New("ticket signing is not configured")
 )
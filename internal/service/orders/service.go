@@ -5,17 +5,43 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/kirinyoku/tix-go/internal/domain"
 	"github.com/kirinyoku/tix-go/internal/repository"
 	postgresrepo "github.com/kirinyoku/tix-go/internal/repository/postgres"
+	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+	"github.com/kirinyoku/tix-go/internal/ticket"
+	"github.com/kirinyoku/tix-go/internal/uow"
 )
 
+type Config struct {
+	// TicketSecret is the HMAC key used to sign tickets. When empty, ticket
+	// signing and verification are disabled.
+	TicketSecret []byte
+}
+
 type Service struct {
-	store *postgresrepo.Store
+	store  *postgresrepo.Store
+	cache  *redisrepo.Cache
+	pubsub *redisrepo.EventsPubSub
+	uow    *uow.UoW
+	cfg    Config
 }
 
-func New(store *postgresrepo.Store) *Service {
-	return &Service{store: store}
+func New(
+	store *postgresrepo.Store,
+	cache *redisrepo.Cache,
+	pubsub *redisrepo.EventsPubSub,
+	cfg Config,
+	uowOpts ...uow.Option,
+) *Service {
+	return &Service{
+		store:  store,
+		cache:  cache,
+		pubsub: pubsub,
+		uow:    uow.NewUoW(store, uowOpts...),
+		cfg:    cfg,
+	}
 }
 
 // GetOrderWithTickets retrieves an order along with its associated tickets.
@@ -23,11 +49,13 @@ func New(store *postgresrepo.Store) *Service {
 // Parameters:
 //   - ctx: request-scoped context.
 //   - orderID: ID of the order to retrieve.
+//   - userID: ID of the requesting user; it must be the user who placed the order.
 //
 // Returns:
 //   - *domain.OrderWithTickets: the retrieved order with tickets, or nil if not found.
 //   - error: orders.ErrOrderNotFound if the order is not found.
-func (s *Service) GetOrderWithTickets(ctx context.Context, orderID string) (*domain.OrderWithTickets, error) {
+//   - error: orders.ErrOrderNotOwned if the order was placed by another user.
+func (s *Service) GetOrderWithTickets(ctx context.Context, orderID string, userID int64) (*domain.OrderWithTickets, error) {
 	const op = "service.orders.GetOrderWithTickets"
 
 	o, err := s.store.Query().GetOrderWithTickets(ctx, orderID)
@@ -39,5 +67,218 @@ func (s *Service) GetOrderWithTickets(ctx context.Context, orderID string) (*dom
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if o.Order.UserID != userID {
+		return nil, fmt.Errorf("%s: %w", op, ErrOrderNotOwned)
+	}
+
 	return o, nil
 }
+
+// RefundOrder refunds a confirmed order and returns its seats to the pool.
+// On success the event cache is invalidated and an event-changed
+// notification is published.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - orderID: ID of the order to refund.
+//   - userID: ID of the user requesting the refund; it must be the user who placed the order.
+//
+// Returns:
+//   - int64: number of seats released.
+//   - error: orders.ErrOrderNotFound if the order is not found.
+//   - error: orders.ErrOrderNotOwned if the order was placed by another user.
+//   - error: orders.ErrOrderAlreadyRefunded if the order was refunded before.
+func (s *Service) RefundOrder(ctx context.Context, orderID uuid.UUID, userID int64) (int64, error) {
+	const op = "service.orders.RefundOrder"
+
+	var released int64
+
+	err := s.uow.Do(ctx, func(
+		ctx context.Context,
+		tx postgresrepo.DB,
+		after func(uow.AfterCommit),
+	) error {
+		owner, err := s.store.Query().With(tx).OrderOwner(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+			}
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if owner != userID {
+			return fmt.Errorf("%s: %w", op, ErrOrderNotOwned)
+		}
+
+		eventID, n, err := s.store.Reservations().With(tx).RefundOrder(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+			}
+			if errors.Is(err, repository.ErrAlreadyRefunded) {
+				return fmt.Errorf("%s: %w", op, ErrOrderAlreadyRefunded)
+			}
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		released = n
+
+		after(func(ctx context.Context) error {
+			return errors.Join(
+				s.cache.InvalidateEvent(ctx, eventID),
+				s.pubsub.PublishEventChanged(ctx, eventID),
+			)
+		})
+		return nil
+	})
+
+	return released, err
+}
+
+// GetTicket retrieves a ticket together with its signature.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - ticketID: ID of the ticket to retrieve.
+//   - userID: ID of the requesting user; it must be the user who placed the order.
+//
+// Returns:
+//   - *domain.Ticket: the ticket.
+//   - string: hex-encoded HMAC signature of the ticket.
+//   - error: orders.ErrTicketNotFound if the ticket is not found.
+//   - error: orders.ErrTicketNotOwned if the ticket's order was placed by another user.
+//   - error: orders.ErrTicketSigningDisabled if no signing secret is configured.
+func (s *Service) GetTicket(ctx context.Context, ticketID uuid.UUID, userID int64) (*domain.Ticket, string, error) {
+	const op = "service.orders.GetTicket"
+
+	t, err := s.getOwnTicket(ctx, ticketID, userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return t, ticket.Sign(*t, s.cfg.TicketSecret), nil
+}
+
+// GetTicketToken returns the compact signed token of a ticket, as encoded in
+// its QR code.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - ticketID: ID of the ticket.
+//   - userID: ID of the requesting user; it must be the user who placed the order.
+//
+// Returns:
+//   - string: the signed ticket token.
+//   - error: orders.ErrTicketNotFound if the ticket is not found.
+//   - error: orders.ErrTicketNotOwned if the ticket's order was placed by another user.
+//   - error: orders.ErrTicketSigningDisabled if no signing secret is configured.
+func (s *Service) GetTicketToken(ctx context.Context, ticketID uuid.UUID, userID int64) (string, error) {
+	const op = "service.orders.GetTicketToken"
+
+	t, err := s.getOwnTicket(ctx, ticketID, userID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ticket.EncodeTicketToken(*t, s.cfg.TicketSecret), nil
+}
+
+// VerifyTicketToken checks a token scanned from a ticket QR code: its HMAC
+// must be valid and it must describe a ticket that still exists. Tickets of
+// refunded orders no longer exist and are reported as not found.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - token: the token from GetTicketToken.
+//
+// Returns:
+//   - *domain.Ticket: the stored ticket when the token is valid, nil otherwise.
+//   - bool: whether the token is valid.
+//   - error: orders.ErrTicketNotFound if the ticket is not found.
+//   - error: orders.ErrTicketSigningDisabled if no signing secret is configured.
+func (s *Service) VerifyTicketToken(ctx context.Context, token string) (*domain.Ticket, bool, error) {
+	const op = "service.orders.VerifyTicketToken"
+
+	if len(s.cfg.TicketSecret) == 0 {
+		return nil, false, fmt.Errorf("%s: %w", op, ErrTicketSigningDisabled)
+	}
+
+	claimed, err := ticket.DecodeTicketToken(token, s.cfg.TicketSecret)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	t, err := s.getTicket(ctx, claimed.ID)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if t.OrderID != claimed.OrderID || t.EventID != claimed.EventID ||
+		t.SeatID != claimed.SeatID || !t.Created.Equal(claimed.Created) {
+		return nil, false, nil
+	}
+
+	return t, true, nil
+}
+
+// VerifyTicket checks a signature against the stored ticket. Tickets of
+// refunded orders no longer exist and are reported as not found.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - ticketID: ID of the ticket to verify.
+//   - signature: hex-encoded signature presented at the gate.
+//
+// Returns:
+//   - bool: whether the signature is valid for the ticket.
+//   - error: orders.ErrTicketNotFound if the ticket is not found.
+//   - error: orders.ErrTicketSigningDisabled if no signing secret is configured.
+func (s *Service) VerifyTicket(ctx context.Context, ticketID uuid.UUID, signature string) (bool, error) {
+	const op = "service.orders.VerifyTicket"
+
+	t, err := s.getTicket(ctx, ticketID)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ticket.Verify(*t, s.cfg.TicketSecret, signature), nil
+}
+
+func (s *Service) getTicket(ctx context.Context, ticketID uuid.UUID) (*domain.Ticket, error) {
+	if len(s.cfg.TicketSecret) == 0 {
+		return nil, ErrTicketSigningDisabled
+	}
+
+	t, err := s.store.Orders().GetTicket(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTicketNotFound
+		}
+
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *Service) getOwnTicket(ctx context.Context, ticketID uuid.UUID, userID int64) (*domain.Ticket, error) {
+	t, err := s.getTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.store.Query().OrderOwner(ctx, t.OrderID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTicketNotFound
+		}
+
+		return nil, err
+	}
+
+	if owner != userID {
+		return nil, ErrTicketNotOwned
+	}
+
+	return t, nil
+}
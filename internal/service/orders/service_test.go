@@ -0,0 +1,185 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kirinyoku/tix-go/internal/postgres/pgtest"
+	postgresrepo "github.com/kirinyoku/tix-go/internal/repository/postgres"
+	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestService(t *testing.T) (*Service, *postgresrepo.Store, *pgxpool.Pool) {
+	t.Helper()
+
+	pool := pgtest.New(t)
+	store := postgresrepo.NewStore(pool)
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	svc := New(store, redisrepo.New(rdb), redisrepo.NewEventsPubSub(rdb), Config{
+		TicketSecret: []byte("ticket-secret"),
+	})
+
+	return svc, store, pool
+}
+
+// confirmOrder holds seatIDs for userID and confirms the hold into an order.
+func confirmOrder(t *testing.T, store *postgresrepo.Store, eventID, userID int64, seatIDs []int64) uuid.UUID {
+	t.Helper()
+
+	ctx := context.Background()
+	holdID, _, err := store.Reservations().HoldSeats(ctx, eventID, userID, seatIDs, time.Minute)
+	if err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	orderID, err := store.Reservations().ConfirmHold(ctx, holdID)
+	if err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+
+	return orderID
+}
+
+func TestRefundOrder(t *testing.T) {
+	svc, store, pool := newTestService(t)
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+	orderID := confirmOrder(t, store, eventID, 1, seatIDs[:2])
+
+	tests := []struct {
+		name         string
+		orderID      uuid.UUID
+		userID       int64
+		wantReleased int64
+		wantErr      error
+	}{
+		{name: "unknown order", orderID: uuid.New(), userID: 1, wantErr: ErrOrderNotFound},
+		{name: "other user", orderID: orderID, userID: 2, wantErr: ErrOrderNotOwned},
+		{name: "owner", orderID: orderID, userID: 1, wantReleased: 2},
+		{name: "again", orderID: orderID, userID: 1, wantErr: ErrOrderAlreadyRefunded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			released, err := svc.RefundOrder(context.Background(), tt.orderID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if released != tt.wantReleased {
+				t.Errorf("released = %d, want %d", released, tt.wantReleased)
+			}
+		})
+	}
+}
+
+func TestGetOrderWithTicketsOwnership(t *testing.T) {
+	svc, store, pool := newTestService(t)
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	orderID := confirmOrder(t, store, eventID, 1, seatIDs)
+
+	tests := []struct {
+		name    string
+		orderID string
+		userID  int64
+		wantErr error
+	}{
+		{name: "owner", orderID: orderID.String(), userID: 1},
+		{name: "other user", orderID: orderID.String(), userID: 2, wantErr: ErrOrderNotOwned},
+		{name: "unknown", orderID: uuid.NewString(), userID: 1, wantErr: ErrOrderNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := svc.GetOrderWithTickets(context.Background(), tt.orderID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && len(o.Tickets) != len(seatIDs) {
+				t.Errorf("tickets = %d, want %d", len(o.Tickets), len(seatIDs))
+			}
+		})
+	}
+}
+
+func TestTicketOwnership(t *testing.T) {
+	svc, store, pool := newTestService(t)
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 1, 0)
+	orderID := confirmOrder(t, store, eventID, 1, seatIDs)
+
+	o, err := svc.GetOrderWithTickets(context.Background(), orderID.String(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ticketID := o.Tickets[0].ID
+
+	tests := []struct {
+		name     string
+		ticketID uuid.UUID
+		userID   int64
+		wantErr  error
+	}{
+		{name: "owner", ticketID: ticketID, userID: 1},
+		{name: "other user", ticketID: ticketID, userID: 2, wantErr: ErrTicketNotOwned},
+		{name: "unknown", ticketID: uuid.New(), userID: 1, wantErr: ErrTicketNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := svc.GetTicket(context.Background(), tt.ticketID, tt.userID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetTicket err = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := svc.GetTicketToken(context.Background(), tt.ticketID, tt.userID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetTicketToken err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyTicketToken(t *testing.T) {
+	svc, store, pool := newTestService(t)
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	kept := confirmOrder(t, store, eventID, 1, seatIDs[:1])
+	refunded := confirmOrder(t, store, eventID, 1, seatIDs[1:])
+
+	token := func(orderID uuid.UUID) string {
+		o, err := svc.GetOrderWithTickets(context.Background(), orderID.String(), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tok, err := svc.GetTicketToken(context.Background(), o.Tickets[0].ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tok
+	}
+	keptToken, refundedToken := token(kept), token(refunded)
+	if _, err := svc.RefundOrder(context.Background(), refunded, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		token     string
+		wantValid bool
+		wantErr   error
+	}{
+		{name: "valid", token: keptToken, wantValid: true},
+		{name: "tampered", token: "x" + keptToken},
+		{name: "garbage", token: "not-a-token"},
+		{name: "refunded", token: refundedToken, wantErr: ErrTicketNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk, valid, err := svc.VerifyTicketToken(context.Background(), tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if valid != tt.wantValid || (tk != nil) != tt.wantValid {
+				t.Errorf("valid = %v, ticket = %v, want valid %v", valid, tk, tt.wantValid)
+			}
+		})
+	}
+}
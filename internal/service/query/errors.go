@@ -5,6 +5,13 @@ import (
 )
 
 var (
-	ErrEventNotFound = errors.New("event not found")
-	ErrOrderNotFound = errors.New("order not found")
+	ErrEventNotFound    = errors.New("event not found")
+	ErrOrderNotFound    = errors.New("order not found")
+	ErrVenueNotFound    = errors.New("venue not found")
+	ErrTooManyEvents    = errors.New("too many events requested")
+	ErrTooManyOrders    = errors.New("too many orders requested")
+	ErrInvalidTimeRange = errors.New("from must not be after to")
+	ErrTimeRangeTooWide = errors.New("time range too wide")
+	ErrInvalidAPIKey    = errors.New("invalid api key")
+	ErrAPIKeyRevoked    = errors.New("api key revoked")
 )
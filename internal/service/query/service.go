@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/kirinyoku/tix-go/internal/auth"
 	"github.com/kirinyoku/tix-go/internal/domain"
 	"github.com/kirinyoku/tix-go/internal/repository"
 	postgresrepo "github.com/kirinyoku/tix-go/internal/repository/postgres"
@@ -15,10 +17,26 @@ import (
 type Config struct {
 	EventSummaryTTL   time.Duration
 	AvailabilityTTL   time.Duration
+	NegativeTTL       time.Duration
 	DefaultSeatsPage  int
 	MaxSeatsPage      int
 	CacheEventSeatMap bool
 	EventSeatMapTTL   time.Duration
+	DefaultEventsPage int
+	MaxEventsPage     int
+	DefaultOrdersPage int
+	MaxOrdersPage     int
+	DefaultVenuesPage int
+	MaxVenuesPage     int
+	DefaultHoldsPage  int
+	MaxHoldsPage      int
+	DefaultLogPage    int
+	MaxLogPage        int
+	MaxBatchEvents    int
+	MaxBatchOrders    int
+	// MaxOrdersWindow caps the created-at range of ListOrders. Defaults to
+	// 31 days.
+	MaxOrdersWindow time.Duration
 }
 
 type Service struct {
@@ -36,6 +54,10 @@ func New(store *postgresrepo.Store, cache *redisrepo.Cache, cfg Config) *Service
 		cfg.AvailabilityTTL = 15 * time.Second
 	}
 
+	if cfg.NegativeTTL <= 0 {
+		cfg.NegativeTTL = 10 * time.Second
+	}
+
 	if cfg.DefaultSeatsPage <= 0 {
 		cfg.DefaultSeatsPage = 100
 	}
@@ -48,6 +70,58 @@ func New(store *postgresrepo.Store, cache *redisrepo.Cache, cfg Config) *Service
 		cfg.EventSeatMapTTL = 60 * time.Second
 	}
 
+	if cfg.DefaultEventsPage <= 0 {
+		cfg.DefaultEventsPage = 50
+	}
+
+	if cfg.MaxEventsPage <= 0 {
+		cfg.MaxEventsPage = 200
+	}
+
+	if cfg.DefaultOrdersPage <= 0 {
+		cfg.DefaultOrdersPage = 20
+	}
+
+	if cfg.MaxOrdersPage <= 0 {
+		cfg.MaxOrdersPage = 100
+	}
+
+	if cfg.DefaultVenuesPage <= 0 {
+		cfg.DefaultVenuesPage = 50
+	}
+
+	if cfg.MaxVenuesPage <= 0 {
+		cfg.MaxVenuesPage = 200
+	}
+
+	if cfg.DefaultHoldsPage <= 0 {
+		cfg.DefaultHoldsPage = 50
+	}
+
+	if cfg.MaxHoldsPage <= 0 {
+		cfg.MaxHoldsPage = 200
+	}
+
+	if cfg.DefaultLogPage <= 0 {
+		cfg.DefaultLogPage = 100
+	}
+
+	if cfg.MaxLogPage <= 0 {
+		cfg.MaxLogPage = 500
+	}
+
+	if cfg.MaxBatchEvents <= 0 {
+		cfg.MaxBatchEvents = 100
+	}
+
+	if cfg.MaxBatchOrders <= 0 {
+		cfg.MaxBatchOrders = 50
+	}
+
+	if cfg.MaxOrdersWindow <= 0 {
+		cfg.MaxOrdersWindow = 31 * 24 * time.Hour
+	}
+
 	return &Service{
 		store: store,
 		cache: cache,
@@ -55,7 +129,32 @@ func New(store *postgresrepo.Store, cache *redisrepo.Cache, cfg Config) *Service
 	}
 }
 
+// GetVenue retrieves a venue by its ID.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - id: ID of the venue to retrieve.
+//
+// Returns:
+//   - *domain.Venue: the retrieved venue, or nil if not found.
+//   - error: query.ErrVenueNotFound if the venue is not found.
+func (s *Service) GetVenue(ctx context.Context, id int64) (*domain.Venue, error) {
+	const op = "service.query.GetVenue"
+
+	v, err := s.store.Query().GetVenue(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrVenueNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return v, nil
+}
+
 // GetEvent retrieves an event by its ID, utilizing a caching layer to improve performance.
+// Unknown IDs are cached for Config.NegativeTTL so repeated misses do not hit Postgres.
 //
 // Parameters:
 //   - ctx: request-scoped context.
@@ -69,11 +168,13 @@ func (s *Service) GetEvent(ctx context.Context, id int64) (*domain.Event, error)
 
 	key := redisrepo.KeyEventSummary(id)
 
-	event, err := redisrepo.GetOrSetJSON(
+	event, err := redisrepo.GetOrSetJSONNegative(
 		ctx,
 		s.cache,
 		key,
 		s.cfg.EventSummaryTTL,
+		s.cfg.NegativeTTL,
+		ErrEventNotFound,
 		func(ctx context.Context) (domain.Event, error) {
 			e, err := s.store.Query().GetEvent(ctx, id)
 			if err != nil {
@@ -94,6 +195,71 @@ func (s *Service) GetEvent(ctx context.Context, id int64) (*domain.Event, error)
 	return &event, nil
 }
 
+// ListEvents retrieves a page of events ordered by their start time.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - limit: maximum number of events to return (default and max limits are enforced).
+//   - offset: number of events to skip for pagination.
+//
+// Returns:
+//   - []domain.Event: list of events, empty if there are none.
+//   - int: the effective page size after clamping.
+//   - error: if the events could not be listed.
+func (s *Service) ListEvents(ctx context.Context, limit, offset int) ([]domain.Event, int, error) {
+	return s.ListEventsFiltered(ctx, nil, nil, nil, limit, offset)
+}
+
+// ListEventsFiltered retrieves a page of events ordered by their start time,
+// optionally limited to one venue and to events starting within [from, to].
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - venueID: only events at this venue, if set.
+//   - from, to: bounds on the event start time, if set.
+//   - limit: maximum number of events to return (default and max limits are enforced).
+//   - offset: number of events to skip for pagination.
+//
+// Returns:
+//   - []domain.Event: list of events, empty if there are none.
+//   - int: the effective page size after clamping.
+//   - error: query.ErrInvalidTimeRange if from is after to.
+func (s *Service) ListEventsFiltered(
+	ctx context.Context,
+	venueID *int64,
+	from, to *time.Time,
+	limit, offset int,
+) ([]domain.Event, int, error) {
+	const op = "service.query.ListEventsFiltered"
+
+	if from != nil && to != nil && from.After(*to) {
+		return nil, 0, fmt.Errorf("%s: %w", op, ErrInvalidTimeRange)
+	}
+
+	if limit <= 0 {
+		limit = s.cfg.DefaultEventsPage
+	}
+
+	if limit > s.cfg.MaxEventsPage {
+		limit = s.cfg.MaxEventsPage
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	events, err := s.store.Query().ListEventsFiltered(ctx, venueID, from, to, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if events == nil {
+		events = []domain.Event{}
+	}
+
+	return events, limit, nil
+}
+
 // CountByStatus retrieves the count of seats by their status for a specific event.
 //
 // Parameters:
@@ -133,6 +299,125 @@ func (s *Service) CountsByStatus(ctx context.Context, eventID int64) (*domain.Ev
 	return &eventCounts, nil
 }
 
+// CountsBySection retrieves per-section seat counts for an event, cached
+// alongside the event totals for Config.AvailabilityTTL.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event.
+//
+// Returns:
+//   - []domain.SectionCounts: counts per section, empty if the event has no seats.
+//   - error: if the counts could not be loaded.
+func (s *Service) CountsBySection(ctx context.Context, eventID int64) ([]domain.SectionCounts, error) {
+	const op = "service.query.CountsBySection"
+
+	key := redisrepo.KeyEventSectionAvailability(eventID)
+
+	counts, err := redisrepo.GetOrSetJSON(
+		ctx,
+		s.cache,
+		key,
+		s.cfg.AvailabilityTTL,
+		func(ctx context.Context) ([]domain.SectionCounts, error) {
+			sc, err := s.store.Query().CountsBySection(ctx, eventID)
+			if err != nil {
+				return nil, err
+			}
+
+			if sc == nil {
+				sc = []domain.SectionCounts{}
+			}
+
+			return sc, nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return counts, nil
+}
+
+// CountsByStatusBatch retrieves seat counts for several events at once.
+// Duplicate IDs are collapsed; unknown events are omitted from the result.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventIDs: IDs of the events, at most Config.MaxBatchEvents distinct ones.
+//
+// Returns:
+//   - map[int64]domain.EventCounts: counts keyed by event ID.
+//   - error: query.ErrTooManyEvents if the cap is exceeded.
+func (s *Service) CountsByStatusBatch(
+	ctx context.Context,
+	eventIDs []int64,
+) (map[int64]domain.EventCounts, error) {
+	const op = "service.query.CountsByStatusBatch"
+
+	seen := make(map[int64]struct{}, len(eventIDs))
+	ids := make([]int64, 0, len(eventIDs))
+	for _, id := range eventIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	if len(ids) > s.cfg.MaxBatchEvents {
+		return nil, fmt.Errorf("%s: %w", op, ErrTooManyEvents)
+	}
+
+	counts, err := s.store.Query().CountsByStatusBatch(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return counts, nil
+}
+
+// TicketsByOrders retrieves the tickets of several orders of a user at once.
+// Duplicate IDs are collapsed; unknown orders, orders of other users and
+// orders without tickets are omitted from the result.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - orderIDs: IDs of the orders, at most Config.MaxBatchOrders distinct ones.
+//   - userID: the user asking; only their orders are returned.
+//
+// Returns:
+//   - map[uuid.UUID][]domain.Ticket: tickets keyed by order ID.
+//   - error: query.ErrTooManyOrders if the cap is exceeded.
+func (s *Service) TicketsByOrders(
+	ctx context.Context,
+	orderIDs []uuid.UUID,
+	userID int64,
+) (map[uuid.UUID][]domain.Ticket, error) {
+	const op = "service.query.TicketsByOrders"
+
+	seen := make(map[uuid.UUID]struct{}, len(orderIDs))
+	ids := make([]uuid.UUID, 0, len(orderIDs))
+	for _, id := range orderIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	if len(ids) > s.cfg.MaxBatchOrders {
+		return nil, fmt.Errorf("%s: %w", op, ErrTooManyOrders)
+	}
+
+	tickets, err := s.store.Query().TicketsByOrders(ctx, ids, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tickets, nil
+}
+
 // ListEventSeats retrieves a list of seats for a specific event, with optional filtering
 // for only available seats. Pagination is supported via limit and offset parameters.
 //
@@ -145,25 +430,203 @@ func (s *Service) CountsByStatus(ctx context.Context, eventID int64) (*domain.Ev
 //
 // Returns:
 //   - []domain.SeatWithStatus: list of seats with their status.
+//   - int: the effective page size after clamping.
 //   - error: query.ErrEventNotFound if the event is not found.
 func (s *Service) ListEventSeats(
 	ctx context.Context,
 	eventID int64,
 	onlyAvailable bool,
 	limit, offset int,
-) ([]domain.SeatWithStatus, error) {
+) ([]domain.SeatWithStatus, int, error) {
 	const op = "service.query.ListEventSeats"
 
+	limit = s.seatsPageSize(limit)
+
+	seats, err := s.store.Query().ListEventSeats(ctx, eventID, onlyAvailable, limit, offset)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, 0, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return seats, limit, nil
+}
+
+// ListEventSeatsPage is like ListEventSeats but also reports the total number
+// of matching seats, so clients can build page controls.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event to list seats for.
+//   - onlyAvailable: if true, only seats with 'available' status are returned.
+//   - limit: maximum number of seats to return (default and max limits are enforced).
+//   - offset: number of seats to skip for pagination.
+//
+// Returns:
+//   - []domain.SeatWithStatus: list of seats with their status.
+//   - int64: total number of matching seats, ignoring limit and offset.
+//   - int: the effective page size after clamping.
+//   - error: query.ErrEventNotFound if the event is not found.
+func (s *Service) ListEventSeatsPage(
+	ctx context.Context,
+	eventID int64,
+	onlyAvailable bool,
+	limit, offset int,
+) ([]domain.SeatWithStatus, int64, int, error) {
+	const op = "service.query.ListEventSeatsPage"
+
+	seats, limit, err := s.ListEventSeats(ctx, eventID, onlyAvailable, limit, offset)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	total, err := s.store.Query().CountEventSeats(ctx, eventID, onlyAvailable)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if seats == nil {
+		seats = []domain.SeatWithStatus{}
+	}
+
+	return seats, total, limit, nil
+}
+
+// ListEventSeatsAfter retrieves a page of seats for an event using keyset
+// pagination over (section, row, number).
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event to list seats for.
+//   - onlyAvailable: if true, only seats with 'available' status are returned.
+//   - after: last seat of the previous page, or nil for the first page.
+//   - limit: maximum number of seats to return (default and max limits are enforced).
+//
+// Returns:
+//   - []domain.SeatWithStatus: list of seats with their status.
+//   - int: the effective page size after clamping.
+//   - error: if the seats could not be listed.
+func (s *Service) ListEventSeatsAfter(
+	ctx context.Context,
+	eventID int64,
+	onlyAvailable bool,
+	after *domain.Seat,
+	limit int,
+) ([]domain.SeatWithStatus, int, error) {
+	const op = "service.query.ListEventSeatsAfter"
+
+	limit = s.seatsPageSize(limit)
+
+	var seats []domain.SeatWithStatus
+	var err error
+
+	if after == nil {
+		seats, err = s.store.Query().ListEventSeats(ctx, eventID, onlyAvailable, limit, 0)
+	} else {
+		seats, err = s.store.Query().ListEventSeatsAfter(
+			ctx,
+			eventID,
+			onlyAvailable,
+			after.Section,
+			after.Row,
+			after.Number,
+			limit,
+		)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if seats == nil {
+		seats = []domain.SeatWithStatus{}
+	}
+
+	return seats, limit, nil
+}
+
+// seatsPageSize applies the default and maximum seat page sizes to limit.
+func (s *Service) seatsPageSize(limit int) int {
 	if limit <= 0 {
-		limit = s.cfg.DefaultSeatsPage
+		return s.cfg.DefaultSeatsPage
 	}
 
-	if limit > s.cfg.MaxSeatsPage {
-		limit = s.cfg.MaxSeatsPage
+	return min(limit, s.cfg.MaxSeatsPage)
+}
+
+// GetSeatMap retrieves every seat of an event together with its status.
+// When Config.CacheEventSeatMap is enabled the result is cached for
+// Config.EventSeatMapTTL and dropped whenever the event is invalidated.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event.
+//
+// Returns:
+//   - []domain.SeatWithStatus: all seats of the event, empty if it has none.
+//   - error: query.ErrEventNotFound if the event is not found.
+func (s *Service) GetSeatMap(ctx context.Context, eventID int64) ([]domain.SeatWithStatus, error) {
+	const op = "service.query.GetSeatMap"
+
+	load := func(ctx context.Context) ([]domain.SeatWithStatus, error) {
+		if _, err := s.store.Query().GetEvent(ctx, eventID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrEventNotFound
+			}
+
+			return nil, err
+		}
+
+		seats, err := s.store.Query().SeatMap(ctx, eventID)
+		if err != nil {
+			return nil, err
+		}
+
+		if seats == nil {
+			seats = []domain.SeatWithStatus{}
+		}
+
+		return seats, nil
 	}
 
-	seats, err := s.store.Query().ListEventSeats(ctx, eventID, onlyAvailable, limit, offset)
+	var seats []domain.SeatWithStatus
+	var err error
+
+	if s.cfg.CacheEventSeatMap {
+		seats, err = redisrepo.GetOrSetJSON(
+			ctx,
+			s.cache,
+			redisrepo.KeyEventSeatMap(eventID),
+			s.cfg.EventSeatMapTTL,
+			load,
+		)
+	} else {
+		seats, err = load(ctx)
+	}
 	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return seats, nil
+}
+
+// GetSeatMapForUser retrieves every seat of an event together with its
+// status and whether it is held by userID. The result depends on the user
+// and is never cached.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event.
+//   - userID: the user whose holds are marked.
+//
+// Returns:
+//   - []domain.UserSeat: all seats of the event, empty if it has none.
+//   - error: query.ErrEventNotFound if the event is not found.
+func (s *Service) GetSeatMapForUser(ctx context.Context, eventID, userID int64) ([]domain.UserSeat, error) {
+	const op = "service.query.GetSeatMapForUser"
+
+	if _, err := s.store.Query().GetEvent(ctx, eventID); err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
 		}
@@ -171,9 +634,47 @@ func (s *Service) ListEventSeats(
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	seats, err := s.store.Query().ListEventSeatsForUser(ctx, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if seats == nil {
+		seats = []domain.UserSeat{}
+	}
+
 	return seats, nil
 }
 
+// SeatStatuses retrieves the current status of a set of seats for an event.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event the seats belong to.
+//   - seatIDs: IDs of the seats to check.
+//
+// Returns:
+//   - map[int64]domain.SeatStatus: status by seat ID; unknown seats are omitted.
+//   - error: if the statuses could not be retrieved.
+func (s *Service) SeatStatuses(
+	ctx context.Context,
+	eventID int64,
+	seatIDs []int64,
+) (map[int64]domain.SeatStatus, error) {
+	const op = "service.query.SeatStatuses"
+
+	if len(seatIDs) == 0 {
+		return map[int64]domain.SeatStatus{}, nil
+	}
+
+	statuses, err := s.store.Query().SeatStatuses(ctx, eventID, seatIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return statuses, nil
+}
+
 // GetOrderWithTickets retrieves an order along with its associated tickets.
 //
 // Parameters:
@@ -197,3 +698,264 @@ func (s *Service) GetOrderWithTickets(ctx context.Context, orderID string) (*dom
 
 	return order, nil
 }
+
+// ListOrdersByUser retrieves a page of orders placed by a user, newest first.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - userID: ID of the user whose orders are listed.
+//   - limit: maximum number of orders to return (default and max limits are enforced).
+//   - offset: number of orders to skip for pagination.
+//
+// Returns:
+//   - []domain.Order: list of orders, empty if the user has none.
+//   - error: if the orders could not be listed.
+func (s *Service) ListOrdersByUser(
+	ctx context.Context,
+	userID int64,
+	limit, offset int,
+) ([]domain.Order, error) {
+	const op = "service.query.ListOrdersByUser"
+
+	if limit <= 0 {
+		limit = s.cfg.DefaultOrdersPage
+	}
+
+	if limit > s.cfg.MaxOrdersPage {
+		limit = s.cfg.MaxOrdersPage
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	orders, err := s.store.Query().ListOrdersByUser(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if orders == nil {
+		orders = []domain.Order{}
+	}
+
+	return orders, nil
+}
+
+// ListOrders retrieves a page of all orders created within [from, to), for
+// reconciliation. The range may span at most Config.MaxOrdersWindow.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - from, to: bounds on the order creation time; to is exclusive.
+//   - desc: newest first when true, oldest first otherwise.
+//   - limit: maximum number of orders to return (default and max limits are enforced).
+//   - offset: number of orders to skip for pagination.
+//
+// Returns:
+//   - []domain.Order: list of orders, empty if there are none.
+//   - int: the effective page size after clamping.
+//   - error: query.ErrInvalidTimeRange if from is after to.
+//   - error: query.ErrTimeRangeTooWide if the range exceeds Config.MaxOrdersWindow.
+func (s *Service) ListOrders(
+	ctx context.Context,
+	from, to time.Time,
+	desc bool,
+	limit, offset int,
+) ([]domain.Order, int, error) {
+	const op = "service.query.ListOrders"
+
+	if from.After(to) {
+		return nil, 0, fmt.Errorf("%s: %w", op, ErrInvalidTimeRange)
+	}
+
+	if to.Sub(from) > s.cfg.MaxOrdersWindow {
+		return nil, 0, fmt.Errorf("%s: %w", op, ErrTimeRangeTooWide)
+	}
+
+	if limit <= 0 {
+		limit = s.cfg.DefaultOrdersPage
+	}
+
+	if limit > s.cfg.MaxOrdersPage {
+		limit = s.cfg.MaxOrdersPage
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	orders, err := s.store.Query().ListOrders(ctx, from, to, desc, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if orders == nil {
+		orders = []domain.Order{}
+	}
+
+	return orders, limit, nil
+}
+
+// ListVenues retrieves a page of venues ordered by ID.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - limit: maximum number of venues to return (default and max limits are enforced).
+//   - offset: number of venues to skip for pagination.
+//
+// Returns:
+//   - []domain.Venue: list of venues, empty if there are none.
+//   - error: if the venues could not be listed.
+func (s *Service) ListVenues(ctx context.Context, limit, offset int) ([]domain.Venue, error) {
+	const op = "service.query.ListVenues"
+
+	if limit <= 0 {
+		limit = s.cfg.DefaultVenuesPage
+	}
+
+	if limit > s.cfg.MaxVenuesPage {
+		limit = s.cfg.MaxVenuesPage
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	venues, err := s.store.Query().ListVenues(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if venues == nil {
+		venues = []domain.Venue{}
+	}
+
+	return venues, nil
+}
+
+// ListActiveHolds retrieves a page of an event's unexpired holds.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event.
+//   - limit: maximum number of holds to return (default and max limits are enforced).
+//   - offset: number of holds to skip for pagination.
+//
+// Returns:
+//   - []domain.HoldSummary: list of active holds, empty if there are none.
+//   - error: query.ErrEventNotFound if the event is not found.
+func (s *Service) ListActiveHolds(ctx context.Context, eventID int64, limit, offset int) ([]domain.HoldSummary, error) {
+	const op = "service.query.ListActiveHolds"
+
+	if limit <= 0 {
+		limit = s.cfg.DefaultHoldsPage
+	}
+
+	if limit > s.cfg.MaxHoldsPage {
+		limit = s.cfg.MaxHoldsPage
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	if _, err := s.store.Query().GetEvent(ctx, eventID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	holds, err := s.store.Query().ListActiveHolds(ctx, eventID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if holds == nil {
+		holds = []domain.HoldSummary{}
+	}
+
+	return holds, nil
+}
+
+// ListEventLog retrieves a page of an event's hold lifecycle log, oldest
+// entry first.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event.
+//   - limit: maximum number of entries to return (default and max limits are enforced).
+//   - offset: number of entries to skip for pagination.
+//
+// Returns:
+//   - []domain.EventLogEntry: list of log entries, empty if there are none.
+//   - error: query.ErrEventNotFound if the event is not found.
+func (s *Service) ListEventLog(ctx context.Context, eventID int64, limit, offset int) ([]domain.EventLogEntry, error) {
+	const op = "service.query.ListEventLog"
+
+	if limit <= 0 {
+		limit = s.cfg.DefaultLogPage
+	}
+
+	if limit > s.cfg.MaxLogPage {
+		limit = s.cfg.MaxLogPage
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	if _, err := s.store.Query().GetEvent(ctx, eventID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrEventNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entries, err := s.store.Query().ListEventLog(ctx, eventID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if entries == nil {
+		entries = []domain.EventLogEntry{}
+	}
+
+	return entries, nil
+}
+
+// AuthenticateAPIKey resolves the client behind an API key. The key is looked
+// up by its SHA-256 hash, so the lookup time reveals nothing about stored
+// keys; keys not shaped like auth.NewAPIKey output are rejected without one.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - key: the API key presented by the client.
+//
+// Returns:
+//   - *domain.APIKey: the key with its client name and scopes.
+//   - error: query.ErrInvalidAPIKey if no key matches.
+//   - error: query.ErrAPIKeyRevoked if the key has been revoked.
+func (s *Service) AuthenticateAPIKey(ctx context.Context, key string) (*domain.APIKey, error) {
+	const op = "service.query.AuthenticateAPIKey"
+
+	if !auth.WellFormedAPIKey(key) {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidAPIKey)
+	}
+
+	k, err := s.store.Query().APIKeyByHash(ctx, auth.HashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidAPIKey)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if k.RevokedAt != nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrAPIKeyRevoked)
+	}
+
+	return k, nil
+}
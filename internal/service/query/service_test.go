@@ -0,0 +1,449 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/postgres/pgtest"
+	postgresrepo "github.com/kirinyoku/tix-go/internal/repository/postgres"
+	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestService(t *testing.T, cfg Config) (*Service, *postgresrepo.Store, *pgxpool.Pool) {
+	t.Helper()
+
+	pool := pgtest.New(t)
+	store := postgresrepo.NewStore(pool)
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	return New(store, redisrepo.New(rdb), cfg), store, pool
+}
+
+// confirmOrder holds seatIDs for userID and confirms the hold into an order.
+func confirmOrder(t *testing.T, store *postgresrepo.Store, eventID, userID int64, seatIDs []int64) uuid.UUID {
+	t.Helper()
+
+	ctx := context.Background()
+	holdID, _, err := store.Reservations().HoldSeats(ctx, eventID, userID, seatIDs, time.Minute)
+	if err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	orderID, err := store.Reservations().ConfirmHold(ctx, holdID)
+	if err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+
+	return orderID
+}
+
+func TestTicketsByOrdersOwnership(t *testing.T) {
+	svc, store, pool := newTestService(t, Config{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+	mine := confirmOrder(t, store, eventID, 1, seatIDs[:2])
+	theirs := confirmOrder(t, store, eventID, 2, seatIDs[2:3])
+
+	tests := []struct {
+		name   string
+		userID int64
+		want   map[uuid.UUID]int
+	}{
+		{name: "owner", userID: 1, want: map[uuid.UUID]int{mine: 2}},
+		{name: "other owner", userID: 2, want: map[uuid.UUID]int{theirs: 1}},
+		{name: "stranger", userID: 3, want: map[uuid.UUID]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.TicketsByOrders(context.Background(), []uuid.UUID{mine, theirs, mine}, tt.userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d orders, want %d", len(got), len(tt.want))
+			}
+			for id, n := range tt.want {
+				if len(got[id]) != n {
+					t.Errorf("order %s: got %d tickets, want %d", id, len(got[id]), n)
+				}
+			}
+		})
+	}
+}
+
+func TestListEventSeatsHoldExpiry(t *testing.T) {
+	svc, store, pool := newTestService(t, Config{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 3, 0)
+	ctx := context.Background()
+
+	if _, _, err := store.Reservations().HoldSeats(ctx, eventID, 1, seatIDs[:1], time.Minute); err != nil {
+		t.Fatalf("live hold: %v", err)
+	}
+	lapsed, _, err := store.Reservations().HoldSeats(ctx, eventID, 2, seatIDs[1:2], time.Minute)
+	if err != nil {
+		t.Fatalf("lapsed hold: %v", err)
+	}
+	if _, err := pool.Exec(ctx,
+		`UPDATE event_seats SET hold_expires_at = now() - interval '1 second' WHERE hold_id = $1`, lapsed,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the live hold carries an expiry; the lapsed one and the free seat
+	// do not.
+	want := map[int64]bool{seatIDs[0]: true, seatIDs[1]: false, seatIDs[2]: false}
+
+	tests := []struct {
+		name  string
+		after *domain.Seat
+	}{
+		{name: "offset"},
+		{name: "keyset", after: &domain.Seat{Section: "A", Row: "0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seats, _, err := svc.ListEventSeatsAfter(ctx, eventID, false, tt.after, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(seats) != len(want) {
+				t.Fatalf("got %d seats, want %d", len(seats), len(want))
+			}
+			for _, s := range seats {
+				if got := s.HoldExpiresAt != nil; got != want[s.ID] {
+					t.Errorf("seat %d: has expiry = %t, want %t", s.ID, got, want[s.ID])
+				}
+			}
+		})
+	}
+}
+
+func TestListOrdersByUser(t *testing.T) {
+	svc, store, pool := newTestService(t, Config{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+	ctx := context.Background()
+
+	// Three orders of user 1, created a minute apart; oldest first.
+	base := time.Now().Add(-time.Hour)
+	var mine []uuid.UUID
+	for i, seatID := range seatIDs[:3] {
+		id := confirmOrder(t, store, eventID, 1, []int64{seatID})
+		if _, err := pool.Exec(ctx,
+			`UPDATE orders SET created_at = $2 WHERE id = $1`, id, base.Add(time.Duration(i)*time.Minute),
+		); err != nil {
+			t.Fatal(err)
+		}
+		mine = append(mine, id)
+	}
+	confirmOrder(t, store, eventID, 2, seatIDs[3:])
+
+	tests := []struct {
+		name          string
+		userID        int64
+		limit, offset int
+		want          []uuid.UUID
+	}{
+		{name: "newest first", userID: 1, limit: 10, want: []uuid.UUID{mine[2], mine[1], mine[0]}},
+		{name: "first page", userID: 1, limit: 2, want: []uuid.UUID{mine[2], mine[1]}},
+		{name: "last page", userID: 1, limit: 2, offset: 2, want: []uuid.UUID{mine[0]}},
+		{name: "past the end", userID: 1, limit: 2, offset: 3, want: []uuid.UUID{}},
+		{name: "negative offset", userID: 1, limit: 1, offset: -5, want: []uuid.UUID{mine[2]}},
+		{name: "no orders", userID: 3, limit: 10, want: []uuid.UUID{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.ListOrdersByUser(ctx, tt.userID, tt.limit, tt.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got == nil {
+				t.Fatal("got nil, want an empty slice")
+			}
+			ids := make([]uuid.UUID, 0, len(got))
+			for _, o := range got {
+				ids = append(ids, o.ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("got %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestListOrders(t *testing.T) {
+	svc, store, pool := newTestService(t, Config{MaxOrdersWindow: 24 * time.Hour})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+	ctx := context.Background()
+
+	// Four orders of different users, created an hour apart; oldest first.
+	base := time.Now().Add(-12 * time.Hour).Truncate(time.Second)
+	var ids []uuid.UUID
+	for i, seatID := range seatIDs {
+		id := confirmOrder(t, store, eventID, int64(i+1), []int64{seatID})
+		if _, err := pool.Exec(ctx,
+			`UPDATE orders SET created_at = $2 WHERE id = $1`, id, base.Add(time.Duration(i)*time.Hour),
+		); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	at := func(h int) time.Time { return base.Add(time.Duration(h) * time.Hour) }
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		desc     bool
+		limit    int
+		offset   int
+		want     []uuid.UUID
+		wantErr  error
+	}{
+		{name: "all oldest first", from: at(0), to: at(4), want: ids},
+		{name: "all newest first", from: at(0), to: at(4), desc: true, want: []uuid.UUID{ids[3], ids[2], ids[1], ids[0]}},
+		{name: "to is exclusive", from: at(1), to: at(3), want: []uuid.UUID{ids[1], ids[2]}},
+		{name: "range newest first", from: at(1), to: at(3), desc: true, want: []uuid.UUID{ids[2], ids[1]}},
+		{name: "page", from: at(0), to: at(4), desc: true, limit: 2, offset: 1, want: []uuid.UUID{ids[2], ids[1]}},
+		{name: "empty range", from: at(5), to: at(6), want: []uuid.UUID{}},
+		{name: "inverted", from: at(3), to: at(1), wantErr: ErrInvalidTimeRange},
+		{name: "too wide", from: at(0), to: at(25), wantErr: ErrTimeRangeTooWide},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := svc.ListOrders(ctx, tt.from, tt.to, tt.desc, tt.limit, tt.offset)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			gotIDs := make([]uuid.UUID, 0, len(got))
+			for _, o := range got {
+				gotIDs = append(gotIDs, o.ID)
+			}
+			if !slices.Equal(gotIDs, tt.want) {
+				t.Errorf("got %v, want %v", gotIDs, tt.want)
+			}
+		})
+	}
+}
+
+func TestListActiveHolds(t *testing.T) {
+	svc, store, pool := newTestService(t, Config{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 5, 0)
+	ctx := context.Background()
+
+	hold := func(userID int64, seats []int64, ttl time.Duration) uuid.UUID {
+		t.Helper()
+		id, _, err := store.Reservations().HoldSeats(ctx, eventID, userID, seats, ttl)
+		if err != nil {
+			t.Fatalf("hold: %v", err)
+		}
+		return id
+	}
+	// Active holds, soonest to expire first.
+	active := []uuid.UUID{
+		hold(1, seatIDs[:2], time.Minute),
+		hold(2, seatIDs[2:3], 2*time.Minute),
+		hold(3, seatIDs[3:4], 3*time.Minute),
+	}
+	lapsed := hold(4, seatIDs[4:], time.Minute)
+	if _, err := pool.Exec(ctx,
+		`UPDATE holds SET expires_at = now() - interval '1 second' WHERE id = $1`, lapsed,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		eventID       int64
+		limit, offset int
+		want          []uuid.UUID
+		wantSeats     []int64
+		wantErr       error
+	}{
+		{name: "all", eventID: eventID, limit: 10, want: active, wantSeats: []int64{2, 1, 1}},
+		{name: "first page", eventID: eventID, limit: 2, want: active[:2], wantSeats: []int64{2, 1}},
+		{name: "last page", eventID: eventID, limit: 2, offset: 2, want: active[2:], wantSeats: []int64{1}},
+		{name: "past the end", eventID: eventID, limit: 2, offset: 3, want: []uuid.UUID{}, wantSeats: []int64{}},
+		{name: "unknown event", eventID: -1, limit: 10, wantErr: ErrEventNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.ListActiveHolds(ctx, tt.eventID, tt.limit, tt.offset)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			ids := make([]uuid.UUID, 0, len(got))
+			seats := make([]int64, 0, len(got))
+			for _, h := range got {
+				ids = append(ids, h.ID)
+				seats = append(seats, h.SeatCount)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("holds = %v, want %v", ids, tt.want)
+			}
+			if !slices.Equal(seats, tt.wantSeats) {
+				t.Errorf("seat counts = %v, want %v", seats, tt.wantSeats)
+			}
+		})
+	}
+}
+
+func TestGetSeatMapCache(t *testing.T) {
+	ctx := context.Background()
+
+	for _, cached := range []bool{false, true} {
+		t.Run(fmt.Sprintf("cached %t", cached), func(t *testing.T) {
+			svc, _, pool := newTestService(t, Config{CacheEventSeatMap: cached})
+			eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+
+			// status returns the status GetSeatMap reports for the first seat.
+			status := func() domain.SeatStatus {
+				t.Helper()
+				seats, err := svc.GetSeatMap(ctx, eventID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(seats) != len(seatIDs) {
+					t.Fatalf("got %d seats, want %d", len(seats), len(seatIDs))
+				}
+				for _, s := range seats {
+					if s.ID == seatIDs[0] {
+						return s.Status
+					}
+				}
+				t.Fatalf("seat %d missing", seatIDs[0])
+				return ""
+			}
+
+			if got := status(); got != domain.SeatAvailable {
+				t.Fatalf("miss: status %q, want available", got)
+			}
+
+			// Change the seat behind the cache's back.
+			if _, err := pool.Exec(ctx,
+				`UPDATE event_seats SET status = 'sold' WHERE event_id = $1 AND seat_id = $2`,
+				eventID, seatIDs[0],
+			); err != nil {
+				t.Fatal(err)
+			}
+			want := domain.SeatSold
+			if cached {
+				want = domain.SeatAvailable
+			}
+			if got := status(); got != want {
+				t.Fatalf("second read: status %q, want %q", got, want)
+			}
+
+			if err := svc.cache.InvalidateEvent(ctx, eventID); err != nil {
+				t.Fatal(err)
+			}
+			if got := status(); got != domain.SeatSold {
+				t.Errorf("after invalidation: status %q, want sold", got)
+			}
+		})
+	}
+
+	svc, _, _ := newTestService(t, Config{CacheEventSeatMap: true})
+	if _, err := svc.GetSeatMap(ctx, -1); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("unknown event: err = %v, want %v", err, ErrEventNotFound)
+	}
+}
+
+func TestCountsByStatusBatch(t *testing.T) {
+	svc, store, pool := newTestService(t, Config{MaxBatchEvents: 4})
+	ctx := context.Background()
+
+	busy, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+	if _, _, err := store.Reservations().HoldSeats(ctx, busy, 1, seatIDs[:1], time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	confirmOrder(t, store, busy, 2, seatIDs[1:3])
+	free, _ := pgtest.SeedEvent(t, pool, 2, 2, 0)
+	var empty int64
+	if err := pool.QueryRow(ctx,
+		`INSERT INTO events (venue_id, title, starts_at, ends_at)
+		 SELECT venue_id, 'No seats', starts_at, ends_at FROM events WHERE id = $1
+		 RETURNING id`, free,
+	).Scan(&empty); err != nil {
+		t.Fatal(err)
+	}
+	const missing = -1
+
+	// Five IDs, four distinct: duplicates do not count against the cap.
+	got, err := svc.CountsByStatusBatch(ctx, []int64{busy, missing, free, busy, empty})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int64]domain.EventCounts{
+		busy:  {Available: 1, Held: 1, Sold: 2, Total: 4},
+		free:  {Available: 4, Total: 4},
+		empty: {},
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestCountsByStatusBatchCap(t *testing.T) {
+	// The cap is checked before the store is reached.
+	svc := &Service{cfg: Config{MaxBatchEvents: 2}}
+
+	if _, err := svc.CountsByStatusBatch(context.Background(), []int64{1, 2, 3}); !errors.Is(err, ErrTooManyEvents) {
+		t.Errorf("err = %v, want %v", err, ErrTooManyEvents)
+	}
+}
+
+func TestCountsBySectionSumToTotals(t *testing.T) {
+	svc, store, pool := newTestService(t, Config{})
+	ctx := context.Background()
+
+	// Two rows of three seats; the second row becomes section B.
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 2, 3, 0)
+	if _, err := pool.Exec(ctx, `UPDATE seats SET section = 'B' WHERE id = ANY($1)`, seatIDs[3:]); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := store.Reservations().HoldSeats(ctx, eventID, 1, seatIDs[:1], time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	confirmOrder(t, store, eventID, 2, seatIDs[4:5])
+
+	got, err := svc.CountsBySection(ctx, eventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []domain.SectionCounts{
+		{Section: "A", Available: 2, Held: 1, Total: 3},
+		{Section: "B", Available: 2, Sold: 1, Total: 3},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	totals, err := svc.CountsByStatus(ctx, eventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum domain.EventCounts
+	for _, sc := range got {
+		sum.Available += sc.Available
+		sum.Held += sc.Held
+		sum.Sold += sc.Sold
+		sum.Total += sc.Total
+	}
+	if sum != *totals {
+		t.Errorf("sections sum to %+v, event totals are %+v", sum, *totals)
+	}
+}
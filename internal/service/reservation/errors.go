@@ -3,16 +3,33 @@ package reservation
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 var (
-	ErrSeatsUnavailable = errors.New("some seats are unavailable")
-	ErrHoldConflict     = errors.New("conflict creating hold")
-	ErrHoldNotFound     = errors.New("hold not found")
-	ErrHoldExpired      = errors.New("hold is expired")
-	ErrEventNotFound    = errors.New("event not found")
+	ErrSeatsUnavailable   = errors.New("some seats are unavailable")
+	ErrEventSoldOut       = errors.New("event is sold out")
+	ErrSeatLimitExceeded  = errors.New("seat limit per user exceeded")
+	ErrHoldConflict       = errors.New("conflict creating hold")
+	ErrHoldNotFound       = errors.New("hold not found")
+	ErrHoldNotOwned       = errors.New("hold belongs to another user")
+	ErrHoldExpired        = errors.New("hold is expired")
+	ErrAlreadyConfirmed   = errors.New("hold is already confirmed")
+	ErrNothingToConfirm   = errors.New("hold has no seats to confirm")
+	ErrGAUnavailable      = errors.New("not enough general admission tickets")
+	ErrNoTicketsRequested = errors.New("no tickets requested")
+	ErrTooManySeats       = errors.New("too many seats in one hold")
+	ErrInvalidExtension   = errors.New("hold extension must be positive")
+	ErrEventNotFound      = errors.New("event not found")
+	ErrEventCancelled     = errors.New("event is cancelled")
+	ErrEventEnded         = errors.New("event is no longer open for holds")
+	ErrRateLimited        = errors.New("rate limited")
+	ErrSeatsNotContiguous = errors.New("seats are not contiguous")
+	// ErrSeatVersionsMismatch is returned when seat versions do not line up
+	// with the seat IDs they are for.
+	ErrSeatVersionsMismatch = errors.New("seat versions do not match seat ids")
 )
 
 type NoSeatsAvailableError struct{}
@@ -21,6 +38,8 @@ func (e NoSeatsAvailableError) Error() string {
 	return "no seats available"
 }
 
+// SeatsUnavailableError is returned when a hold fails because some of the
+// requested seats are taken. SeatIDs lists those seats.
 type SeatsUnavailableError struct {
 	SeatIDs []int64
 }
@@ -29,6 +48,12 @@ func (e SeatsUnavailableError) Error() string {
 	return fmt.Sprintf("some or all seats are unavailable: %v", e.SeatIDs)
 }
 
+// Is reports SeatsUnavailableError as ErrSeatsUnavailable so callers can
+// match it with errors.Is.
+func (e SeatsUnavailableError) Is(target error) bool {
+	return target == ErrSeatsUnavailable
+}
+
 type HoldNotFoundError struct {
 	HoldID uuid.UUID
 }
@@ -58,3 +83,19 @@ type ConflictError struct{}
 func (e ConflictError) Error() string {
 	return "conflict"
 }
+
+// RateLimitedError is returned when a hold request is throttled. RetryAfter
+// is the limiter's estimate of when the next request will be admitted.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited, retry in %s", e.RetryAfter)
+}
+
+// Is reports RateLimitedError as ErrRateLimited so callers can match it
+// with errors.Is.
+func (e RateLimitedError) Is(target error) bool {
+	return target == ErrRateLimited
+}
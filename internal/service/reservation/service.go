@@ -4,36 +4,128 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/metrics"
 	"github.com/kirinyoku/tix-go/internal/repository"
 	postgresrepo "github.com/kirinyoku/tix-go/internal/repository/postgres"
 	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+	"github.com/kirinyoku/tix-go/internal/tracing"
 	"github.com/kirinyoku/tix-go/internal/uow"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/sync/singleflight"
+)
+
+var tracer = otel.Tracer("github.com/kirinyoku/tix-go/internal/service/reservation")
+
+// confirmTimeout bounds a confirm shared by concurrent callers, which does
+// not stop when the caller that started it goes away.
+const confirmTimeout = 10 * time.Second
+
+// HoldCutoff selects the point in an event's schedule after which no new
+// holds are accepted.
+type HoldCutoff int
+
+const (
+	// HoldCutoffEventEnd accepts holds until the event has ended.
+	HoldCutoffEventEnd HoldCutoff = iota
+	// HoldCutoffEventStart accepts holds only until the event starts.
+	HoldCutoffEventStart
 )
 
 type Config struct {
 	MinHoldTTL time.Duration
 	MaxHoldTTL time.Duration
+	HoldCutoff HoldCutoff
+	// IsolationLevel is used for the transactions that change seat state.
+	// Defaults to pgx.Serializable, which rules out double-selling on its
+	// own. pgx.RepeatableRead is cheaper under contention and still safe for
+	// these operations, because every seat update is guarded by its current
+	// status (and, for versioned holds, its version) in the WHERE clause;
+	// lower levels are not supported.
+	IsolationLevel pgx.TxIsoLevel
+	// MaxSeatsPerUser caps how many seats one user may hold and own for a
+	// single event. Zero means no cap. The count is only race-free under
+	// serializable isolation.
+	MaxSeatsPerUser int
+	// MaxSeatsPerHold caps how many seats or general-admission tickets a
+	// single hold request may take. Defaults to 100.
+	MaxSeatsPerHold int
+	// Notifier delivers waitlist notifications. Defaults to LogNotifier.
+	Notifier Notifier
+}
+
+// Limiter decides whether a request identified by suffix may proceed.
+// Depending on the interface rather than a Redis-backed type keeps the
+// service usable with an in-memory fake.
+type Limiter interface {
+	Allow(ctx context.Context, suffix string) (allowed bool, current int64, retryAfter time.Duration, err error)
+	// Peek reports what Allow would decide without counting a request.
+	Peek(ctx context.Context, suffix string) (allowed bool, current int64, retryAfter time.Duration, err error)
+}
+
+var (
+	_ Limiter = (*redisrepo.SlidingWindowLimiter)(nil)
+	_ Limiter = (*redisrepo.TokenBucketLimiter)(nil)
+)
+
+// Notifier tells a waitlisted user that enough seats are available for them.
+// It runs after the entry has been marked notified; on error the entry goes
+// back to its place in the queue.
+type Notifier interface {
+	NotifyWaitlisted(ctx context.Context, e domain.WaitlistEntry) error
+}
+
+// LogNotifier is a Notifier that only logs, for deployments without a
+// delivery channel. A nil Logger uses slog.Default.
+type LogNotifier struct {
+	Logger *slog.Logger
+}
+
+func (n LogNotifier) NotifyWaitlisted(ctx context.Context, e domain.WaitlistEntry) error {
+	logger := n.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	logger.InfoContext(ctx, "waitlist entry notified",
+		"event_id", e.EventID,
+		"user_id", e.UserID,
+		"seat_count", e.SeatCount,
+	)
+
+	return nil
 }
 
 type Service struct {
-	store   *postgresrepo.Store
-	cache   *redisrepo.Cache
-	pubsub  *redisrepo.EventsPubSub
-	limiter *redisrepo.SlidingWindowLimiter
-	uow     *uow.UoW
-	cfg     Config
+	store       *postgresrepo.Store
+	cache       *redisrepo.Cache
+	pubsub      *redisrepo.EventsPubSub
+	ipLimiter   Limiter
+	userLimiter Limiter
+	metrics     *metrics.Metrics
+	uow         *uow.UoW
+	cfg         Config
+	// confirms collapses concurrent confirms of the same hold.
+	confirms singleflight.Group
 }
 
 func New(
 	store *postgresrepo.Store,
 	cache *redisrepo.Cache,
 	pubsub *redisrepo.EventsPubSub,
-	limiter *redisrepo.SlidingWindowLimiter,
+	ipLimiter Limiter,
+	userLimiter Limiter,
+	m *metrics.Metrics,
 	cfg Config,
+	uowOpts ...uow.Option,
 ) *Service {
 	if cfg.MinHoldTTL <= 0 {
 		cfg.MinHoldTTL = 15 * time.Second
@@ -43,71 +135,245 @@ func New(
 		cfg.MaxHoldTTL = 5 * time.Minute
 	}
 
+	if cfg.MaxSeatsPerHold <= 0 {
+		cfg.MaxSeatsPerHold = 100
+	}
+
+	if cfg.IsolationLevel == "" {
+		cfg.IsolationLevel = pgx.Serializable
+	}
+
+	if cfg.Notifier == nil {
+		cfg.Notifier = LogNotifier{}
+	}
+
 	return &Service{
-		store:   store,
-		cache:   cache,
-		pubsub:  pubsub,
-		limiter: limiter,
-		uow:     uow.NewUoW(store),
-		cfg:     cfg,
+		store:       store,
+		cache:       cache,
+		pubsub:      pubsub,
+		ipLimiter:   ipLimiter,
+		userLimiter: userLimiter,
+		metrics:     m,
+		uow:         uow.NewUoW(store, uowOpts...),
+		cfg:         cfg,
+	}
+}
+
+// txOptions returns the options for transactions that change seat state.
+func (s *Service) txOptions() *pgx.TxOptions {
+	return &pgx.TxOptions{
+		IsoLevel:   s.cfg.IsolationLevel,
+		AccessMode: pgx.ReadWrite,
 	}
 }
 
-// CreateHold creates a new hold for the specified seats.
+// CreateHold creates a new hold for the specified seats. The request is
+// rate limited both per client (rlKey) and per user; exceeding either limit
+// rejects the hold. Duplicate seat IDs are ignored.
+//
+// seatVersions, when given, pins each seat to the version the caller last
+// read; a seat that changed since then is treated as taken.
+//
+// With allowPartial set, the seats that are available are held and the seats
+// left out are returned alongside them. allowPartial is ignored when
+// contiguous is set, since a partial run would not be contiguous.
 //
 // Parameters:
 //   - ctx: request-scoped context.
 //   - userID: ID of the user creating the hold.
 //   - eventID: ID of the event the seats are for.
 //   - seatIDs: IDs of the seats to hold.
+//   - seatVersions: expected version of each seat, aligned with seatIDs; nil skips the check.
 //   - ttl: time-to-live for the hold.
+//   - rlKey: client rate-limit key (e.g. "ip:<addr>"); empty skips the client limit.
+//   - contiguous: if true, the seats must form a gap-free run within one section and row.
+//   - allowPartial: if true, hold the available seats instead of failing when some are taken.
 //
 // Returns:
 //   - uuid.UUID: the ID of the created hold.
-//   - error: reservation.ErrSeatsUnavailable if the seats are unavailable.
+//   - []int64: IDs of the held seats, in ascending order.
+//   - []int64: IDs of the seats left out when allowPartial is set, in ascending order.
+//   - error: reservation.SeatsUnavailableError, matching reservation.ErrSeatsUnavailable,
+//     naming the taken seats if some are unavailable, or all are when allowPartial is set.
+//   - error: reservation.ErrEventSoldOut if the event has no available seats left.
+//   - error: reservation.ErrSeatLimitExceeded if the user would exceed Config.MaxSeatsPerUser.
 //   - error: reservation.ErrHoldConflict if the hold conflicts with an existing hold.
+//   - error: reservation.ErrEventNotFound if the event does not exist.
+//   - error: reservation.ErrEventCancelled if the event has been cancelled.
+//   - error: reservation.ErrEventEnded if the event is past the configured hold cutoff.
+//   - error: reservation.ErrSeatsNotContiguous if contiguous is set and the seats have gaps.
+//   - error: reservation.ErrTooManySeats if more than Config.MaxSeatsPerHold distinct seats are requested.
+//   - error: reservation.ErrSeatVersionsMismatch if seatVersions does not line up with seatIDs.
 func (s *Service) CreateHold(
 	ctx context.Context,
 	userID, eventID int64,
 	seatIDs []int64,
+	seatVersions []int64,
 	ttl time.Duration,
 	rlKey string,
-) (uuid.UUID, error) {
+	contiguous bool,
+	allowPartial bool,
+) (_ uuid.UUID, _ []int64, _ []int64, err error) {
 	const op = "service.reservation.CreateHold"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
+
 	if len(seatIDs) == 0 {
-		return uuid.Nil, fmt.Errorf("%s:%s", op, "no seats selected")
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%s", op, "no seats selected")
+	}
+
+	// Duplicates would hold fewer seats than requested and fail the hold.
+	seatIDs, seatVersions, err = dedupeSeats(seatIDs, seatVersions)
+	if err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if len(seatIDs) > s.cfg.MaxSeatsPerHold {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, ErrTooManySeats)
 	}
 
 	ttl = s.clampTTL(ttl)
 
-	if s.limiter != nil && rlKey != "" {
-		ok, _, retry, err := s.limiter.Allow(ctx, rlKey)
-		if err != nil {
-			return uuid.Nil, fmt.Errorf("%s:%w", op, err)
+	if err := s.checkLimits(ctx, userID, rlKey, "create_hold"); err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	var (
+		holdID      uuid.UUID
+		held        []int64
+		unavailable []int64
+	)
+
+	err = s.uow.DoWithOpts(ctx, s.txOptions(), func(
+		ctx context.Context,
+		tx postgresrepo.DB,
+		after func(uow.AfterCommit),
+	) error {
+		if err := s.checkEventOpen(ctx, tx, eventID); err != nil {
+			return fmt.Errorf("%s:%w", op, err)
 		}
-		if !ok {
-			return uuid.Nil, fmt.Errorf("%s: rate limited, retry in %s", op, retry)
+
+		if contiguous {
+			seats, err := s.store.Query().With(tx).SeatsByIDs(ctx, eventID, seatIDs)
+			if err != nil {
+				return fmt.Errorf("%s:%w", op, err)
+			}
+
+			if len(seats) != len(seatIDs) {
+				return fmt.Errorf("%s:%w", op, SeatsUnavailableError{SeatIDs: missingSeats(seatIDs, seats)})
+			}
+
+			if !isContiguous(seats) {
+				return fmt.Errorf("%s:%w", op, ErrSeatsNotContiguous)
+			}
 		}
+
+		rid, ids, missing, err := s.holdSeats(ctx, tx, userID, eventID, seatIDs, seatVersions, ttl, allowPartial && !contiguous)
+		if err != nil {
+			return fmt.Errorf("%s:%w", op, err)
+		}
+
+		holdID, held, unavailable = rid, ids, missing
+
+		after(func(ctx context.Context) error {
+			return errors.Join(
+				s.cache.InvalidateEvent(ctx, eventID),
+				s.pubsub.PublishEventChanged(ctx, eventID),
+			)
+		})
+
+		return nil
+	})
+	s.metrics.IncReservation("create_hold", outcome(err))
+	if err != nil {
+		return uuid.Nil, nil, nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return holdID, held, unavailable, nil
+}
+
+// CreateGAHold holds general-admission tickets of an event. The request is
+// rate limited and capped per user like CreateHold.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - userID: ID of the user creating the hold.
+//   - eventID: ID of the event.
+//   - qty: number of tickets to hold.
+//   - ttl: time-to-live for the hold.
+//   - rlKey: client rate-limit key (e.g. "ip:<addr>"); empty skips the client limit.
+//
+// Returns:
+//   - uuid.UUID: the ID of the created hold.
+//   - error: reservation.ErrNoTicketsRequested if qty is not positive.
+//   - error: reservation.ErrGAUnavailable if fewer than qty tickets are left.
+//   - error: reservation.ErrTooManySeats if qty exceeds Config.MaxSeatsPerHold.
+//   - error: reservation.ErrSeatLimitExceeded if the user would exceed Config.MaxSeatsPerUser.
+//   - error: reservation.ErrEventNotFound if the event does not exist.
+//   - error: reservation.ErrEventCancelled if the event has been cancelled.
+//   - error: reservation.ErrEventEnded if the event is past the configured hold cutoff.
+func (s *Service) CreateGAHold(
+	ctx context.Context,
+	userID, eventID int64,
+	qty int,
+	ttl time.Duration,
+	rlKey string,
+) (_ uuid.UUID, err error) {
+	const op = "service.reservation.CreateGAHold"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
+
+	if qty <= 0 {
+		return uuid.Nil, fmt.Errorf("%s:%w", op, ErrNoTicketsRequested)
+	}
+
+	if qty > s.cfg.MaxSeatsPerHold {
+		return uuid.Nil, fmt.Errorf("%s:%w", op, ErrTooManySeats)
+	}
+
+	ttl = s.clampTTL(ttl)
+
+	if err := s.checkLimits(ctx, userID, rlKey, "create_ga_hold"); err != nil {
+		return uuid.Nil, fmt.Errorf("%s:%w", op, err)
 	}
 
 	var holdID uuid.UUID
 
-	err := s.uow.Do(ctx, func(
+	err = s.uow.DoWithOpts(ctx, s.txOptions(), func(
 		ctx context.Context,
 		tx postgresrepo.DB,
 		after func(uow.AfterCommit),
 	) error {
+		if err := s.checkEventOpen(ctx, tx, eventID); err != nil {
+			return fmt.Errorf("%s:%w", op, err)
+		}
+
+		if s.cfg.MaxSeatsPerUser > 0 {
+			owned, err := s.store.Reservations().With(tx).CountUserSeats(ctx, eventID, userID)
+			if err != nil {
+				return fmt.Errorf("%s:%w", op, err)
+			}
+			if owned+int64(qty) > int64(s.cfg.MaxSeatsPerUser) {
+				return fmt.Errorf("%s:%w", op, ErrSeatLimitExceeded)
+			}
+		}
+
 		rid, err := s.store.Reservations().
 			With(tx).
-			HoldSeats(ctx, eventID, userID, seatIDs, ttl)
+			HoldGA(ctx, eventID, userID, qty, ttl)
 		if err != nil {
-			if errors.Is(err, repository.ErrSeatsUnavailable) {
-				return fmt.Errorf("%s:%w", op, ErrSeatsUnavailable)
+			if errors.Is(err, repository.ErrGAUnavailable) {
+				return fmt.Errorf("%s:%w", op, ErrGAUnavailable)
 			}
 
-			if errors.Is(err, repository.ErrConflict) {
-				return fmt.Errorf("%s:%w", op, ErrHoldConflict)
+			if errors.Is(err, repository.ErrEventCancelled) {
+				return fmt.Errorf("%s:%w", op, ErrEventCancelled)
+			}
+
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%s:%w", op, ErrEventNotFound)
 			}
 
 			return fmt.Errorf("%s:%w", op, err)
@@ -115,13 +381,16 @@ func (s *Service) CreateHold(
 
 		holdID = rid
 
-		after(func(ctx context.Context) {
-			_ = s.cache.InvalidateEvent(ctx, eventID)
-			_ = s.pubsub.PublishEventChanged(ctx, eventID)
+		after(func(ctx context.Context) error {
+			return errors.Join(
+				s.cache.InvalidateEvent(ctx, eventID),
+				s.pubsub.PublishEventChanged(ctx, eventID),
+			)
 		})
 
 		return nil
 	})
+	s.metrics.IncReservation("create_ga_hold", outcome(err))
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("%s:%w", op, err)
 	}
@@ -129,59 +398,378 @@ func (s *Service) CreateHold(
 	return holdID, nil
 }
 
-// Confirm confirms a hold and creates an order.
+// SuggestAndHold picks the best available seats for an event and holds them
+// in the same transaction, so the suggestion cannot be taken by someone else
+// in between. Seats are preferred in the lowest row, closest to its centre.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - userID: ID of the user creating the hold.
+//   - eventID: ID of the event.
+//   - count: number of seats to hold.
+//   - ttl: time-to-live for the hold.
+//   - rlKey: client rate-limit key (e.g. "ip:<addr>"); empty skips the client limit.
+//
+// Returns:
+//   - uuid.UUID: the ID of the created hold.
+//   - []int64: IDs of the held seats.
+//   - error: reservation.ErrSeatsUnavailable if fewer than count seats are available.
+//   - error: reservation.ErrTooManySeats if count exceeds Config.MaxSeatsPerHold.
+//   - error: the same errors as CreateHold otherwise.
+func (s *Service) SuggestAndHold(
+	ctx context.Context,
+	userID, eventID int64,
+	count int,
+	ttl time.Duration,
+	rlKey string,
+) (_ uuid.UUID, _ []int64, err error) {
+	const op = "service.reservation.SuggestAndHold"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
+
+	if count <= 0 {
+		return uuid.Nil, nil, fmt.Errorf("%s:%s", op, "no seats requested")
+	}
+
+	if count > s.cfg.MaxSeatsPerHold {
+		return uuid.Nil, nil, fmt.Errorf("%s:%w", op, ErrTooManySeats)
+	}
+
+	ttl = s.clampTTL(ttl)
+
+	if err := s.checkLimits(ctx, userID, rlKey, "suggest_hold"); err != nil {
+		return uuid.Nil, nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	var (
+		holdID  uuid.UUID
+		seatIDs []int64
+	)
+
+	err = s.uow.DoWithOpts(ctx, s.txOptions(), func(
+		ctx context.Context,
+		tx postgresrepo.DB,
+		after func(uow.AfterCommit),
+	) error {
+		if err := s.checkEventOpen(ctx, tx, eventID); err != nil {
+			return fmt.Errorf("%s:%w", op, err)
+		}
+
+		ids, err := s.store.Query().With(tx).SuggestSeats(ctx, eventID, count)
+		if err != nil {
+			return fmt.Errorf("%s:%w", op, err)
+		}
+
+		if len(ids) < count {
+			return fmt.Errorf("%s:%w", op, ErrSeatsUnavailable)
+		}
+
+		rid, held, _, err := s.holdSeats(ctx, tx, userID, eventID, ids, nil, ttl, false)
+		if err != nil {
+			return fmt.Errorf("%s:%w", op, err)
+		}
+
+		holdID = rid
+		seatIDs = held
+
+		after(func(ctx context.Context) error {
+			return errors.Join(
+				s.cache.InvalidateEvent(ctx, eventID),
+				s.pubsub.PublishEventChanged(ctx, eventID),
+			)
+		})
+
+		return nil
+	})
+	s.metrics.IncReservation("suggest_hold", outcome(err))
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return holdID, seatIDs, nil
+}
+
+// checkLimits applies the per-client and per-user hold limits. Both limits
+// are checked before either is counted, so a request rejected by one does
+// not use up the other's budget. Concurrent requests can still slip between
+// the check and the count; Allow then rejects them as usual. Throttled
+// requests are counted under the given metrics operation.
+func (s *Service) checkLimits(ctx context.Context, userID int64, rlKey, operation string) error {
+	type limit struct {
+		limiter Limiter
+		key     string
+	}
+
+	var limits []limit
+	if s.ipLimiter != nil && rlKey != "" {
+		limits = append(limits, limit{s.ipLimiter, rlKey})
+	}
+	if s.userLimiter != nil {
+		limits = append(limits, limit{s.userLimiter, fmt.Sprintf("user:%d", userID)})
+	}
+
+	for _, l := range limits {
+		ok, _, retry, err := l.limiter.Peek(ctx, l.key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			s.metrics.IncReservation(operation, "rate_limited")
+			return RateLimitedError{RetryAfter: retry}
+		}
+	}
+
+	for _, l := range limits {
+		ok, _, retry, err := l.limiter.Allow(ctx, l.key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			s.metrics.IncReservation(operation, "rate_limited")
+			return RateLimitedError{RetryAfter: retry}
+		}
+	}
+
+	return nil
+}
+
+// checkEventOpen verifies inside tx that the event exists and is still
+// accepting holds.
+func (s *Service) checkEventOpen(ctx context.Context, tx postgresrepo.DB, eventID int64) error {
+	event, err := s.store.Query().With(tx).GetEvent(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrEventNotFound
+		}
+
+		return err
+	}
+
+	if !time.Now().Before(s.holdCutoff(event)) {
+		return ErrEventEnded
+	}
+
+	return nil
+}
+
+// holdSeats holds seatIDs inside tx, translating repository errors into
+// service errors. When partial is set, seats that cannot be held are left
+// out instead of failing the hold. It returns the hold ID, the held seat IDs
+// and the seat IDs left out.
+func (s *Service) holdSeats(
+	ctx context.Context,
+	tx postgresrepo.DB,
+	userID, eventID int64,
+	seatIDs []int64,
+	versions []int64,
+	ttl time.Duration,
+	partial bool,
+) (uuid.UUID, []int64, []int64, error) {
+	if s.cfg.MaxSeatsPerUser > 0 {
+		owned, err := s.store.Reservations().With(tx).CountUserSeats(ctx, eventID, userID)
+		if err != nil {
+			return uuid.Nil, nil, nil, err
+		}
+		if owned+int64(len(seatIDs)) > int64(s.cfg.MaxSeatsPerUser) {
+			return uuid.Nil, nil, nil, ErrSeatLimitExceeded
+		}
+	}
+
+	var (
+		rid         uuid.UUID
+		held        []int64
+		unavailable []int64
+		err         error
+	)
+	if partial {
+		rid, held, unavailable, err = s.store.Reservations().
+			With(tx).
+			HoldAvailableSeats(ctx, eventID, userID, seatIDs, versions, ttl)
+	} else {
+		rid, held, err = s.store.Reservations().
+			With(tx).
+			HoldSeatsAtVersions(ctx, eventID, userID, seatIDs, versions, ttl)
+	}
+	if err != nil {
+		var se repository.SeatsUnavailableError
+		if errors.As(err, &se) {
+			return uuid.Nil, nil, nil, s.seatsUnavailableErr(ctx, eventID, se.SeatIDs)
+		}
+
+		if errors.Is(err, repository.ErrConflict) {
+			return uuid.Nil, nil, nil, ErrHoldConflict
+		}
+
+		if errors.Is(err, repository.ErrEventCancelled) {
+			return uuid.Nil, nil, nil, ErrEventCancelled
+		}
+
+		if errors.Is(err, repository.ErrNotFound) {
+			return uuid.Nil, nil, nil, ErrEventNotFound
+		}
+
+		return uuid.Nil, nil, nil, err
+	}
+
+	return rid, held, unavailable, nil
+}
+
+// missingSeats returns the IDs in seatIDs that none of seats has, in the
+// order of seatIDs.
+func missingSeats(seatIDs []int64, seats []domain.Seat) []int64 {
+	found := make(map[int64]bool, len(seats))
+	for _, seat := range seats {
+		found[seat.ID] = true
+	}
+
+	var missing []int64
+	for _, id := range seatIDs {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing
+}
+
+// dedupeSeats sorts seatIDs and drops duplicates, keeping versions aligned.
+// A seat listed twice with different versions is rejected.
+func dedupeSeats(seatIDs, versions []int64) ([]int64, []int64, error) {
+	if versions == nil {
+		seatIDs = slices.Clone(seatIDs)
+		slices.Sort(seatIDs)
+		return slices.Compact(seatIDs), nil, nil
+	}
+
+	if len(versions) != len(seatIDs) {
+		return nil, nil, ErrSeatVersionsMismatch
+	}
+
+	byID := make(map[int64]int64, len(seatIDs))
+	for i, id := range seatIDs {
+		if v, dup := byID[id]; dup && v != versions[i] {
+			return nil, nil, ErrSeatVersionsMismatch
+		}
+		byID[id] = versions[i]
+	}
+
+	ids := slices.Sorted(maps.Keys(byID))
+	out := make([]int64, len(ids))
+	for i, id := range ids {
+		out[i] = byID[id]
+	}
+
+	return ids, out, nil
+}
+
+// seatsUnavailableErr tells a sold-out event apart from a conflict on some of
+// the requested seats. It counts outside the failed hold's transaction,
+// which has already moved some of the requested seats to the hold and would
+// make the event look sold out. If the counters cannot be read, the seats
+// are reported as taken.
+func (s *Service) seatsUnavailableErr(ctx context.Context, eventID int64, seatIDs []int64) error {
+	holdable, total, err := s.store.Query().CountHoldableSeats(ctx, eventID)
+	if err == nil && total > 0 && holdable == 0 {
+		return ErrEventSoldOut
+	}
+
+	return SeatsUnavailableError{SeatIDs: seatIDs}
+}
+
+// Confirm confirms a hold and creates an order. The order total is computed
+// from the prices of the held seats.
+//
+// Concurrent confirms of the same hold by the same user are collapsed:
+// callers that arrive while a confirm is in flight wait for it and share its
+// result. The shared confirm is detached from the first caller's
+// cancellation, so that caller going away does not fail the others, and is
+// bounded by confirmTimeout instead.
 //
 // Parameters:
 //   - ctx: request-scoped context.
 //   - holdID: ID of the hold to confirm.
-//   - totalCents: total amount for the order.
+//   - userID: ID of the user confirming; it must be the user who placed the hold.
 //
 // Returns:
 //   - uuid.UUID: the ID of the created order.
 //   - int64: the ID of the event the order is for.
 //   - error: reservation.ErrHoldConflict if the hold conflicts with an existing hold.
 //   - error: reservation.ErrHoldNotFound if the hold is not found.
+//   - error: reservation.ErrHoldNotOwned if the hold was placed by another user.
 //   - error: reservation.ErrHoldExpired if the hold has expired.
+//   - error: reservation.ErrAlreadyConfirmed if the hold was confirmed by an earlier call.
+//   - error: reservation.ErrNothingToConfirm if the hold has no seats left.
 func (s *Service) Confirm(
 	ctx context.Context,
 	holdID uuid.UUID,
-	totalCents int,
+	userID int64,
 ) (uuid.UUID, int64, error) {
+	type result struct {
+		orderID uuid.UUID
+		eventID int64
+	}
+
+	key := holdID.String() + ":" + strconv.FormatInt(userID, 10)
+	v, err, _ := s.confirms.Do(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), confirmTimeout)
+		defer cancel()
+
+		orderID, eventID, err := s.confirm(ctx, holdID, userID)
+		return result{orderID: orderID, eventID: eventID}, err
+	})
+	res := v.(result)
+
+	return res.orderID, res.eventID, err
+}
+
+func (s *Service) confirm(
+	ctx context.Context,
+	holdID uuid.UUID,
+	userID int64,
+) (_ uuid.UUID, _ int64, err error) {
 	const op = "service.reservation.Confirm"
 
-	if totalCents <= 0 {
-		return uuid.Nil, 0, fmt.Errorf("%s: total must be positive", op)
-	}
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
 
 	var orderID uuid.UUID
 	var eventID int64
 
-	err := s.uow.Do(ctx, func(
+	err = s.uow.DoWithOpts(ctx, s.txOptions(), func(
 		ctx context.Context,
 		tx postgresrepo.DB,
 		after func(uow.AfterCommit),
 	) error {
-		eid, err := s.store.Query().With(tx).EventIDByHold(ctx, holdID)
+		eid, owner, err := s.store.Query().With(tx).HoldOwner(ctx, holdID)
 		if err != nil {
 			if errors.Is(err, repository.ErrNotFound) {
-				return fmt.Errorf("%s:%w", op, ErrHoldNotFound)
+				return fmt.Errorf("%s:%w", op, s.holdGoneErr(ctx, tx, holdID, ErrHoldNotFound))
 			}
 
 			return fmt.Errorf("%s:%w", op, err)
 		}
 
+		if owner != userID {
+			return fmt.Errorf("%s:%w", op, ErrHoldNotOwned)
+		}
+
 		eventID = eid
 
 		oid, err := s.store.Reservations().
 			With(tx).
-			ConfirmHold(ctx, holdID, totalCents)
+			ConfirmHold(ctx, holdID)
 		if err != nil {
 			if errors.Is(err, repository.ErrConflict) {
 				return fmt.Errorf("%s:%w", op, ErrHoldConflict)
 			}
 
 			if errors.Is(err, repository.ErrHoldExpired) {
-				return fmt.Errorf("%s:%w", op, ErrHoldExpired)
+				return fmt.Errorf("%s:%w", op, s.holdGoneErr(ctx, tx, holdID, ErrHoldExpired))
+			}
+
+			if errors.Is(err, repository.ErrNothingToConfirm) {
+				return fmt.Errorf("%s:%w", op, ErrNothingToConfirm)
 			}
 
 			return fmt.Errorf("%s:%w", op, err)
@@ -189,37 +777,55 @@ func (s *Service) Confirm(
 
 		orderID = oid
 
-		after(func(ctx context.Context) {
-			_ = s.cache.InvalidateEvent(ctx, eventID)
-			_ = s.pubsub.PublishEventChanged(ctx, eventID)
+		after(func(ctx context.Context) error {
+			return errors.Join(
+				s.cache.InvalidateEvent(ctx, eventID),
+				s.pubsub.PublishEventChanged(ctx, eventID),
+			)
 		})
 
 		return nil
 	})
+	s.metrics.IncReservation("confirm", outcome(err))
 
 	return orderID, eventID, err
 }
 
+// holdGoneErr explains why a hold can no longer be confirmed: if an order
+// was created from it, ErrAlreadyConfirmed is returned, otherwise fallback.
+func (s *Service) holdGoneErr(ctx context.Context, tx postgresrepo.DB, holdID uuid.UUID, fallback error) error {
+	if _, err := s.store.Query().With(tx).OrderIDByHold(ctx, holdID); err == nil {
+		return ErrAlreadyConfirmed
+	}
+
+	return fallback
+}
+
 // Cancel cancels a hold.
 //
 // Parameters:
 //   - ctx: request-scoped context.
 //   - holdID: ID of the hold to cancel.
+//   - userID: ID of the user cancelling; it must be the user who placed the hold.
 //
 // Returns:
 //   - int64: the ID of the event the hold was for.
 //   - error: reservation.ErrHoldNotFound if the hold is not found.
-func (s *Service) Cancel(ctx context.Context, holdID uuid.UUID) (int64, error) {
+//   - error: reservation.ErrHoldNotOwned if the hold was placed by another user.
+func (s *Service) Cancel(ctx context.Context, holdID uuid.UUID, userID int64) (_ int64, err error) {
 	const op = "service.reservation.Cancel"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
+
 	var eventID int64
 
-	err := s.uow.Do(ctx, func(
+	err = s.uow.DoWithOpts(ctx, s.txOptions(), func(
 		ctx context.Context,
 		tx postgresrepo.DB,
 		after func(uow.AfterCommit),
 	) error {
-		eid, err := s.store.Query().With(tx).EventIDByHold(ctx, holdID)
+		eid, owner, err := s.store.Query().With(tx).HoldOwner(ctx, holdID)
 		if err != nil {
 			if errors.Is(err, repository.ErrNotFound) {
 				return fmt.Errorf("%s:%w", op, ErrHoldNotFound)
@@ -228,6 +834,10 @@ func (s *Service) Cancel(ctx context.Context, holdID uuid.UUID) (int64, error) {
 			return fmt.Errorf("%s:%w", op, err)
 		}
 
+		if owner != userID {
+			return fmt.Errorf("%s:%w", op, ErrHoldNotOwned)
+		}
+
 		eventID = eid
 
 		if err := s.store.Reservations().With(tx).CancelHold(ctx, holdID); err != nil {
@@ -238,9 +848,11 @@ func (s *Service) Cancel(ctx context.Context, holdID uuid.UUID) (int64, error) {
 			return fmt.Errorf("%s:%w", op, err)
 		}
 
-		after(func(ctx context.Context) {
-			_ = s.cache.InvalidateEvent(ctx, eventID)
-			_ = s.pubsub.PublishEventChanged(ctx, eventID)
+		after(func(ctx context.Context) error {
+			return errors.Join(
+				s.cache.InvalidateEvent(ctx, eventID),
+				s.pubsub.PublishEventChanged(ctx, eventID),
+			)
 		})
 
 		return nil
@@ -249,25 +861,291 @@ func (s *Service) Cancel(ctx context.Context, holdID uuid.UUID) (int64, error) {
 	return eventID, err
 }
 
-// Expire expires all holds that have exceeded their TTL.
+// ExtendHold extends an active hold by extraTTL. The total hold lifetime,
+// measured from its creation, is capped by Config.MaxHoldTTL.
 //
 // Parameters:
 //   - ctx: request-scoped context.
+//   - holdID: ID of the hold to extend.
+//   - userID: ID of the user extending; it must be the user who placed the hold.
+//   - extraTTL: additional time requested for the hold.
 //
 // Returns:
-//   - int64: the number of expired holds.
+//   - time.Time: the new expiry of the hold.
+//   - error: reservation.ErrHoldNotFound if the hold is not found.
+//   - error: reservation.ErrHoldNotOwned if the hold was placed by another user.
+//   - error: reservation.ErrHoldExpired if the hold has already expired.
+//   - error: reservation.ErrInvalidExtension if extraTTL is not positive.
+func (s *Service) ExtendHold(
+	ctx context.Context,
+	holdID uuid.UUID,
+	userID int64,
+	extraTTL time.Duration,
+) (_ time.Time, err error) {
+	const op = "service.reservation.ExtendHold"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
+
+	if extraTTL <= 0 {
+		return time.Time{}, fmt.Errorf("%s:%w", op, ErrInvalidExtension)
+	}
+
+	var expiresAt time.Time
+
+	err = s.uow.DoWithOpts(ctx, s.txOptions(), func(
+		ctx context.Context,
+		tx postgresrepo.DB,
+		after func(uow.AfterCommit),
+	) error {
+		_, owner, err := s.store.Query().With(tx).HoldOwner(ctx, holdID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%s:%w", op, ErrHoldNotFound)
+			}
+
+			return fmt.Errorf("%s:%w", op, err)
+		}
+
+		if owner != userID {
+			return fmt.Errorf("%s:%w", op, ErrHoldNotOwned)
+		}
+
+		eventID, exp, err := s.store.Reservations().
+			With(tx).
+			ExtendHold(ctx, holdID, extraTTL, s.cfg.MaxHoldTTL)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%s:%w", op, ErrHoldNotFound)
+			}
+
+			if errors.Is(err, repository.ErrHoldExpired) {
+				return fmt.Errorf("%s:%w", op, ErrHoldExpired)
+			}
+
+			return fmt.Errorf("%s:%w", op, err)
+		}
+
+		expiresAt = exp
+
+		after(func(ctx context.Context) error {
+			return errors.Join(
+				s.cache.InvalidateEvent(ctx, eventID),
+				s.pubsub.PublishEventChanged(ctx, eventID),
+			)
+		})
+
+		return nil
+	})
+	s.metrics.IncReservation("extend_hold", outcome(err))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return expiresAt, nil
+}
+
+// GetHold returns an active hold with its held seat IDs.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - holdID: ID of the hold to look up.
+//   - userID: ID of the requesting user; it must be the user who placed the hold.
+//
+// Returns:
+//   - *domain.Hold: the hold when it exists and has not expired.
+//   - error: reservation.ErrHoldNotFound if the hold is missing or expired.
+//   - error: reservation.ErrHoldNotOwned if the hold was placed by another user.
+func (s *Service) GetHold(ctx context.Context, holdID uuid.UUID, userID int64) (_ *domain.Hold, err error) {
+	const op = "service.reservation.GetHold"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
+
+	h, err := s.store.Reservations().GetHold(ctx, holdID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%s:%w", op, ErrHoldNotFound)
+		}
+
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if h.UserID != userID {
+		return nil, fmt.Errorf("%s:%w", op, ErrHoldNotOwned)
+	}
+
+	return h, nil
+}
+
+// Expire expires all holds that have exceeded their TTL. The cache of every
+// event that had seats released is invalidated and an event-changed
+// notification is published for it.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//
+// Returns:
+//   - int64: the number of released seats.
+//   - []int64: IDs of the events that had seats released.
 //   - error: if the expiration fails.
-func (s *Service) Expire(ctx context.Context) (int64, error) {
+func (s *Service) Expire(ctx context.Context) (_ int64, _ []int64, err error) {
 	const op = "service.reservation.Expire"
 
-	released, err := s.store.Reservations().ExpireHolds(ctx)
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
+
+	released, eventIDs, err := s.store.Reservations().ExpireHolds(ctx)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	for _, eventID := range eventIDs {
+		s.eventChanged(ctx, eventID)
+	}
+
+	return released, eventIDs, nil
+}
+
+// ExpireForEvent expires the holds of a single event that have exceeded
+// their TTL.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event whose holds are expired.
+//
+// Returns:
+//   - int64: the number of released seats.
+//   - error: if the expiration fails.
+func (s *Service) ExpireForEvent(ctx context.Context, eventID int64) (_ int64, err error) {
+	const op = "service.reservation.ExpireForEvent"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
+
+	released, err := s.store.Reservations().ExpireHoldsForEvent(ctx, eventID)
 	if err != nil {
 		return 0, fmt.Errorf("%s:%w", op, err)
 	}
 
+	if released > 0 {
+		s.eventChanged(ctx, eventID)
+	}
+
 	return released, nil
 }
 
+// eventChanged invalidates the cached views of an event and notifies
+// subscribers. Failures are ignored: the seats are already released and
+// cached entries expire on their own.
+func (s *Service) eventChanged(ctx context.Context, eventID int64) {
+	_ = s.cache.InvalidateEvent(ctx, eventID)
+	_ = s.pubsub.PublishEventChanged(ctx, eventID)
+}
+
+// JoinWaitlist queues a user for seats of an event. Joining again for the
+// same event returns the existing entry, unless the user was already
+// notified: then they are queued again at the back.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event to wait for.
+//   - userID: ID of the waiting user.
+//   - seatCount: number of seats the user wants.
+//
+// Returns:
+//   - *domain.WaitlistEntry: the waitlist entry.
+//   - error: reservation.ErrEventNotFound if the event does not exist.
+func (s *Service) JoinWaitlist(
+	ctx context.Context,
+	eventID, userID int64,
+	seatCount int,
+) (_ *domain.WaitlistEntry, err error) {
+	const op = "service.reservation.JoinWaitlist"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
+
+	if seatCount <= 0 {
+		return nil, fmt.Errorf("%s: seat count must be positive", op)
+	}
+
+	e, err := s.store.Reservations().JoinWaitlist(ctx, eventID, userID, seatCount)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%s:%w", op, ErrEventNotFound)
+		}
+
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return e, nil
+}
+
+// NotifyWaitlist picks the earliest pending waitlist entry of an event and
+// marks it notified when enough seats are available for it, then hands it to
+// Config.Notifier once committed. It is meant to run whenever seats of the
+// event are released.
+//
+// Parameters:
+//   - ctx: request-scoped context.
+//   - eventID: ID of the event whose seats changed.
+//
+// Returns:
+//   - *domain.WaitlistEntry: the notified entry, or nil if nobody was notified.
+//   - error: if the waitlist could not be processed.
+func (s *Service) NotifyWaitlist(ctx context.Context, eventID int64) (_ *domain.WaitlistEntry, err error) {
+	const op = "service.reservation.NotifyWaitlist"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
+
+	var notified *domain.WaitlistEntry
+
+	err = s.uow.DoWithOpts(ctx, s.txOptions(), func(
+		ctx context.Context,
+		tx postgresrepo.DB,
+		after func(uow.AfterCommit),
+	) error {
+		counts, err := s.store.Query().With(tx).CountsByStatus(ctx, eventID)
+		if err != nil {
+			return fmt.Errorf("%s:%w", op, err)
+		}
+
+		if counts.Available == 0 {
+			return nil
+		}
+
+		e, err := s.store.Reservations().
+			With(tx).
+			NotifyNextWaitlisted(ctx, eventID, counts.Available)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil
+			}
+
+			return fmt.Errorf("%s:%w", op, err)
+		}
+
+		notified = e
+
+		after(func(ctx context.Context) error {
+			if err := s.cfg.Notifier.NotifyWaitlisted(ctx, *e); err != nil {
+				err = errors.Join(err, s.store.Reservations().ResetWaitlistNotification(ctx, e.ID))
+				return fmt.Errorf("%s:%w", op, err)
+			}
+			return nil
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", op, err)
+	}
+
+	return notified, nil
+}
+
 // Availability returns the availability of an event.
 //
 // Parameters:
@@ -277,9 +1155,12 @@ func (s *Service) Expire(ctx context.Context) (int64, error) {
 // Returns:
 //   - *domain.EventCounts: the availability counts for the event.
 //   - error: if the availability check fails.
-func (s *Service) Availability(ctx context.Context, eventID int64) (*domain.EventCounts, error) {
+func (s *Service) Availability(ctx context.Context, eventID int64) (_ *domain.EventCounts, err error) {
 	const op = "service.reservation.Availability"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer tracing.End(span, &err)
+
 	eventCounts, err := s.store.Query().CountsByStatus(ctx, eventID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
@@ -292,6 +1173,15 @@ func (s *Service) Availability(ctx context.Context, eventID int64) (*domain.Even
 	return eventCounts, nil
 }
 
+// holdCutoff returns the moment after which the event no longer accepts holds.
+func (s *Service) holdCutoff(e *domain.Event) time.Time {
+	if s.cfg.HoldCutoff == HoldCutoffEventStart {
+		return e.Starts
+	}
+
+	return e.Ends
+}
+
 func (s *Service) clampTTL(ttl time.Duration) time.Duration {
 	if ttl < s.cfg.MinHoldTTL {
 		return s.cfg.MinHoldTTL
@@ -303,3 +1193,52 @@ func (s *Service) clampTTL(ttl time.Duration) time.Duration {
 
 	return ttl
 }
+
+// isContiguous reports whether seats, ordered by (section, row, number), lie
+// in a single section and row with consecutive numbers.
+func isContiguous(seats []domain.Seat) bool {
+	for i := 1; i < len(seats); i++ {
+		prev, cur := seats[i-1], seats[i]
+		if cur.Section != prev.Section || cur.Row != prev.Row || cur.Number != prev.Number+1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// outcome maps a reservation error to a low-cardinality metrics label.
+func outcome(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, ErrSeatsUnavailable):
+		return "seats_unavailable"
+	case errors.Is(err, ErrEventSoldOut):
+		return "sold_out"
+	case errors.Is(err, ErrSeatLimitExceeded):
+		return "seat_limit"
+	case errors.Is(err, ErrHoldConflict):
+		return "conflict"
+	case errors.Is(err, ErrHoldExpired):
+		return "hold_expired"
+	case errors.Is(err, ErrHoldNotFound):
+		return "hold_not_found"
+	case errors.Is(err, ErrAlreadyConfirmed):
+		return "already_confirmed"
+	case errors.Is(err, ErrNothingToConfirm):
+		return "nothing_to_confirm"
+	case errors.Is(err, ErrGAUnavailable):
+		return "ga_unavailable"
+	case errors.Is(err, ErrTooManySeats):
+		return "too_many_seats"
+	case errors.Is(err, ErrEventEnded), errors.Is(err, ErrEventCancelled):
+		return "event_closed"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrSeatsNotContiguous):
+		return "not_contiguous"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,1284 @@
+package reservation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/metrics"
+	"github.com/kirinyoku/tix-go/internal/postgres/pgtest"
+	postgresrepo "github.com/kirinyoku/tix-go/internal/repository/postgres"
+	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+	"github.com/kirinyoku/tix-go/internal/uow"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newTestService returns a service over a fresh schema, without rate
+// limits.
+func newTestService(t *testing.T, cfg Config) (*Service, *pgxpool.Pool) {
+	t.Helper()
+
+	svc, pool, _ := newTestServiceRedis(t, cfg)
+	return svc, pool
+}
+
+// newTestServiceRedis is newTestService that also returns the in-memory
+// Redis behind the cache and pub/sub.
+func newTestServiceRedis(t *testing.T, cfg Config) (*Service, *pgxpool.Pool, *miniredis.Miniredis) {
+	t.Helper()
+
+	pool := pgtest.New(t)
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	svc := New(
+		postgresrepo.NewStore(pool),
+		redisrepo.New(rdb),
+		redisrepo.NewEventsPubSub(rdb),
+		nil,
+		nil,
+		metrics.New(prometheus.NewRegistry()),
+		cfg,
+	)
+
+	return svc, pool, mr
+}
+
+func TestHoldOwnership(t *testing.T) {
+	svc, pool := newTestService(t, Config{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+	ctx := context.Background()
+
+	const owner, other = 1, 2
+
+	holdID, _, _, err := svc.CreateHold(ctx, owner, eventID, seatIDs[:2], nil, time.Minute, "", false, false)
+	if err != nil {
+		t.Fatalf("create hold: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func(userID int64) error
+	}{
+		{name: "get", call: func(userID int64) error {
+			_, err := svc.GetHold(ctx, holdID, userID)
+			return err
+		}},
+		{name: "extend", call: func(userID int64) error {
+			_, err := svc.ExtendHold(ctx, holdID, userID, 10*time.Second)
+			return err
+		}},
+		{name: "confirm", call: func(userID int64) error {
+			_, _, err := svc.Confirm(ctx, holdID, userID)
+			return err
+		}},
+		{name: "cancel", call: func(userID int64) error {
+			_, err := svc.Cancel(ctx, holdID, userID)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(other); !errors.Is(err, ErrHoldNotOwned) {
+				t.Errorf("other user: err = %v, want %v", err, ErrHoldNotOwned)
+			}
+		})
+	}
+
+	if _, err := svc.GetHold(ctx, holdID, owner); err != nil {
+		t.Fatalf("owner get: %v", err)
+	}
+	if _, err := svc.ExtendHold(ctx, holdID, owner, 10*time.Second); err != nil {
+		t.Fatalf("owner extend: %v", err)
+	}
+	if _, err := svc.Cancel(ctx, holdID, owner); err != nil {
+		t.Fatalf("owner cancel: %v", err)
+	}
+	if _, err := svc.GetHold(ctx, holdID, owner); !errors.Is(err, ErrHoldNotFound) {
+		t.Errorf("after cancel: err = %v, want %v", err, ErrHoldNotFound)
+	}
+}
+
+func TestExtendHoldClamp(t *testing.T) {
+	svc, pool, mr := newTestServiceRedis(t, Config{MaxHoldTTL: 2 * time.Minute})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	ctx := context.Background()
+
+	holdID, _, _, err := svc.CreateHold(ctx, 1, eventID, seatIDs[:1], nil, time.Minute, "", false, false)
+	if err != nil {
+		t.Fatalf("create hold: %v", err)
+	}
+	var created time.Time
+	if err := pool.QueryRow(ctx, `SELECT created_at FROM holds WHERE id = $1`, holdID).Scan(&created); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		name  string
+		extra time.Duration
+		want  time.Duration
+	}{
+		{name: "within max", extra: 30 * time.Second, want: 90 * time.Second},
+		{name: "up to max", extra: 30 * time.Second, want: 2 * time.Minute},
+		{name: "past max", extra: time.Hour, want: 2 * time.Minute},
+	}
+	for _, st := range steps {
+		if err := mr.Set(redisrepo.KeyEventSeatMap(eventID), "{}"); err != nil {
+			t.Fatal(err)
+		}
+		got, err := svc.ExtendHold(ctx, holdID, 1, st.extra)
+		if err != nil {
+			t.Fatalf("%s: %v", st.name, err)
+		}
+		if mr.Exists(redisrepo.KeyEventSeatMap(eventID)) {
+			t.Errorf("%s: seat map still cached", st.name)
+		}
+		if want := created.Add(st.want); !got.Equal(want) {
+			t.Errorf("%s: expires at %v, want %v", st.name, got, want)
+		}
+		var seatExpiry time.Time
+		if err := pool.QueryRow(ctx,
+			`SELECT hold_expires_at FROM event_seats WHERE hold_id = $1`, holdID,
+		).Scan(&seatExpiry); err != nil {
+			t.Fatal(err)
+		}
+		if !seatExpiry.Equal(got) {
+			t.Errorf("%s: seat expires at %v, hold at %v", st.name, seatExpiry, got)
+		}
+	}
+
+	if _, err := svc.ExtendHold(ctx, holdID, 1, 0); !errors.Is(err, ErrInvalidExtension) {
+		t.Errorf("zero extra: err = %v, want %v", err, ErrInvalidExtension)
+	}
+
+	if _, err := pool.Exec(ctx,
+		`UPDATE holds SET expires_at = now() - interval '1 second' WHERE id = $1`, holdID,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ExtendHold(ctx, holdID, 1, 10*time.Second); !errors.Is(err, ErrHoldExpired) {
+		t.Errorf("lapsed: err = %v, want %v", err, ErrHoldExpired)
+	}
+}
+
+func TestIsolationLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		level pgx.TxIsoLevel
+		want  string
+	}{
+		{name: "default", want: "serializable"},
+		{name: "serializable", level: pgx.Serializable, want: "serializable"},
+		{name: "repeatable read", level: pgx.RepeatableRead, want: "repeatable read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t, Config{IsolationLevel: tt.level})
+
+			var got string
+			err := svc.uow.DoWithOpts(context.Background(), svc.txOptions(), func(
+				ctx context.Context,
+				tx postgresrepo.DB,
+				_ func(uow.AfterCommit),
+			) error {
+				return tx.QueryRow(ctx, `SHOW transaction_isolation`).Scan(&got)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("transaction_isolation = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLimits(t *testing.T) {
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	reg := prometheus.NewRegistry()
+	svc := &Service{
+		ipLimiter:   redisrepo.NewSlidingWindowLimiter(rdb, "rl:hold:client", 2, time.Minute),
+		userLimiter: redisrepo.NewSlidingWindowLimiter(rdb, "rl:hold:user", 1, time.Minute),
+		metrics:     metrics.New(reg),
+	}
+	ctx := context.Background()
+
+	if err := svc.checkLimits(ctx, 1, "ip:a", "hold"); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+
+	// User 1 is over its limit; the rejection must not count against the
+	// shared client budget.
+	for range 3 {
+		var rl RateLimitedError
+		if err := svc.checkLimits(ctx, 1, "ip:a", "hold"); !errors.As(err, &rl) {
+			t.Fatalf("over user limit: err = %v, want %T", err, rl)
+		}
+	}
+	if err := svc.checkLimits(ctx, 2, "ip:a", "hold"); err != nil {
+		t.Fatalf("other user on the same client: %v", err)
+	}
+
+	want := `
+# HELP tixgo_reservation_outcomes_total Reservation operations by operation and outcome.
+# TYPE tixgo_reservation_outcomes_total counter
+tixgo_reservation_outcomes_total{operation="hold",outcome="rate_limited"} 3
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "tixgo_reservation_outcomes_total"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClampTTL(t *testing.T) {
+	svc := &Service{cfg: Config{MinHoldTTL: 15 * time.Second, MaxHoldTTL: 5 * time.Minute}}
+
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		want time.Duration
+	}{
+		{name: "zero", ttl: 0, want: 15 * time.Second},
+		{name: "just below min", ttl: 15*time.Second - time.Nanosecond, want: 15 * time.Second},
+		{name: "at min", ttl: 15 * time.Second, want: 15 * time.Second},
+		{name: "between", ttl: time.Minute, want: time.Minute},
+		{name: "at max", ttl: 5 * time.Minute, want: 5 * time.Minute},
+		{name: "just above max", ttl: 5*time.Minute + time.Nanosecond, want: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := svc.clampTTL(tt.ttl); got != tt.want {
+			t.Errorf("%s: clampTTL(%v) = %v, want %v", tt.name, tt.ttl, got, tt.want)
+		}
+	}
+}
+
+// fakeLimiter admits requests while admit is set and otherwise reports
+// retry as the wait. It counts the requests it admitted.
+type fakeLimiter struct {
+	admit   bool
+	retry   time.Duration
+	allowed int
+}
+
+func (f *fakeLimiter) Allow(_ context.Context, _ string) (bool, int64, time.Duration, error) {
+	if !f.admit {
+		return false, 0, f.retry, nil
+	}
+	f.allowed++
+	return true, 0, 0, nil
+}
+
+func (f *fakeLimiter) Peek(_ context.Context, _ string) (bool, int64, time.Duration, error) {
+	if !f.admit {
+		return false, 0, f.retry, nil
+	}
+	return true, 0, 0, nil
+}
+
+func TestCreateHoldRateLimited(t *testing.T) {
+	tests := []struct {
+		name      string
+		ip, user  *fakeLimiter
+		wantRetry time.Duration
+	}{
+		{
+			name:      "client limit",
+			ip:        &fakeLimiter{retry: 2 * time.Second},
+			user:      &fakeLimiter{admit: true},
+			wantRetry: 2 * time.Second,
+		},
+		{
+			name:      "user limit",
+			ip:        &fakeLimiter{admit: true},
+			user:      &fakeLimiter{retry: 3 * time.Second},
+			wantRetry: 3 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The limits are checked before any store access, so the service
+			// needs neither Postgres nor Redis.
+			svc := &Service{
+				ipLimiter:   tt.ip,
+				userLimiter: tt.user,
+				cfg:         Config{MinHoldTTL: 15 * time.Second, MaxHoldTTL: 5 * time.Minute, MaxSeatsPerHold: 100},
+			}
+
+			_, _, _, err := svc.CreateHold(context.Background(), 1, 1, []int64{1}, nil, time.Minute, "ip:a", false, false)
+			if !errors.Is(err, ErrRateLimited) {
+				t.Fatalf("err = %v, want %v", err, ErrRateLimited)
+			}
+			var rl RateLimitedError
+			if !errors.As(err, &rl) || rl.RetryAfter != tt.wantRetry {
+				t.Errorf("err = %v, want retry after %v", err, tt.wantRetry)
+			}
+			if want := fmt.Sprintf("rate limited, retry in %s", tt.wantRetry); !strings.Contains(err.Error(), want) {
+				t.Errorf("message = %q, want it to contain %q", err.Error(), want)
+			}
+			if tt.ip.allowed+tt.user.allowed != 0 {
+				t.Errorf("a rejected request was counted: client %d, user %d", tt.ip.allowed, tt.user.allowed)
+			}
+		})
+	}
+}
+
+func TestHoldsRateLimited(t *testing.T) {
+	// Every hold entry point reports throttling as ErrRateLimited, before
+	// any store access.
+	svc := &Service{
+		ipLimiter:   &fakeLimiter{admit: true},
+		userLimiter: &fakeLimiter{retry: time.Second},
+		cfg:         Config{MinHoldTTL: 15 * time.Second, MaxHoldTTL: 5 * time.Minute, MaxSeatsPerHold: 100},
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "seats", call: func() error {
+			_, _, _, err := svc.CreateHold(ctx, 1, 1, []int64{1}, nil, time.Minute, "ip:a", false, false)
+			return err
+		}},
+		{name: "general admission", call: func() error {
+			_, err := svc.CreateGAHold(ctx, 1, 1, 2, time.Minute, "ip:a")
+			return err
+		}},
+		{name: "suggested", call: func() error {
+			_, _, err := svc.SuggestAndHold(ctx, 1, 1, 2, time.Minute, "ip:a")
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		if err := tt.call(); !errors.Is(err, ErrRateLimited) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, ErrRateLimited)
+		}
+	}
+}
+
+func TestSpanRecordsError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	svc := &Service{}
+	if _, _, _, err := svc.CreateHold(context.Background(), 1, 1, nil, nil, time.Minute, "", false, false); err == nil {
+		t.Fatal("want error for an empty seat list")
+	}
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended spans = %d, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "service.reservation.CreateHold" {
+		t.Errorf("span name = %q", span.Name())
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want %v", span.Status().Code, codes.Error)
+	}
+	if len(span.Events()) == 0 || span.Events()[0].Name != "exception" {
+		t.Errorf("span events = %v, want the recorded error", span.Events())
+	}
+}
+
+func TestCreateHoldUnavailableReason(t *testing.T) {
+	svc, pool := newTestService(t, Config{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	ctx := context.Background()
+
+	if _, _, _, err := svc.CreateHold(ctx, 1, eventID, seatIDs[:1], nil, time.Minute, "", false, false); err != nil {
+		t.Fatalf("first hold: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T)
+		seatIDs []int64
+		wantErr error
+		// wantTaken lists the seats the error should name as taken.
+		wantTaken []int64
+	}{
+		{
+			// The failed hold takes seatIDs[1] before failing on
+			// seatIDs[0]; that must not make the event look sold out.
+			name:      "some seats taken",
+			seatIDs:   seatIDs,
+			wantErr:   ErrSeatsUnavailable,
+			wantTaken: seatIDs[:1],
+		},
+		{
+			name: "sold out",
+			setup: func(t *testing.T) {
+				if _, _, _, err := svc.CreateHold(ctx, 3, eventID, seatIDs[1:], nil, time.Minute, "", false, false); err != nil {
+					t.Fatalf("second hold: %v", err)
+				}
+			},
+			seatIDs: seatIDs[:1],
+			wantErr: ErrEventSoldOut,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup(t)
+			}
+			_, _, _, err := svc.CreateHold(ctx, 2, eventID, tt.seatIDs, nil, time.Minute, "", false, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantTaken == nil {
+				return
+			}
+			var se SeatsUnavailableError
+			if !errors.As(err, &se) {
+				t.Fatalf("err = %v, want a SeatsUnavailableError", err)
+			}
+			if !slices.Equal(se.SeatIDs, tt.wantTaken) {
+				t.Errorf("taken seats = %v, want %v", se.SeatIDs, tt.wantTaken)
+			}
+		})
+	}
+}
+
+func TestCreateGAHoldConcurrent(t *testing.T) {
+	tests := []struct {
+		name      string
+		isolation pgx.TxIsoLevel
+		// exact: every request fails only for lack of capacity, so the
+		// capacity is sold out exactly.
+		exact bool
+	}{
+		{name: "read committed", isolation: pgx.ReadCommitted, exact: true},
+		{name: "serializable", isolation: pgx.Serializable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const capacity, clients = 5, 20
+
+			svc, pool := newTestService(t, Config{IsolationLevel: tt.isolation})
+			eventID, _ := pgtest.SeedEvent(t, pool, 0, 0, capacity)
+
+			var (
+				wg   sync.WaitGroup
+				mu   sync.Mutex
+				held int
+			)
+			for i := range clients {
+				wg.Add(1)
+				go func(userID int64) {
+					defer wg.Done()
+					_, err := svc.CreateGAHold(context.Background(), userID, eventID, 1, time.Minute, "")
+					if err != nil && tt.exact && !errors.Is(err, ErrGAUnavailable) {
+						t.Errorf("user %d: %v", userID, err)
+					}
+					if err == nil {
+						mu.Lock()
+						held++
+						mu.Unlock()
+					}
+				}(int64(i + 1))
+			}
+			wg.Wait()
+
+			var gaHeld int
+			if err := pool.QueryRow(context.Background(),
+				`SELECT ga_held FROM events WHERE id = $1`, eventID,
+			).Scan(&gaHeld); err != nil {
+				t.Fatal(err)
+			}
+			if held > capacity || gaHeld != held {
+				t.Fatalf("held %d holds, ga_held = %d, capacity %d", held, gaHeld, capacity)
+			}
+			if tt.exact && held != capacity {
+				t.Errorf("held %d holds, want %d", held, capacity)
+			}
+		})
+	}
+}
+
+func TestCreateGAHold(t *testing.T) {
+	svc, pool, mr := newTestServiceRedis(t, Config{})
+	eventID, _ := pgtest.SeedEvent(t, pool, 0, 0, 2)
+	ctx := context.Background()
+
+	if _, err := svc.CreateGAHold(ctx, 1, eventID, 0, time.Minute, ""); !errors.Is(err, ErrNoTicketsRequested) {
+		t.Fatalf("zero qty: err = %v, want %v", err, ErrNoTicketsRequested)
+	}
+
+	if err := mr.Set(redisrepo.KeyEventAvailability(eventID), "{}"); err != nil {
+		t.Fatal(err)
+	}
+	expired, err := svc.CreateGAHold(ctx, 1, eventID, 2, time.Minute, "")
+	if err != nil {
+		t.Fatalf("first hold: %v", err)
+	}
+	if mr.Exists(redisrepo.KeyEventAvailability(eventID)) {
+		t.Error("availability still cached after the hold")
+	}
+	if _, err := svc.CreateGAHold(ctx, 2, eventID, 1, time.Minute, ""); !errors.Is(err, ErrGAUnavailable) {
+		t.Fatalf("full: err = %v, want %v", err, ErrGAUnavailable)
+	}
+
+	// Once the first hold expires its tickets are free again, before any
+	// sweep.
+	if _, err := pool.Exec(ctx,
+		`UPDATE holds SET expires_at = now() - interval '1 second' WHERE id = $1`, expired,
+	); err != nil {
+		t.Fatal(err)
+	}
+	holdID, err := svc.CreateGAHold(ctx, 2, eventID, 2, time.Minute, "")
+	if err != nil {
+		t.Fatalf("after expiry: %v", err)
+	}
+
+	var logged int
+	if err := pool.QueryRow(ctx,
+		`SELECT count(*) FROM event_log WHERE hold_id = $1 AND kind = 'hold_expired' AND seat_count = 2`, expired,
+	).Scan(&logged); err != nil {
+		t.Fatal(err)
+	}
+	if logged != 1 {
+		t.Errorf("hold_expired entries = %d, want 1", logged)
+	}
+
+	orderID, _, err := svc.Confirm(ctx, holdID, 2)
+	if err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	var tickets int
+	if err := pool.QueryRow(ctx,
+		`SELECT count(*) FROM tickets WHERE order_id = $1 AND seat_id IS NULL`, orderID,
+	).Scan(&tickets); err != nil {
+		t.Fatal(err)
+	}
+	if tickets != 2 {
+		t.Errorf("general-admission tickets = %d, want 2", tickets)
+	}
+}
+
+func TestCreateHoldSeatVersions(t *testing.T) {
+	svc, pool := newTestService(t, Config{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 3, 0)
+	ctx := context.Background()
+
+	seats, err := svc.store.Query().SeatMap(ctx, eventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := make(map[int64]int64, len(seats))
+	for _, s := range seats {
+		versions[s.ID] = s.Version
+	}
+
+	// Another user holds and releases seatIDs[0], so its version moves on.
+	holdID, _, _, err := svc.CreateHold(ctx, 2, eventID, seatIDs[:1], nil, time.Minute, "", false, false)
+	if err != nil {
+		t.Fatalf("other hold: %v", err)
+	}
+	if _, err := svc.Cancel(ctx, holdID, 2); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		seatIDs  []int64
+		versions []int64
+		partial  bool
+		wantErr  error
+		wantHeld []int64
+		// wantUnavailable lists the seats a partial hold leaves out.
+		wantUnavailable []int64
+	}{
+		{
+			name:     "mismatched length",
+			seatIDs:  seatIDs[1:],
+			versions: []int64{versions[seatIDs[1]]},
+			wantErr:  ErrSeatVersionsMismatch,
+		},
+		{
+			name:     "conflicting duplicate",
+			seatIDs:  []int64{seatIDs[1], seatIDs[1]},
+			versions: []int64{versions[seatIDs[1]], versions[seatIDs[1]] + 1},
+			wantErr:  ErrSeatVersionsMismatch,
+		},
+		{
+			name:     "stale version",
+			seatIDs:  seatIDs[:2],
+			versions: []int64{versions[seatIDs[0]], versions[seatIDs[1]]},
+			wantErr:  ErrSeatsUnavailable,
+		},
+		{
+			name:            "stale version partial",
+			seatIDs:         seatIDs[:2],
+			versions:        []int64{versions[seatIDs[0]], versions[seatIDs[1]]},
+			partial:         true,
+			wantHeld:        seatIDs[1:2],
+			wantUnavailable: seatIDs[:1],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, held, unavailable, err := svc.CreateHold(ctx, 1, eventID, tt.seatIDs, tt.versions, time.Minute, "", false, tt.partial)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(held, tt.wantHeld) {
+				t.Errorf("held = %v, want %v", held, tt.wantHeld)
+			}
+			if !slices.Equal(unavailable, tt.wantUnavailable) {
+				t.Errorf("unavailable = %v, want %v", unavailable, tt.wantUnavailable)
+			}
+		})
+	}
+}
+
+func TestCreateHoldDuplicateSeats(t *testing.T) {
+	svc, pool := newTestService(t, Config{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	ctx := context.Background()
+
+	// A seat listed twice is held once instead of failing the hold.
+	_, held, _, err := svc.CreateHold(ctx, 1, eventID, []int64{seatIDs[0], seatIDs[0], seatIDs[1]}, nil, time.Minute, "", false, false)
+	if err != nil {
+		t.Fatalf("create hold: %v", err)
+	}
+	if !slices.Equal(held, seatIDs) {
+		t.Errorf("held = %v, want %v", held, seatIDs)
+	}
+}
+
+func TestDedupeSeats(t *testing.T) {
+	tests := []struct {
+		name         string
+		seatIDs      []int64
+		versions     []int64
+		wantIDs      []int64
+		wantVersions []int64
+		wantErr      error
+	}{
+		{name: "duplicates", seatIDs: []int64{1, 1, 2}, wantIDs: []int64{1, 2}},
+		{name: "unsorted", seatIDs: []int64{3, 1, 2, 3}, wantIDs: []int64{1, 2, 3}},
+		{
+			name:         "versions follow their seats",
+			seatIDs:      []int64{2, 1, 2},
+			versions:     []int64{7, 5, 7},
+			wantIDs:      []int64{1, 2},
+			wantVersions: []int64{5, 7},
+		},
+		{
+			name:     "conflicting versions",
+			seatIDs:  []int64{1, 1},
+			versions: []int64{1, 2},
+			wantErr:  ErrSeatVersionsMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, versions, err := dedupeSeats(tt.seatIDs, tt.versions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+			if !slices.Equal(versions, tt.wantVersions) {
+				t.Errorf("versions = %v, want %v", versions, tt.wantVersions)
+			}
+		})
+	}
+}
+
+func TestHoldLifecycleLog(t *testing.T) {
+	svc, pool := newTestService(t, Config{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	ctx := context.Background()
+
+	logged := func(t *testing.T, holdID uuid.UUID, kind string) int {
+		t.Helper()
+		var n int
+		if err := pool.QueryRow(ctx,
+			`SELECT count(*) FROM event_log WHERE hold_id = $1 AND kind = $2 AND seat_count = 2`, holdID, kind,
+		).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	expired, _, _, err := svc.CreateHold(ctx, 1, eventID, seatIDs, nil, time.Minute, "", false, false)
+	if err != nil {
+		t.Fatalf("first hold: %v", err)
+	}
+	if _, err := svc.ExtendHold(ctx, expired, 1, 10*time.Second); err != nil {
+		t.Fatalf("extend: %v", err)
+	}
+	if n := logged(t, expired, "hold_extended"); n != 1 {
+		t.Errorf("hold_extended entries = %d, want 1", n)
+	}
+
+	// Once the first hold expires its seats can be held again before any
+	// sweep, and the expired hold is logged and deleted on the way.
+	if _, err := pool.Exec(ctx,
+		`UPDATE holds SET expires_at = now() - interval '1 second' WHERE id = $1`, expired,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Exec(ctx,
+		`UPDATE event_seats SET hold_expires_at = now() - interval '1 second' WHERE hold_id = $1`, expired,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := svc.CreateHold(ctx, 2, eventID, seatIDs, nil, time.Minute, "", false, false); err != nil {
+		t.Fatalf("after expiry: %v", err)
+	}
+
+	if n := logged(t, expired, "hold_expired"); n != 1 {
+		t.Errorf("hold_expired entries = %d, want 1", n)
+	}
+	if _, err := svc.GetHold(ctx, expired, 1); !errors.Is(err, ErrHoldNotFound) {
+		t.Errorf("expired hold: err = %v, want %v", err, ErrHoldNotFound)
+	}
+}
+
+func TestConfirmDetachedAndRepeated(t *testing.T) {
+	svc, pool := newTestService(t, Config{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+
+	holdID, _, _, err := svc.CreateHold(context.Background(), 1, eventID, seatIDs, nil, time.Minute, "", false, false)
+	if err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+
+	// The shared confirm outlives the caller that started it.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	orderID, _, err := svc.Confirm(ctx, holdID, 1)
+	if err != nil {
+		t.Fatalf("confirm with cancelled caller: %v", err)
+	}
+
+	var stored uuid.UUID
+	if err := pool.QueryRow(context.Background(),
+		`SELECT hold_id FROM orders WHERE id = $1`, orderID,
+	).Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored != holdID {
+		t.Errorf("orders.hold_id = %s, want %s", stored, holdID)
+	}
+
+	if _, _, err := svc.Confirm(context.Background(), holdID, 1); !errors.Is(err, ErrAlreadyConfirmed) {
+		t.Errorf("second confirm: err = %v, want %v", err, ErrAlreadyConfirmed)
+	}
+}
+
+func TestConfirmEmptyHold(t *testing.T) {
+	svc, pool := newTestService(t, Config{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	ctx := context.Background()
+
+	holdID, _, _, err := svc.CreateHold(ctx, 1, eventID, seatIDs, nil, time.Minute, "", false, false)
+	if err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	// The hold stays active but loses every seat it held.
+	if _, err := pool.Exec(ctx,
+		`UPDATE event_seats
+		 SET status = 'available', hold_id = NULL, hold_expires_at = NULL
+		 WHERE hold_id = $1`,
+		holdID,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := svc.Confirm(ctx, holdID, 1); !errors.Is(err, ErrNothingToConfirm) {
+		t.Fatalf("confirm: err = %v, want %v", err, ErrNothingToConfirm)
+	}
+
+	var orders int
+	if err := pool.QueryRow(ctx, `SELECT count(*) FROM orders`).Scan(&orders); err != nil {
+		t.Fatal(err)
+	}
+	if orders != 0 {
+		t.Errorf("orders = %d, want 0", orders)
+	}
+}
+
+// fakeNotifier records the users it notifies and fails with err when set.
+type fakeNotifier struct {
+	mu    sync.Mutex
+	users []int64
+	err   error
+}
+
+func (n *fakeNotifier) NotifyWaitlisted(_ context.Context, e domain.WaitlistEntry) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.users = append(n.users, e.UserID)
+
+	return n.err
+}
+
+func TestWaitlist(t *testing.T) {
+	// Waitlist notification runs at the configured isolation level like
+	// every other seat-state transaction.
+	for _, iso := range []pgx.TxIsoLevel{pgx.Serializable, pgx.RepeatableRead} {
+		t.Run(string(iso), func(t *testing.T) {
+			notifier := &fakeNotifier{}
+			svc, pool := newTestService(t, Config{Notifier: notifier, IsolationLevel: iso})
+			eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+			ctx := context.Background()
+
+			holdID, _, _, err := svc.CreateHold(ctx, 9, eventID, seatIDs, nil, time.Minute, "", false, false)
+			if err != nil {
+				t.Fatalf("hold: %v", err)
+			}
+
+			first, err := svc.JoinWaitlist(ctx, eventID, 1, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := svc.JoinWaitlist(ctx, eventID, 2, 1); err != nil {
+				t.Fatal(err)
+			}
+			again, err := svc.JoinWaitlist(ctx, eventID, 1, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again.ID != first.ID || again.SeatCount != 1 {
+				t.Fatalf("pending rejoin changed the entry: %+v, want %+v", again, first)
+			}
+
+			if _, err := svc.Cancel(ctx, holdID, 9); err != nil {
+				t.Fatalf("cancel: %v", err)
+			}
+
+			notify := func(t *testing.T, wantUser int64) {
+				t.Helper()
+				e, err := svc.NotifyWaitlist(ctx, eventID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if e == nil || e.UserID != wantUser {
+					t.Fatalf("notified %+v, want user %d", e, wantUser)
+				}
+			}
+
+			notify(t, 1)
+
+			// Once notified, joining again queues the user at the back.
+			requeued, err := svc.JoinWaitlist(ctx, eventID, 1, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if requeued.NotifiedAt != nil {
+				t.Fatalf("requeued entry still notified at %v", requeued.NotifiedAt)
+			}
+			notify(t, 2)
+
+			// A failed delivery leaves the entry queued for the next attempt.
+			notifier.err = errors.New("boom")
+			notify(t, 1)
+			notifier.err = nil
+			notify(t, 1)
+
+			if want := []int64{1, 2, 1, 1}; !slices.Equal(notifier.users, want) {
+				t.Errorf("notified users = %v, want %v", notifier.users, want)
+			}
+		})
+	}
+}
+
+func TestSeatLimitPerUser(t *testing.T) {
+	svc, pool := newTestService(t, Config{MaxSeatsPerUser: 3})
+	ctx := context.Background()
+
+	const userID = 1
+
+	tests := []struct {
+		name string
+		// prepare runs against a fresh event before the checked hold.
+		prepare func(t *testing.T, eventID int64, seatIDs []int64)
+		// seats indexes seatIDs for the checked hold.
+		seats   []int
+		wantErr error
+	}{
+		{
+			name:  "at limit",
+			seats: []int{0, 1, 2},
+		},
+		{
+			name:    "over limit",
+			seats:   []int{0, 1, 2, 3},
+			wantErr: ErrSeatLimitExceeded,
+		},
+		{
+			name: "held seats count",
+			prepare: func(t *testing.T, eventID int64, seatIDs []int64) {
+				if _, _, _, err := svc.CreateHold(ctx, userID, eventID, seatIDs[:2], nil, time.Minute, "", false, false); err != nil {
+					t.Fatalf("first hold: %v", err)
+				}
+			},
+			seats:   []int{2, 3},
+			wantErr: ErrSeatLimitExceeded,
+		},
+		{
+			name: "sold seats count",
+			prepare: func(t *testing.T, eventID int64, seatIDs []int64) {
+				holdID, _, _, err := svc.CreateHold(ctx, userID, eventID, seatIDs[:2], nil, time.Minute, "", false, false)
+				if err != nil {
+					t.Fatalf("first hold: %v", err)
+				}
+				if _, _, err := svc.Confirm(ctx, holdID, userID); err != nil {
+					t.Fatalf("confirm: %v", err)
+				}
+			},
+			seats:   []int{2, 3},
+			wantErr: ErrSeatLimitExceeded,
+		},
+		{
+			name: "released then retry",
+			prepare: func(t *testing.T, eventID int64, seatIDs []int64) {
+				holdID, _, _, err := svc.CreateHold(ctx, userID, eventID, seatIDs[:3], nil, time.Minute, "", false, false)
+				if err != nil {
+					t.Fatalf("first hold: %v", err)
+				}
+				if _, _, _, err := svc.CreateHold(ctx, userID, eventID, seatIDs[3:4], nil, time.Minute, "", false, false); !errors.Is(err, ErrSeatLimitExceeded) {
+					t.Fatalf("before release: err = %v, want %v", err, ErrSeatLimitExceeded)
+				}
+				if _, err := svc.Cancel(ctx, holdID, userID); err != nil {
+					t.Fatalf("cancel: %v", err)
+				}
+			},
+			seats: []int{3, 4, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 6, 0)
+			if tt.prepare != nil {
+				tt.prepare(t, eventID, seatIDs)
+			}
+
+			var ids []int64
+			for _, i := range tt.seats {
+				ids = append(ids, seatIDs[i])
+			}
+			_, held, _, err := svc.CreateHold(ctx, userID, eventID, ids, nil, time.Minute, "", false, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && len(held) != len(ids) {
+				t.Errorf("held %d seats, want %d", len(held), len(ids))
+			}
+		})
+	}
+}
+
+func TestCreateHoldCutoff(t *testing.T) {
+	now := time.Now()
+	schedules := []struct {
+		name         string
+		starts, ends time.Time
+		// open reports whether holds are accepted under each cutoff.
+		open map[HoldCutoff]bool
+	}{
+		{
+			name:   "past",
+			starts: now.Add(-3 * time.Hour),
+			ends:   now.Add(-time.Hour),
+			open:   map[HoldCutoff]bool{HoldCutoffEventEnd: false, HoldCutoffEventStart: false},
+		},
+		{
+			name:   "ongoing",
+			starts: now.Add(-time.Hour),
+			ends:   now.Add(time.Hour),
+			open:   map[HoldCutoff]bool{HoldCutoffEventEnd: true, HoldCutoffEventStart: false},
+		},
+		{
+			name:   "future",
+			starts: now.Add(time.Hour),
+			ends:   now.Add(3 * time.Hour),
+			open:   map[HoldCutoff]bool{HoldCutoffEventEnd: true, HoldCutoffEventStart: true},
+		},
+	}
+	cutoffs := []struct {
+		name   string
+		cutoff HoldCutoff
+	}{
+		{name: "until end", cutoff: HoldCutoffEventEnd},
+		{name: "until start", cutoff: HoldCutoffEventStart},
+	}
+	for _, c := range cutoffs {
+		t.Run(c.name, func(t *testing.T) {
+			svc, pool := newTestService(t, Config{HoldCutoff: c.cutoff})
+			ctx := context.Background()
+
+			for _, sc := range schedules {
+				t.Run(sc.name, func(t *testing.T) {
+					eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 1, 0)
+					if _, err := pool.Exec(ctx,
+						`UPDATE events SET starts_at = $2, ends_at = $3 WHERE id = $1`,
+						eventID, sc.starts, sc.ends,
+					); err != nil {
+						t.Fatal(err)
+					}
+
+					_, _, _, err := svc.CreateHold(ctx, 1, eventID, seatIDs, nil, time.Minute, "", false, false)
+					if sc.open[c.cutoff] {
+						if err != nil {
+							t.Errorf("err = %v, want the hold accepted", err)
+						}
+					} else if !errors.Is(err, ErrEventEnded) {
+						t.Errorf("err = %v, want %v", err, ErrEventEnded)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestIsContiguous(t *testing.T) {
+	seat := func(section, row string, number int) domain.Seat {
+		return domain.Seat{Section: section, Row: row, Number: number}
+	}
+
+	tests := []struct {
+		name  string
+		seats []domain.Seat
+		want  bool
+	}{
+		{name: "single", seats: []domain.Seat{seat("A", "1", 5)}, want: true},
+		{name: "run", seats: []domain.Seat{seat("A", "1", 5), seat("A", "1", 6), seat("A", "1", 7)}, want: true},
+		{name: "gap", seats: []domain.Seat{seat("A", "1", 5), seat("A", "1", 7)}},
+		{name: "same number", seats: []domain.Seat{seat("A", "1", 5), seat("A", "1", 5)}},
+		{name: "other row", seats: []domain.Seat{seat("A", "1", 5), seat("A", "2", 6)}},
+		{name: "other section", seats: []domain.Seat{seat("A", "1", 5), seat("B", "1", 6)}},
+	}
+	for _, tt := range tests {
+		if got := isContiguous(tt.seats); got != tt.want {
+			t.Errorf("%s: isContiguous = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCreateHoldContiguous(t *testing.T) {
+	svc, pool := newTestService(t, Config{})
+	ctx := context.Background()
+
+	// Seats are indexed row by row: 0-3 are row 1, 4-7 are row 2.
+	tests := []struct {
+		name    string
+		pick    []int
+		wantErr error
+	}{
+		{name: "run", pick: []int{0, 1, 2}},
+		{name: "run out of order", pick: []int{2, 0, 1}},
+		{name: "gap", pick: []int{0, 2}, wantErr: ErrSeatsNotContiguous},
+		{name: "across rows", pick: []int{3, 4}, wantErr: ErrSeatsNotContiguous},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventID, seatIDs := pgtest.SeedEvent(t, pool, 2, 4, 0)
+			picked := make([]int64, len(tt.pick))
+			for i, p := range tt.pick {
+				picked[i] = seatIDs[p]
+			}
+
+			_, held, _, err := svc.CreateHold(ctx, 1, eventID, picked, nil, time.Minute, "", true, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			slices.Sort(picked)
+			slices.Sort(held)
+			if !slices.Equal(held, picked) {
+				t.Errorf("held %v, want %v", held, picked)
+			}
+		})
+	}
+
+	// Without the flag a gapped selection is held.
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 4, 0)
+	if _, _, _, err := svc.CreateHold(ctx, 1, eventID, []int64{seatIDs[0], seatIDs[2]}, nil, time.Minute, "", false, false); err != nil {
+		t.Errorf("gap without contiguous: %v", err)
+	}
+}
+
+func TestSuggestAndHold(t *testing.T) {
+	svc, pool := newTestService(t, Config{})
+	ctx := context.Background()
+
+	// Two rows of three seats: 0-2 are row 1, 3-5 are row 2.
+	tests := []struct {
+		name    string
+		taken   []int
+		count   int
+		want    []int
+		wantErr error
+	}{
+		{name: "front row", count: 3, want: []int{0, 1, 2}},
+		{name: "centre of next row", count: 4, want: []int{0, 1, 2, 4}},
+		{name: "exactly what is left", taken: []int{0, 4}, count: 4, want: []int{1, 2, 3, 5}},
+		{name: "every seat", count: 6, want: []int{0, 1, 2, 3, 4, 5}},
+		{name: "one short", taken: []int{1}, count: 6, wantErr: ErrSeatsUnavailable},
+		{name: "sold out", taken: []int{0, 1, 2, 3, 4, 5}, count: 1, wantErr: ErrSeatsUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventID, seatIDs := pgtest.SeedEvent(t, pool, 2, 3, 0)
+			pick := func(idx []int) []int64 {
+				out := make([]int64, len(idx))
+				for i, p := range idx {
+					out[i] = seatIDs[p]
+				}
+				return out
+			}
+			if len(tt.taken) > 0 {
+				if _, _, _, err := svc.CreateHold(ctx, 2, eventID, pick(tt.taken), nil, time.Minute, "", false, false); err != nil {
+					t.Fatalf("take seats: %v", err)
+				}
+			}
+
+			_, held, err := svc.SuggestAndHold(ctx, 1, eventID, tt.count, time.Minute, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				// Nothing was held on the user's behalf.
+				var n int
+				if err := pool.QueryRow(ctx,
+					`SELECT count(*) FROM holds WHERE event_id = $1 AND user_id = 1`, eventID,
+				).Scan(&n); err != nil {
+					t.Fatal(err)
+				}
+				if n != 0 {
+					t.Errorf("%d holds created, want none", n)
+				}
+				return
+			}
+
+			want := pick(tt.want)
+			slices.Sort(want)
+			slices.Sort(held)
+			if !slices.Equal(held, want) {
+				t.Errorf("held %v, want %v", held, want)
+			}
+		})
+	}
+}
+
+func TestExpireInvalidatesEvents(t *testing.T) {
+	svc, pool, mr := newTestServiceRedis(t, Config{})
+	ctx := context.Background()
+	holds := postgresrepo.NewStore(pool).Reservations()
+
+	eventA, seatsA := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	eventB, seatsB := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	eventC, seatsC := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	for _, h := range []struct {
+		eventID int64
+		seatIDs []int64
+		lapsed  bool
+	}{
+		{eventID: eventA, seatIDs: seatsA, lapsed: true},
+		{eventID: eventB, seatIDs: seatsB[:1], lapsed: true},
+		{eventID: eventC, seatIDs: seatsC},
+	} {
+		holdID, _, err := holds.HoldSeats(ctx, h.eventID, 1, h.seatIDs, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !h.lapsed {
+			continue
+		}
+		if _, err := pool.Exec(ctx,
+			`UPDATE holds SET expires_at = now() - interval '1 second' WHERE id = $1`, holdID,
+		); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := pool.Exec(ctx,
+			`UPDATE event_seats SET hold_expires_at = now() - interval '1 second' WHERE hold_id = $1`, holdID,
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, eventID := range []int64{eventA, eventB, eventC} {
+		if err := mr.Set(redisrepo.KeyEventAvailability(eventID), "{}"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	sub := rdb.Subscribe(ctx, redisrepo.ChannelEventsChanged())
+	t.Cleanup(func() { _ = sub.Close() })
+	if _, err := sub.Receive(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	released, eventIDs, err := svc.Expire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if released != 3 {
+		t.Errorf("released = %d, want 3", released)
+	}
+	slices.Sort(eventIDs)
+	if want := []int64{eventA, eventB}; !slices.Equal(eventIDs, want) {
+		t.Errorf("event IDs = %v, want %v", eventIDs, want)
+	}
+
+	for _, eventID := range []int64{eventA, eventB} {
+		if mr.Exists(redisrepo.KeyEventAvailability(eventID)) {
+			t.Errorf("event %d: availability still cached", eventID)
+		}
+	}
+	if !mr.Exists(redisrepo.KeyEventAvailability(eventC)) {
+		t.Errorf("event %d: untouched availability was invalidated", eventC)
+	}
+
+	var published []int64
+	for range 2 {
+		recvCtx, cancel := context.WithTimeout(ctx, time.Second)
+		msg, err := sub.ReceiveMessage(recvCtx)
+		cancel()
+		if err != nil {
+			t.Fatalf("after %v: %v", published, err)
+		}
+		var ev struct {
+			EventID int64 `json:"event_id"`
+		}
+		if err := json.Unmarshal([]byte(msg.Payload), &ev); err != nil {
+			t.Fatal(err)
+		}
+		published = append(published, ev.EventID)
+	}
+	slices.Sort(published)
+	if want := []int64{eventA, eventB}; !slices.Equal(published, want) {
+		t.Errorf("published = %v, want %v", published, want)
+	}
+}
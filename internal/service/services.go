@@ -1,12 +1,16 @@
 package service
 
 import (
+	"log/slog"
+
+	"github.com/kirinyoku/tix-go/internal/metrics"
 	postgres "github.com/kirinyoku/tix-go/internal/repository/postgres"
 	redis "github.com/kirinyoku/tix-go/internal/repository/redis"
 	"github.com/kirinyoku/tix-go/internal/service/admin"
 	"github.com/kirinyoku/tix-go/internal/service/orders"
 	"github.com/kirinyoku/tix-go/internal/service/query"
 	"github.com/kirinyoku/tix-go/internal/service/reservation"
+	"github.com/kirinyoku/tix-go/internal/uow"
 )
 
 type Services struct {
@@ -19,19 +23,26 @@ type Services struct {
 type Config struct {
 	Reservation reservation.Config
 	Query       query.Config
+	Orders      orders.Config
+	// Logger records failures of after-commit hooks. Defaults to slog.Default.
+	Logger *slog.Logger
 }
 
 func NewServices(
 	store *postgres.Store,
 	cache *redis.Cache,
 	pubsub *redis.EventsPubSub,
-	limiter *redis.SlidingWindowLimiter,
+	ipLimiter reservation.Limiter,
+	userLimiter reservation.Limiter,
+	m *metrics.Metrics,
 	cfg Config,
 ) *Services {
+	uowOpts := []uow.Option{uow.WithLogger(cfg.Logger)}
+
 	return &Services{
-		Reservation: reservation.New(store, cache, pubsub, limiter, cfg.Reservation),
+		Reservation: reservation.New(store, cache, pubsub, ipLimiter, userLimiter, m, cfg.Reservation, uowOpts...),
 		Query:       query.New(store, cache, cfg.Query),
-		Admin:       admin.New(store, cache, pubsub),
-		Orders:      orders.New(store),
+		Admin:       admin.New(store, cache, pubsub, uowOpts...),
+		Orders:      orders.New(store, cache, pubsub, cfg.Orders, uowOpts...),
 	}
 }
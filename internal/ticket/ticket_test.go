@@ -0,0 +1,107 @@
+package ticket
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+)
+
+func TestDecodeTicketToken(t *testing.T) {
+	secret := []byte("ticket-secret")
+	tk := domain.Ticket{
+		ID:      uuid.New(),
+		OrderID: uuid.New(),
+		EventID: 42,
+		SeatID:  7,
+		Created: time.Date(2026, 10, 16, 12, 0, 0, 123456789, time.UTC),
+	}
+	token := EncodeTicketToken(tk, secret)
+	payload, mac, _ := strings.Cut(token, ".")
+	other := EncodeTicketToken(domain.Ticket{ID: uuid.New(), OrderID: tk.OrderID, EventID: 42, SeatID: 8}, secret)
+	otherPayload, _, _ := strings.Cut(other, ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		secret  []byte
+		wantErr error
+	}{
+		{name: "valid", token: token, secret: secret},
+		{name: "wrong secret", token: token, secret: []byte("other"), wantErr: ErrInvalidToken},
+		{name: "swapped payload", token: otherPayload + "." + mac, secret: secret, wantErr: ErrInvalidToken},
+		{name: "no mac", token: payload, secret: secret, wantErr: ErrInvalidToken},
+		{name: "bad base64", token: "!!." + mac, secret: secret, wantErr: ErrInvalidToken},
+		{name: "empty", token: "", secret: secret, wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeTicketToken(tt.token, tt.secret)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got != tk {
+				t.Errorf("ticket = %+v, want %+v", got, tk)
+			}
+		})
+	}
+}
+
+func TestDecodeTicketTokenSignedGarbage(t *testing.T) {
+	secret := []byte("ticket-secret")
+	for _, p := range []string{"a|b", "x|y|1|2|3", strings.Repeat("|", 4)} {
+		token := base64.RawURLEncoding.EncodeToString([]byte(p)) + "." +
+			base64.RawURLEncoding.EncodeToString(sum([]byte(p), secret))
+		if _, err := DecodeTicketToken(token, secret); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("payload %q: err = %v, want ErrInvalidToken", p, err)
+		}
+	}
+}
+
+func TestRenderQRRoundTrip(t *testing.T) {
+	secret := []byte("ticket-secret")
+	tk := domain.Ticket{
+		ID:      uuid.New(),
+		OrderID: uuid.New(),
+		EventID: 42,
+		SeatID:  7,
+		Created: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+	}
+	token := EncodeTicketToken(tk, secret)
+
+	b, err := RenderQR(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("not a PNG: %v", err)
+	}
+	bmp, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := qrcode.NewQRCodeReader().Decode(bmp, nil)
+	if err != nil {
+		t.Fatalf("decode QR: %v", err)
+	}
+
+	if got := res.GetText(); got != token {
+		t.Fatalf("QR text = %q, want %q", got, token)
+	}
+	got, err := DecodeTicketToken(res.GetText(), secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != tk {
+		t.Errorf("ticket = %+v, want %+v", got, tk)
+	}
+}
@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const serviceName = "tix-go"
+
+// Setup installs a global tracer provider exporting spans over OTLP/HTTP.
+// The exporter is configured with the standard OTEL_EXPORTER_OTLP_* env vars.
+// When neither OTEL_EXPORTER_OTLP_ENDPOINT nor
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is set, tracing stays a no-op.
+//
+// Returns:
+//   - func(context.Context) error: flushes and stops the provider; always non-nil.
+//   - error: if the exporter could not be created.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	const op = "tracing.Setup"
+
+	noop := func(context.Context) error { return nil }
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" &&
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return noop, nil
+	}
+
+	exp, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, fmt.Errorf("%s:%w", op, err)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return noop, fmt.Errorf("%s:%w", op, err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return tp.Shutdown, nil
+}
+
+// End records err on span, if any, and ends it. It is meant to be deferred
+// with a pointer to a named error result:
+//
+//	ctx, span := tracer.Start(ctx, op)
+//	defer tracing.End(span, &err)
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,153 @@
+package httpgin
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// redactedHeaders are replaced by a placeholder in body logs.
+var redactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	AdminTokenHeader,
+	APIKeyHeader,
+}
+
+// redactedBodyPaths are path prefixes whose request and response bodies are
+// never logged: the API-key endpoints return the one-time key in the body.
+var redactedBodyPaths = []string{
+	"/admin/api-keys",
+}
+
+// BodyLogMiddleware logs request and response bodies at debug level, each
+// cut to maxBytes, together with the request headers with credentials
+// redacted. Bodies under redactedBodyPaths are not captured. It is meant for
+// debugging client integrations and does nothing unless logger has debug
+// level enabled.
+func BodyLogMiddleware(logger *slog.Logger, maxBytes int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if maxBytes <= 0 || !logger.Enabled(ctx, slog.LevelDebug) {
+			c.Next()
+			return
+		}
+
+		redacted := hasBodyRedacted(c.Request.URL.Path)
+
+		reqBody := &capBuffer{max: maxBytes}
+		if c.Request.Body != nil && !redacted {
+			c.Request.Body = &teeBody{ReadCloser: c.Request.Body, buf: reqBody}
+		}
+
+		w := &bodyLogWriter{ResponseWriter: c.Writer, buf: &capBuffer{max: maxBytes}, skip: redacted}
+		c.Writer = w
+		defer func() {
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+
+		if redacted {
+			reqBody.WriteString("[REDACTED]")
+			w.buf.WriteString("[REDACTED]")
+		}
+
+		reqID, _ := c.Get("request_id")
+		logger.DebugContext(ctx, "http body",
+			slog.Group("http",
+				slog.String("method", c.Request.Method),
+				slog.String("path", c.Request.URL.Path),
+				slog.Any("request_id", reqID),
+				slog.Any("headers", redactHeaders(c.Request.Header)),
+				slog.String("request_body", reqBody.String()),
+				slog.Bool("request_truncated", reqBody.truncated),
+				slog.Int("status", w.Status()),
+				slog.String("response_encoding", w.Header().Get("Content-Encoding")),
+				slog.String("response_body", w.buf.String()),
+				slog.Bool("response_truncated", w.buf.truncated),
+			),
+		)
+	}
+}
+
+// redactHeaders returns a copy of h with the values of redactedHeaders
+// replaced.
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range redactedHeaders {
+		if _, ok := out[http.CanonicalHeaderKey(name)]; ok {
+			out.Set(name, "[REDACTED]")
+		}
+	}
+
+	return out
+}
+
+// hasBodyRedacted reports whether the bodies of requests to path must not be
+// logged.
+func hasBodyRedacted(path string) bool {
+	for _, prefix := range redactedBodyPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// capBuffer keeps the first max bytes written to it and records whether
+// anything was dropped.
+type capBuffer struct {
+	bytes.Buffer
+
+	max       int
+	truncated bool
+}
+
+func (b *capBuffer) capture(p []byte) {
+	if room := b.max - b.Len(); room < len(p) {
+		b.truncated = true
+		p = p[:max(room, 0)]
+	}
+	b.Write(p)
+}
+
+// teeBody copies what the handler reads from the request body into buf.
+type teeBody struct {
+	io.ReadCloser
+
+	buf *capBuffer
+}
+
+func (t *teeBody) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	t.buf.capture(p[:n])
+
+	return n, err
+}
+
+// bodyLogWriter copies the response body into buf while passing it through,
+// unless skip is set. Bodies compressed further down the chain, e.g. by
+// GzipMiddleware, are not copied.
+type bodyLogWriter struct {
+	gin.ResponseWriter
+
+	buf  *capBuffer
+	skip bool
+}
+
+func (w *bodyLogWriter) Write(p []byte) (int, error) {
+	if !w.skip && w.Header().Get("Content-Encoding") == "" {
+		w.buf.capture(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *bodyLogWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
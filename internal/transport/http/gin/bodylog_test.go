@@ -0,0 +1,176 @@
+package httpgin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kirinyoku/tix-go/internal/auth"
+	"github.com/kirinyoku/tix-go/internal/service"
+)
+
+func TestBodyLogMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		body          string
+		header        string
+		wantReqBody   string
+		wantRespBody  string
+		wantTruncated bool
+	}{
+		{
+			name:         "logged",
+			path:         "/orders/confirm",
+			body:         `{"hold_id":"x"}`,
+			header:       "Authorization",
+			wantReqBody:  `{"hold_id":"x"}`,
+			wantRespBody: `{"hold_id":"x"}`,
+		},
+		{
+			name:          "truncated",
+			path:          "/orders/confirm",
+			body:          strings.Repeat("a", 40),
+			header:        APIKeyHeader,
+			wantReqBody:   strings.Repeat("a", 16),
+			wantRespBody:  strings.Repeat("a", 16),
+			wantTruncated: true,
+		},
+		{
+			name:         "api keys",
+			path:         "/admin/api-keys",
+			body:         `{"client_name":"box office"}`,
+			header:       AdminTokenHeader,
+			wantReqBody:  "[REDACTED]",
+			wantRespBody: "[REDACTED]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			r := gin.New()
+			r.Use(BodyLogMiddleware(logger, 16))
+			r.POST(tt.path, func(c *gin.Context) {
+				b, _ := io.ReadAll(c.Request.Body)
+				c.Data(http.StatusOK, "application/json", b)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(tt.header, "secret")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Body.String() != tt.body {
+				t.Fatalf("response body = %q, want it passed through", w.Body.String())
+			}
+
+			var entry struct {
+				HTTP struct {
+					Headers           map[string][]string `json:"headers"`
+					RequestBody       string              `json:"request_body"`
+					RequestTruncated  bool                `json:"request_truncated"`
+					ResponseBody      string              `json:"response_body"`
+					ResponseTruncated bool                `json:"response_truncated"`
+				} `json:"http"`
+			}
+			if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+				t.Fatalf("decode log: %v\n%s", err, out.String())
+			}
+
+			if strings.Contains(out.String(), "secret") {
+				t.Errorf("log leaks %s: %s", tt.header, out.String())
+			}
+			if got := entry.HTTP.Headers[http.CanonicalHeaderKey(tt.header)]; len(got) != 1 || got[0] != "[REDACTED]" {
+				t.Errorf("%s = %v, want redacted", tt.header, got)
+			}
+			if entry.HTTP.RequestBody != tt.wantReqBody {
+				t.Errorf("request_body = %q, want %q", entry.HTTP.RequestBody, tt.wantReqBody)
+			}
+			if entry.HTTP.ResponseBody != tt.wantRespBody {
+				t.Errorf("response_body = %q, want %q", entry.HTTP.ResponseBody, tt.wantRespBody)
+			}
+			if entry.HTTP.RequestTruncated != tt.wantTruncated || entry.HTTP.ResponseTruncated != tt.wantTruncated {
+				t.Errorf("truncated = %v/%v, want %v",
+					entry.HTTP.RequestTruncated, entry.HTTP.ResponseTruncated, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestBodyLogOptIn(t *testing.T) {
+	jwt, err := auth.IssueToken(1, auth.RoleAdmin, time.Hour, []byte(testJWTSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		maxBytes  int
+		level     slog.Level
+		wantLines int
+	}{
+		{name: "disabled by default", level: slog.LevelDebug},
+		{name: "enabled", maxBytes: 64, level: slog.LevelDebug, wantLines: 1},
+		{name: "enabled without debug level", maxBytes: 64, level: slog.LevelInfo},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: tt.level}))
+			r := NewRouter(&service.Services{}, nil, logger, RouterConfig{
+				JWTSecret:       testJWTSecret,
+				AdminToken:      "admin",
+				LogBodyMaxBytes: tt.maxBytes,
+			})
+
+			// The body lacks the venue name, so the handler reads it and
+			// answers 400 without reaching a service.
+			req := httptest.NewRequest(http.MethodPost, "/admin/venues", strings.NewReader(`{"seating_scheme":{}}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+jwt)
+			req.Header.Set(AdminTokenHeader, "admin")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			var lines int
+			for line := range strings.Lines(out.String()) {
+				if !strings.Contains(line, `"msg":"http body"`) {
+					continue
+				}
+				lines++
+				for _, leak := range []string{jwt, `"admin"`} {
+					if strings.Contains(line, leak) {
+						t.Errorf("log leaks %s: %s", leak, line)
+					}
+				}
+				if !strings.Contains(line, `"request_body":"{\"seating_scheme\":{}}"`) {
+					t.Errorf("log misses the request body: %s", line)
+				}
+			}
+			if lines != tt.wantLines {
+				t.Errorf("body log lines = %d, want %d:\n%s", lines, tt.wantLines, out.String())
+			}
+		})
+	}
+}
+
+func TestCapBufferAtLimit(t *testing.T) {
+	b := &capBuffer{max: 4}
+	b.capture([]byte("ab"))
+	b.capture([]byte("cd"))
+	if b.String() != "abcd" || b.truncated {
+		t.Errorf("at limit: got %q, truncated %v; want %q, false", b.String(), b.truncated, "abcd")
+	}
+	b.capture([]byte("e"))
+	if b.String() != "abcd" || !b.truncated {
+		t.Errorf("over limit: got %q, truncated %v; want %q, true", b.String(), b.truncated, "abcd")
+	}
+}
@@ -1,19 +1,76 @@
 package httpgin
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/kirinyoku/tix-go/internal/domain"
 )
 
 type CreateHoldRequest struct {
-	UserID  int64   `json:"user_id" binding:"required"`
+	// UserID is optional; when set it must match the authenticated user.
+	UserID  int64   `json:"user_id,omitempty"`
 	SeatIDs []int64 `json:"seat_ids" binding:"required,min=1,dive,required"`
-	TTLSec  int     `json:"ttl_sec"`
+	// SeatVersions optionally pins each seat to the version last read from a
+	// seat listing, aligned with SeatIDs. A seat that changed since then is
+	// treated as taken.
+	SeatVersions []int64 `json:"seat_versions,omitempty" binding:"omitempty,dive,min=0"`
+	TTLSec       int     `json:"ttl_sec"`
+	// Contiguous requires the seats to be side by side in one section and row.
+	Contiguous bool `json:"contiguous"`
+	// AllowPartial holds the available seats instead of failing when some
+	// are taken. Ignored when Contiguous is set.
+	AllowPartial bool `json:"allow_partial"`
+}
+
+type AutoHoldRequest struct {
+	// UserID is optional; when set it must match the authenticated user.
+	UserID int64 `json:"user_id,omitempty"`
+	Count  int   `json:"count" binding:"required,min=1"`
+	TTLSec int   `json:"ttl_sec"`
+}
+
+type GAHoldRequest struct {
+	// UserID is optional; when set it must match the authenticated user.
+	UserID int64 `json:"user_id,omitempty"`
+	Qty    int   `json:"qty" binding:"required,min=1"`
+	TTLSec int   `json:"ttl_sec"`
+}
+
+type SetGACapacityRequest struct {
+	Capacity   int `json:"capacity" binding:"gte=0"`
+	PriceCents int `json:"price_cents" binding:"gte=0"`
+}
+
+type SeatStatusesRequest struct {
+	SeatIDs []int64 `json:"seat_ids" binding:"required,min=1,max=1000,dive,required"`
+}
+
+type AvailabilityBatchRequest struct {
+	EventIDs []int64 `json:"event_ids" binding:"required,min=1,dive,gt=0"`
+}
+
+type OrderTicketsRequest struct {
+	OrderIDs []string `json:"order_ids" binding:"required,min=1,dive,uuid"`
 }
 
 type ConfirmOrderRequest struct {
-	HoldID     string `json:"hold_id" binding:"required,uuid"`
-	TotalCents int    `json:"total_cents" binding:"required,gt=0"`
+	HoldID string `json:"hold_id" binding:"required,uuid"`
+	// UserID is optional; when set it must match the authenticated user.
+	UserID int64 `json:"user_id,omitempty"`
+}
+
+type ExtendHoldRequest struct {
+	ExtraSec int `json:"extra_sec" binding:"required,gt=0"`
+}
+
+type JoinWaitlistRequest struct {
+	// UserID is optional; when set it must match the authenticated user.
+	UserID    int64 `json:"user_id,omitempty"`
+	SeatCount int   `json:"seat_count" binding:"required,gt=0"`
 }
 
 type CreateVenueRequest struct {
@@ -21,29 +78,129 @@ type CreateVenueRequest struct {
 	SeatingScheme json.RawMessage `json:"seating_scheme"`
 }
 
+type UpdateVenueRequest struct {
+	Name          string          `json:"name" binding:"required"`
+	SeatingScheme json.RawMessage `json:"seating_scheme"`
+}
+
 type BatchCreateSeatsRequest struct {
 	Seats []SeatInput `json:"seats" binding:"required,min=1,dive"`
 }
 
+type BatchCreateSeatsResponse struct {
+	Created int64 `json:"created"`
+}
+
 type SeatInput struct {
 	Section string `json:"section" binding:"required"`
-	Row     string `json:"row" binding:"required"`
-	Number  int    `json:"number" binding:"required,gt=0"`
+	// Row is a positive integer, e.g. "12".
+	Row    string `json:"row" binding:"required,seat_row"`
+	Number int    `json:"number" binding:"required,gt=0"`
+}
+
+// UpdateSeatRequest moves a seat; omitted fields keep their current value.
+type UpdateSeatRequest struct {
+	Section *string `json:"section" binding:"omitempty,min=1"`
+	Row     *string `json:"row" binding:"omitempty,seat_row"`
+	Number  *int    `json:"number" binding:"omitempty,gt=0"`
 }
 
 type CreateEventRequest struct {
-	VenueID  int64  `json:"venue_id" binding:"required"`
+	VenueID    int64  `json:"venue_id" binding:"required"`
+	Title      string `json:"title" binding:"required"`
+	StartsAt   string `json:"starts_at" binding:"required"`
+	EndsAt     string `json:"ends_at" binding:"required"`
+	PriceCents int    `json:"price_cents" binding:"gte=0"`
+}
+
+type SeatStatusesResponse struct {
+	Statuses map[int64]domain.SeatStatus `json:"statuses"`
+}
+
+type SeatPageResponse struct {
+	Items      []domain.SeatWithStatus `json:"items"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+// SeatListResponse is returned by GET /events/{id}/seats when meta=true.
+type SeatListResponse struct {
+	Items  []domain.SeatWithStatus `json:"items"`
+	Total  int64                   `json:"total"`
+	Limit  int                     `json:"limit"`
+	Offset int                     `json:"offset"`
+}
+
+type UpdateEventRequest struct {
 	Title    string `json:"title" binding:"required"`
 	StartsAt string `json:"starts_at" binding:"required"`
 	EndsAt   string `json:"ends_at" binding:"required"`
 }
 
+type EventRevenueResponse struct {
+	OrdersCount int64 `json:"orders_count"`
+	TicketsSold int64 `json:"tickets_sold"`
+	TotalCents  int64 `json:"total_cents"`
+}
+
+type CreateAPIKeyRequest struct {
+	ClientName string   `json:"client_name" binding:"required"`
+	Scopes     []string `json:"scopes" binding:"required,min=1,dive,oneof=read write"`
+}
+
+type CreateAPIKeyResponse struct {
+	ID         int64                `json:"id"`
+	Key        string               `json:"key"`
+	ClientName string               `json:"client_name"`
+	Scopes     []domain.APIKeyScope `json:"scopes"`
+	CreatedAt  time.Time            `json:"created_at"`
+}
+
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields,omitempty"`
+	// UnavailableSeatIDs lists the requested seats that were taken when a
+	// hold fails with 409.
+	UnavailableSeatIDs []int64 `json:"unavailable_seat_ids,omitempty"`
+}
+
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
 }
 
 type CreateHoldResponse struct {
+	HoldID  string  `json:"hold_id"`
+	SeatIDs []int64 `json:"seat_ids"`
+	// UnavailableSeatIDs lists requested seats left out of a partial hold.
+	UnavailableSeatIDs []int64 `json:"unavailable_seat_ids,omitempty"`
+}
+
+type AutoHoldResponse struct {
+	HoldID  string  `json:"hold_id"`
+	SeatIDs []int64 `json:"seat_ids"`
+}
+
+type GAHoldResponse struct {
 	HoldID string `json:"hold_id"`
+	Qty    int    `json:"qty"`
+}
+
+type HoldStatusResponse struct {
+	HoldID          string    `json:"hold_id"`
+	EventID         int64     `json:"event_id"`
+	UserID          int64     `json:"user_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	TTLRemainingSec int64     `json:"ttl_remaining_sec"`
+	SeatIDs         []int64   `json:"seat_ids"`
+	// GAQty is the number of general-admission tickets held.
+	GAQty int `json:"ga_qty"`
+}
+
+type ExtendHoldResponse struct {
+	HoldID          string    `json:"hold_id"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	TTLRemainingSec int64     `json:"ttl_remaining_sec"`
 }
 
 type ConfirmOrderResponse struct {
@@ -59,6 +216,127 @@ type CreateEventResponse struct {
 	EventID int64 `json:"event_id"`
 }
 
+type CreateRecurringEventsRequest struct {
+	VenueID    int64           `json:"venue_id" binding:"required"`
+	Title      string          `json:"title" binding:"required"`
+	Slots      []TimeSlotInput `json:"slots" binding:"required,min=1,dive"`
+	PriceCents int             `json:"price_cents" binding:"gte=0"`
+}
+
+type TimeSlotInput struct {
+	StartsAt string `json:"starts_at" binding:"required"`
+	EndsAt   string `json:"ends_at" binding:"required"`
+}
+
+type CreateRecurringEventsResponse struct {
+	EventIDs []int64 `json:"event_ids"`
+}
+
+// seatCursor is the decoded form of the opaque cursor used for keyset
+// pagination of event seats.
+type seatCursor struct {
+	Section string `json:"s"`
+	Row     string `json:"r"`
+	Number  int    `json:"n"`
+}
+
+func encodeSeatCursor(seat domain.Seat) string {
+	b, _ := json.Marshal(seatCursor{
+		Section: seat.Section,
+		Row:     seat.Row,
+		Number:  seat.Number,
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeSeatCursor parses a cursor produced by encodeSeatCursor. An empty
+// cursor denotes the first page and yields nil.
+func decodeSeatCursor(s string) (*domain.Seat, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var cur seatCursor
+	if err := json.Unmarshal(b, &cur); err != nil {
+		return nil, err
+	}
+	if cur.Section == "" || cur.Row == "" {
+		return nil, errors.New("incomplete cursor")
+	}
+	return &domain.Seat{
+		Section: cur.Section,
+		Row:     cur.Row,
+		Number:  cur.Number,
+	}, nil
+}
+
+// ttlRemainingSec returns the whole seconds left until expiresAt, rounded up
+// so that a hold with any time left never reports zero. Expired holds report 0.
+func ttlRemainingSec(expiresAt, now time.Time) int64 {
+	d := expiresAt.Sub(now)
+	if d <= 0 {
+		return 0
+	}
+	return int64((d + time.Second - 1) / time.Second)
+}
+
 func parseRFC3339(s string) (time.Time, error) {
 	return time.Parse(time.RFC3339, s)
 }
+
+type AvailabilityBatchResponse struct {
+	Counts map[int64]domain.EventCounts `json:"counts"`
+}
+
+type OrderTicketsResponse struct {
+	Tickets map[uuid.UUID][]domain.Ticket `json:"tickets"`
+}
+
+// ExpireHoldsRequest optionally scopes expiry to a single event. The body may
+// be omitted to expire holds of every event.
+type ExpireHoldsRequest struct {
+	EventID *int64 `json:"event_id" binding:"omitempty,gt=0"`
+}
+
+type ExpireHoldsResponse struct {
+	Released int64 `json:"released"`
+}
+
+type TicketResponse struct {
+	Ticket    domain.Ticket `json:"ticket"`
+	Signature string        `json:"signature"`
+}
+
+// TicketQRResponse is returned by GET /tickets/{id}/qr unless the client
+// asks for image/png.
+type TicketQRResponse struct {
+	Token string `json:"token"`
+	// PNG is the base64-encoded QR code image.
+	PNG string `json:"png"`
+}
+
+type TicketVerifyResponse struct {
+	Valid bool `json:"valid"`
+}
+
+type VerifyTicketTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// VerifyTicketTokenResponse carries the stored ticket when the token is valid.
+type VerifyTicketTokenResponse struct {
+	Valid  bool           `json:"valid"`
+	Ticket *domain.Ticket `json:"ticket,omitempty"`
+}
+
+type RefundOrderResponse struct {
+	ReleasedSeats int64 `json:"released_seats"`
+}
+
+type ReadyResponse struct {
+	Status string            `json:"status"`
+	Failed map[string]string `json:"failed,omitempty"`
+}
@@ -0,0 +1,54 @@
+package httpgin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kirinyoku/tix-go/internal/domain"
+)
+
+func TestTTLRemainingSec(t *testing.T) {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		left time.Duration
+		want int64
+	}{
+		{name: "expired long ago", left: -time.Minute, want: 0},
+		{name: "just expired", left: -time.Nanosecond, want: 0},
+		{name: "expires now", left: 0, want: 0},
+		{name: "nanosecond left", left: time.Nanosecond, want: 1},
+		{name: "just under a second", left: time.Second - time.Nanosecond, want: 1},
+		{name: "exactly a second", left: time.Second, want: 1},
+		{name: "just over a second", left: time.Second + time.Nanosecond, want: 2},
+		{name: "whole minute", left: time.Minute, want: 60},
+	}
+	for _, tt := range tests {
+		if got := ttlRemainingSec(now.Add(tt.left), now); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSeatCursor(t *testing.T) {
+	seat := domain.Seat{Section: "Balcony", Row: "10", Number: 7}
+
+	got, err := decodeSeatCursor(encodeSeatCursor(seat))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != seat {
+		t.Errorf("round trip = %+v, want %+v", *got, seat)
+	}
+
+	if got, err := decodeSeatCursor(""); got != nil || err != nil {
+		t.Errorf("empty cursor = %v, %v; want nil, nil", got, err)
+	}
+
+	for _, bad := range []string{"***", "bm9wZQ", "e30"} {
+		if _, err := decodeSeatCursor(bad); err == nil {
+			t.Errorf("decodeSeatCursor(%q): got no error", bad)
+		}
+	}
+}
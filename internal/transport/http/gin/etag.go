@@ -5,10 +5,53 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+// entityTag encodes v as JSON and derives its ETag from the encoding.
+func entityTag(v any, weak bool) (string, []byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", nil, err
+	}
+	sum := sha256.Sum256(b)
+	tag := `"` + hex.EncodeToString(sum[:]) + `"`
+	if weak {
+		tag = "W/" + tag
+	}
+
+	return tag, b, nil
+}
+
+// gzipETagSuffix marks the ETag of a gzip-compressed representation, so the
+// compressed and plain bodies never share a strong validator.
+const gzipETagSuffix = "-gzip"
+
+// gzipETag returns the ETag of the gzip representation of the entity tagged
+// tag.
+func gzipETag(tag string) string {
+	if !strings.HasSuffix(tag, `"`) || strings.HasSuffix(tag, gzipETagSuffix+`"`) {
+		return tag
+	}
+
+	return strings.TrimSuffix(tag, `"`) + gzipETagSuffix + `"`
+}
+
+// matchETag returns the entry of the comma-separated list header that
+// identifies tag, either as is or as its gzip representation.
+func matchETag(header, tag string) (string, bool) {
+	for part := range strings.SplitSeq(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == tag || part == gzipETag(tag) {
+			return part, true
+		}
+	}
+
+	return "", false
+}
+
 // writeJSONWithCache — writes a JSON response with ETag/Cache-Control.
 // If If-None-Match matches the current ETag — returns 304.
 func writeJSONWithCache(
@@ -18,24 +61,55 @@ func writeJSONWithCache(
 	cacheControl string,
 	weak bool,
 ) {
-	b, err := json.Marshal(v)
+	tag, b, err := entityTag(v, weak)
 	if err != nil {
 		c.Status(http.StatusInternalServerError)
 		return
 	}
-	sum := sha256.Sum256(b)
-	tag := `"` + hex.EncodeToString(sum[:]) + `"`
-	if weak {
-		tag = "W/" + tag
-	}
-	inm := c.GetHeader("If-None-Match")
 	c.Header("ETag", tag)
 	if cacheControl != "" {
 		c.Header("Cache-Control", cacheControl)
 	}
-	if inm == tag {
+	if matched, ok := matchETag(c.GetHeader("If-None-Match"), tag); ok {
+		// Echo the validator the client holds, which may be the gzip one.
+		c.Header("ETag", matched)
 		c.Status(http.StatusNotModified)
 		return
 	}
 	c.Data(status, "application/json; charset=utf-8", b)
 }
+
+// writeJSONWithETag writes v as JSON with its strong ETag, so clients can
+// send it back in If-Match on the next update.
+func writeJSONWithETag(c *gin.Context, status int, v any) {
+	tag, b, err := entityTag(v, false)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	c.Header("ETag", tag)
+	c.Data(status, "application/json; charset=utf-8", b)
+}
+
+// ifMatch turns the If-Match header into a precondition on the current
+// entity, or returns nil when the header is absent. Comparison is strong, so
+// weak ETags never match; "*" matches any existing entity. The ETag of the
+// gzip representation matches the same entity.
+func ifMatch[T any](c *gin.Context) func(current T) bool {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return nil
+	}
+
+	return func(current T) bool {
+		if header == "*" {
+			return true
+		}
+		tag, _, err := entityTag(current, false)
+		if err != nil {
+			return false
+		}
+		_, ok := matchETag(header, tag)
+		return ok
+	}
+}
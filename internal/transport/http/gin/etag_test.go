@@ -0,0 +1,122 @@
+package httpgin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type etagBody struct {
+	Name string `json:"name"`
+}
+
+func newETagRouter(body etagBody) *gin.Engine {
+	r := gin.New()
+	r.Use(GzipMiddleware(16))
+	r.GET("/x", func(c *gin.Context) {
+		writeJSONWithCache(c, http.StatusOK, body, "public, max-age=60", false)
+	})
+
+	return r
+}
+
+func TestWriteJSONWithCacheETag(t *testing.T) {
+	body := etagBody{Name: strings.Repeat("a", 64)}
+	tag, _, err := entityTag(body, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		ifNoneMatch    string
+		wantStatus     int
+		wantETag       string
+		wantEncoding   string
+	}{
+		{name: "plain", wantStatus: http.StatusOK, wantETag: tag},
+		{name: "gzip", acceptEncoding: "gzip", wantStatus: http.StatusOK, wantETag: gzipETag(tag), wantEncoding: "gzip"},
+		{name: "plain revalidated", ifNoneMatch: tag, wantStatus: http.StatusNotModified, wantETag: tag},
+		{name: "gzip revalidated", acceptEncoding: "gzip", ifNoneMatch: gzipETag(tag), wantStatus: http.StatusNotModified, wantETag: gzipETag(tag)},
+		{name: "list", ifNoneMatch: `"other", ` + tag, wantStatus: http.StatusNotModified, wantETag: tag},
+		{name: "stale", ifNoneMatch: `"other"`, wantStatus: http.StatusOK, wantETag: tag},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/x", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			newETagRouter(body).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %s, want %s", got, tt.wantETag)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+		})
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	current := etagBody{Name: "venue"}
+	tag, _, err := entityTag(current, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weak, _, err := entityTag(current, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		header  string
+		wantNil bool
+		want    bool
+	}{
+		{name: "absent", wantNil: true},
+		{name: "exact", header: tag, want: true},
+		{name: "gzip", header: gzipETag(tag), want: true},
+		{name: "star", header: "*", want: true},
+		{name: "list", header: `"other", ` + tag, want: true},
+		{name: "weak", header: weak, want: false},
+		{name: "stale", header: `"other"`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPut, "/x", nil)
+			if tt.header != "" {
+				c.Request.Header.Set("If-Match", tt.header)
+			}
+
+			match := ifMatch[etagBody](c)
+			if tt.wantNil {
+				if match != nil {
+					t.Fatal("expected no precondition")
+				}
+				return
+			}
+			if got := match(current); got != tt.want {
+				t.Errorf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
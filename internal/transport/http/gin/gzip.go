@@ -0,0 +1,160 @@
+package httpgin
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultGzipMinBytes is used when RouterConfig.GzipMinBytes is unset.
+const defaultGzipMinBytes = 1024
+
+// GzipMiddleware compresses responses of at least minBytes for clients that
+// accept gzip; smaller responses are sent as is. A compressed response gets
+// its ETag suffixed with "-gzip", since it is a different representation
+// from the plain body the handler tagged.
+func GzipMiddleware(minBytes int) gin.HandlerFunc {
+	if minBytes <= 0 {
+		minBytes = defaultGzipMinBytes
+	}
+
+	pool := &sync.Pool{
+		New: func() any { return gzip.NewWriter(io.Discard) },
+	}
+
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+			c.Next()
+			return
+		}
+
+		w := &gzipWriter{ResponseWriter: c.Writer, minBytes: minBytes, pool: pool}
+		c.Writer = w
+		defer func() {
+			w.close()
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// gzipWriter buffers the body until it reaches minBytes, then switches to
+// gzip. Responses that end below the threshold are written uncompressed.
+type gzipWriter struct {
+	gin.ResponseWriter
+
+	minBytes int
+	pool     *sync.Pool
+	buf      []byte
+	decided  bool
+	gz       *gzip.Writer
+}
+
+func (w *gzipWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		if w.gz != nil {
+			return w.gz.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.minBytes {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what is buffered, without compression if the threshold has
+// not been reached yet.
+func (w *gzipWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide fixes the encoding of the response and writes the buffered body.
+// Bodies that are already encoded, or whose status forbids a body, are never
+// compressed.
+func (w *gzipWriter) decide(compress bool) error {
+	w.decided = true
+
+	h := w.Header()
+	status := w.Status()
+	if compress && h.Get("Content-Encoding") == "" &&
+		status != http.StatusNoContent && status != http.StatusNotModified {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		if tag := h.Get("ETag"); tag != "" {
+			h.Set("ETag", gzipETag(tag))
+		}
+
+		w.gz = w.pool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.gz != nil {
+		_, err := w.gz.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+
+	return err
+}
+
+// close writes any buffered body and finishes the gzip stream.
+func (w *gzipWriter) close() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+		w.gz.Reset(io.Discard)
+		w.pool.Put(w.gz)
+		w.gz = nil
+	}
+}
+
+// acceptsGzip reports whether the Accept-Encoding header allows gzip, either
+// by name or through "*", with a non-zero quality.
+func acceptsGzip(acceptEncoding string) bool {
+	for part := range strings.SplitSeq(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v <= 0 {
+				continue
+			}
+		}
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package httpgin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+)
+
+const idempotencyHeader = "Idempotency-Key"
+
+// serveIdempotent runs fn at most once per Idempotency-Key.
+//
+// keyFn maps the client-supplied key to a storage key scoped to the
+// resource being mutated. fn returns the response body and true on success,
+// or false after it has already written an error response. Successful
+// responses are stored and replayed verbatim with status on retries; while
+// the first request is still in flight, duplicates get 409 with Retry-After.
+//
+// req is the bound request body; its fingerprint is stored with the key,
+// atomically with the lock, so reusing a key for a different request is
+// rejected with 422 instead of replaying an unrelated result, even while the
+// first request is in flight.
+//
+// Without an Idempotency-Key header (or without a store) fn simply runs.
+func serveIdempotent(
+	c *gin.Context,
+	idem *redisrepo.IdempotencyStore,
+	keyFn func(idemKey string) string,
+	req any,
+	status int,
+	fn func() (any, bool),
+) {
+	idemKey := strings.TrimSpace(c.GetHeader(idempotencyHeader))
+	if idem == nil || idemKey == "" {
+		if resp, ok := fn(); ok {
+			c.JSON(status, resp)
+		}
+		return
+	}
+
+	ctx := c.Request.Context()
+	storageKey := keyFn(idemKey)
+	fingerprint := requestFingerprint(c, req)
+
+	if payload, ok, _ := idem.GetResult(ctx, storageKey); ok {
+		if !checkFingerprint(c, idem, storageKey, fingerprint) {
+			return
+		}
+		replayIdempotent(c, idemKey, status, payload)
+		return
+	}
+
+	locked, err := idem.AcquireLock(ctx, storageKey, fingerprint, idem.LockTTL())
+	if err != nil {
+		respondErr(c, err)
+		return
+	}
+	if !locked {
+		if !checkFingerprint(c, idem, storageKey, fingerprint) {
+			return
+		}
+		if payload, ok, _ := idem.GetResult(ctx, storageKey); ok {
+			replayIdempotent(c, idemKey, status, payload)
+			return
+		}
+		c.Header("Retry-After", "1")
+		writeError(
+			c,
+			http.StatusConflict,
+			ErrorResponse{Error: "idempotency key in progress"},
+		)
+		return
+	}
+
+	// Release the lock on every path that does not store a result, including
+	// panics, so retries are not blocked until the lock expires. The release
+	// must survive the client going away.
+	saved := false
+	defer func() {
+		if !saved {
+			_ = idem.Release(context.WithoutCancel(ctx), storageKey)
+		}
+	}()
+
+	resp, ok := fn()
+	if !ok {
+		return
+	}
+
+	b, _ := json.Marshal(resp)
+	if err := idem.SaveResult(ctx, storageKey, string(b)); err == nil {
+		saved = true
+	}
+
+	c.Header(idempotencyHeader, idemKey)
+	c.Data(status, "application/json; charset=utf-8", b)
+}
+
+func replayIdempotent(c *gin.Context, idemKey string, status int, payload string) {
+	c.Header(idempotencyHeader, idemKey)
+	c.Data(status, "application/json; charset=utf-8", []byte(payload))
+}
+
+// requestFingerprint hashes the route and the bound request body.
+func requestFingerprint(c *gin.Context, req any) string {
+	b, _ := json.Marshal(req)
+
+	h := sha256.New()
+	h.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n"))
+	h.Write(b)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// checkFingerprint writes 422 and returns false if storageKey belongs to a
+// different request. Store errors are ignored so Redis hiccups do not block
+// legitimate retries.
+func checkFingerprint(
+	c *gin.Context,
+	idem *redisrepo.IdempotencyStore,
+	storageKey, fingerprint string,
+) bool {
+	err := idem.CheckFingerprint(c.Request.Context(), storageKey, fingerprint)
+	if errors.Is(err, redisrepo.ErrFingerprintMismatch) {
+		writeError(
+			c,
+			http.StatusUnprocessableEntity,
+			ErrorResponse{Error: "idempotency key reused with a different request"},
+		)
+		return false
+	}
+
+	return true
+}
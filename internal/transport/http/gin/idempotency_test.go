@@ -0,0 +1,145 @@
+package httpgin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+type idemBody struct {
+	N int `json:"n"`
+}
+
+// newIdemRouter serves POST /x idempotently. The handler fails while *fail is
+// set and counts the runs that got past binding.
+func newIdemRouter(idem *redisrepo.IdempotencyStore, runs *int, fail *bool) *gin.Engine {
+	r := gin.New()
+	r.POST("/x", func(c *gin.Context) {
+		var req idemBody
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		serveIdempotent(c, idem, func(k string) string { return "idem:" + k }, &req, http.StatusCreated,
+			func() (any, bool) {
+				*runs++
+				if *fail {
+					writeError(c, http.StatusConflict, ErrorResponse{Error: "boom"})
+					return nil, false
+				}
+				return map[string]int{"run": *runs}, true
+			})
+	})
+
+	return r
+}
+
+func TestServeIdempotent(t *testing.T) {
+	// step is one request; key is its Idempotency-Key header.
+	type step struct {
+		key        string
+		body       string
+		fail       bool
+		wantStatus int
+		wantBody   string
+		wantRuns   int
+	}
+
+	tests := []struct {
+		name  string
+		setup func(mr *miniredis.Miniredis)
+		steps []step
+	}{
+		{
+			name: "no key runs every time",
+			steps: []step{
+				{body: `{"n":1}`, wantStatus: http.StatusCreated, wantBody: `{"run":1}`, wantRuns: 1},
+				{body: `{"n":1}`, wantStatus: http.StatusCreated, wantBody: `{"run":2}`, wantRuns: 2},
+			},
+		},
+		{
+			name: "retry replays the stored result",
+			steps: []step{
+				{key: "k", body: `{"n":1}`, wantStatus: http.StatusCreated, wantBody: `{"run":1}`, wantRuns: 1},
+				{key: "k", body: `{"n":1}`, wantStatus: http.StatusCreated, wantBody: `{"run":1}`, wantRuns: 1},
+			},
+		},
+		{
+			name: "key reused for another request",
+			steps: []step{
+				{key: "k", body: `{"n":1}`, wantStatus: http.StatusCreated, wantRuns: 1},
+				{key: "k", body: `{"n":2}`, wantStatus: http.StatusUnprocessableEntity, wantRuns: 1},
+			},
+		},
+		{
+			name: "failure releases the key",
+			steps: []step{
+				{key: "k", body: `{"n":1}`, fail: true, wantStatus: http.StatusConflict, wantRuns: 1},
+				{key: "k", body: `{"n":1}`, wantStatus: http.StatusCreated, wantBody: `{"run":2}`, wantRuns: 2},
+			},
+		},
+		{
+			name: "request in flight",
+			setup: func(mr *miniredis.Miniredis) {
+				_ = mr.Set("idem:k", "LOCK")
+			},
+			steps: []step{
+				{key: "k", body: `{"n":1}`, wantStatus: http.StatusConflict, wantRuns: 0},
+			},
+		},
+		{
+			name: "different request while in flight",
+			setup: func(mr *miniredis.Miniredis) {
+				_ = mr.Set("idem:k", "LOCK")
+				_ = mr.Set("idem:k:fp", "other")
+			},
+			steps: []step{
+				{key: "k", body: `{"n":1}`, wantStatus: http.StatusUnprocessableEntity, wantRuns: 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			t.Cleanup(func() { _ = rdb.Close() })
+			if tt.setup != nil {
+				tt.setup(mr)
+			}
+
+			var (
+				runs int
+				fail bool
+			)
+			r := newIdemRouter(redisrepo.NewIdempotencyStore(rdb, time.Hour, time.Minute), &runs, &fail)
+
+			for i, s := range tt.steps {
+				fail = s.fail
+				req := httptest.NewRequest(http.MethodPost, "/x", strings.NewReader(s.body))
+				req.Header.Set("Content-Type", "application/json")
+				if s.key != "" {
+					req.Header.Set(idempotencyHeader, s.key)
+				}
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+
+				if w.Code != s.wantStatus {
+					t.Fatalf("step %d: status = %d, want %d: %s", i, w.Code, s.wantStatus, w.Body.String())
+				}
+				if s.wantBody != "" && w.Body.String() != s.wantBody {
+					t.Errorf("step %d: body = %s, want %s", i, w.Body.String(), s.wantBody)
+				}
+				if runs != s.wantRuns {
+					t.Errorf("step %d: handler ran %d times, want %d", i, runs, s.wantRuns)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package httpgin
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setPageLinks sets an RFC 8288 Link header with "prev" and "next" links for
+// an offset-paginated list. limit is the effective page size and n the number
+// of items returned; "next" is omitted when the page is not full and "prev"
+// on the first page. Other query parameters are preserved.
+func setPageLinks(c *gin.Context, limit, offset, n int) {
+	if limit <= 0 {
+		return
+	}
+	offset = max(offset, 0)
+
+	var links []string
+	if offset > 0 {
+		links = append(links, pageLink(c.Request.URL, limit, max(offset-limit, 0), "prev"))
+	}
+	if n >= limit {
+		links = append(links, pageLink(c.Request.URL, limit, offset+limit, "next"))
+	}
+	if len(links) == 0 {
+		return
+	}
+
+	c.Header("Link", strings.Join(links, ", "))
+}
+
+// pageLink formats one Link header value pointing at the page of u at offset.
+func pageLink(u *url.URL, limit, offset int, rel string) string {
+	q := u.Query()
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set("offset", strconv.Itoa(offset))
+
+	return "<" + u.Path + "?" + q.Encode() + `>; rel="` + rel + `"`
+}
@@ -0,0 +1,68 @@
+package httpgin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSetPageLinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		limit  int
+		offset int
+		n      int
+		want   string
+	}{
+		{
+			name:   "first full page",
+			target: "/events",
+			limit:  10, offset: 0, n: 10,
+			want: `</events?limit=10&offset=10>; rel="next"`,
+		},
+		{
+			name:   "middle page keeps other params",
+			target: "/events?venue_id=3",
+			limit:  10, offset: 20, n: 10,
+			want: `</events?limit=10&offset=10&venue_id=3>; rel="prev", </events?limit=10&offset=30&venue_id=3>; rel="next"`,
+		},
+		{
+			name:   "last page",
+			target: "/events",
+			limit:  10, offset: 20, n: 4,
+			want: `</events?limit=10&offset=10>; rel="prev"`,
+		},
+		{
+			name:   "prev clamps at zero",
+			target: "/events",
+			limit:  10, offset: 5, n: 0,
+			want: `</events?limit=10&offset=0>; rel="prev"`,
+		},
+		{
+			name:   "single short page",
+			target: "/events",
+			limit:  10, offset: 0, n: 3,
+		},
+		{
+			name:   "no page size",
+			target: "/events",
+			limit:  0, offset: 0, n: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+			setPageLinks(c, tt.limit, tt.offset, tt.n)
+
+			if got := w.Header().Get("Link"); got != tt.want {
+				t.Errorf("Link = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,32 @@
 package httpgin
 
 import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/kirinyoku/tix-go/internal/auth"
+	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/metrics"
+	"github.com/kirinyoku/tix-go/internal/service/reservation"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func RequestIDMiddleware() gin.HandlerFunc {
@@ -38,11 +58,16 @@ func CORS() gin.HandlerFunc {
 			"X-Request-ID",
 			"Idempotency-Key",
 			"If-None-Match",
+			"If-Match",
+			AdminTokenHeader,
+			UserIDHeader,
+			APIKeyHeader,
 		},
 		ExposeHeaders: []string{
 			"X-Request-ID",
 			"ETag",
 			"Cache-Control",
+			"Link",
 		},
 		AllowCredentials: false,
 		MaxAge:           12 * time.Hour,
@@ -51,8 +76,16 @@ func CORS() gin.HandlerFunc {
 	return cors.New(cfg)
 }
 
+// loggerKey is the gin context key under which LoggingMiddleware stores its
+// logger for handlers.
+const loggerKey = "logger"
+
+// LoggingMiddleware writes an access log line per request and makes logger
+// available to handlers through requestLogger.
 func LoggingMiddleware(logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		c.Set(loggerKey, logger)
+
 		start := time.Now()
 		path := c.Request.URL.Path
 		raw := c.Request.URL.RawQuery
@@ -76,6 +109,9 @@ func LoggingMiddleware(logger *slog.Logger) gin.HandlerFunc {
 			slog.Duration("latency", latency),
 			slog.Int("bytes_out", c.Writer.Size()),
 		}
+		if k, ok := c.Get(apiKeyKey); ok {
+			attrs = append(attrs, slog.String("api_client", k.(*domain.APIKey).ClientName))
+		}
 
 		// convert []slog.Attr to []any for slog.Group variadic parameter
 		anyAttrs := make([]any, len(attrs))
@@ -83,10 +119,429 @@ func LoggingMiddleware(logger *slog.Logger) gin.HandlerFunc {
 			anyAttrs[i] = attrs[i]
 		}
 
-		if len(c.Errors) > 0 {
-			logger.Error("http", slog.Group("http", anyAttrs...))
-		} else {
-			logger.Info("http", slog.Group("http", anyAttrs...))
+		logger.Info("http", slog.Group("http", anyAttrs...))
+	}
+}
+
+// requestLogger returns the logger stored by LoggingMiddleware, or the
+// default logger when there is none.
+func requestLogger(c *gin.Context) *slog.Logger {
+	if l, ok := c.Get(loggerKey); ok {
+		if logger, ok := l.(*slog.Logger); ok {
+			return logger
+		}
+	}
+
+	return slog.Default()
+}
+
+// MetricsMiddleware records request count, latency and status code per route.
+// The route label is the registered pattern (e.g. /events/:id) to keep
+// cardinality bounded.
+func MetricsMiddleware(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		m.ObserveHTTP(
+			c.Request.Method,
+			route,
+			strconv.Itoa(c.Writer.Status()),
+			time.Since(start),
+		)
+	}
+}
+
+// AdminTokenHeader is the request header carrying the admin API token.
+const AdminTokenHeader = "X-Admin-Token"
+
+// AdminAuthMiddleware grants the admin role to requests whose
+// AdminTokenHeader matches expectedKey, and rejects requests with any other
+// value in that header with 401. Requests without the header pass through
+// with whatever role JWTAuthMiddleware found; RequireRole(auth.RoleAdmin),
+// mounted after it, then decides. An empty expectedKey rejects every static
+// token, so the admin API stays closed unless a token or a JWT secret is
+// configured.
+func AdminAuthMiddleware(expectedKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got := c.GetHeader(AdminTokenHeader)
+		if got == "" {
+			c.Next()
+			return
+		}
+		if expectedKey == "" ||
+			subtle.ConstantTimeCompare([]byte(got), []byte(expectedKey)) != 1 {
+			abortWithError(
+				c,
+				http.StatusUnauthorized,
+				ErrorResponse{Error: "unauthorized"},
+			)
+			return
+		}
+
+		c.Set(roleKey, auth.RoleAdmin)
+		c.Next()
+	}
+}
+
+// UserIDHeader is the request header carrying the user ID in development,
+// when RouterConfig.AllowDevUserHeader is set.
+const UserIDHeader = "X-User-ID"
+
+// userIDKey and roleKey are the gin context keys under which
+// JWTAuthMiddleware stores the authenticated user ID and role. roleKey is
+// also set by AdminAuthMiddleware for a valid static token.
+const (
+	userIDKey = "user_id"
+	roleKey   = "role"
+)
+
+// JWTAuthMiddleware validates an "Authorization: Bearer" JWT signed with
+// secret (see auth.IssueToken) and stores its subject as the user ID and its
+// role claim. Requests without a bearer token pass through unauthenticated
+// and are left to RequireUser and RequireRole; invalid or expired tokens are
+// rejected with 401.
+func JWTAuthMiddleware(secret []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !found {
+			c.Next()
+			return
+		}
+
+		userID, role, err := auth.ParseToken(strings.TrimSpace(token), secret)
+		if err != nil {
+			msg := "unauthorized"
+			if errors.Is(err, auth.ErrTokenExpired) {
+				msg = "token expired"
+			}
+			abortWithError(c, http.StatusUnauthorized, ErrorResponse{Error: msg})
+			return
+		}
+
+		c.Set(userIDKey, userID)
+		c.Set(roleKey, role)
+		c.Next()
+	}
+}
+
+// RequireUser rejects requests without a user authenticated by
+// JWTAuthMiddleware with 401. An API key alone does not identify a user.
+// With allowDevHeader, a request may instead name its user in UserIDHeader;
+// this must stay off in production.
+func RequireUser(allowDevHeader bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetInt64(userIDKey) != 0 {
+			c.Next()
+			return
+		}
+
+		if v := c.GetHeader(UserIDHeader); allowDevHeader && v != "" {
+			if id, err := strconv.ParseInt(v, 10, 64); err == nil && id > 0 {
+				c.Set(userIDKey, id)
+				c.Next()
+				return
+			}
+		}
+
+		abortWithError(c, http.StatusUnauthorized, ErrorResponse{Error: "unauthorized"})
+	}
+}
+
+// RequireRole rejects requests not authenticated with role, by a JWT or by
+// AdminAuthMiddleware: unauthenticated ones with 401, those with another
+// role with 403.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got, ok := c.Get(roleKey)
+		if !ok {
+			abortWithError(c, http.StatusUnauthorized, ErrorResponse{Error: "unauthorized"})
+			return
+		}
+		if got != role {
+			abortWithError(c, http.StatusForbidden, ErrorResponse{Error: "forbidden"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireClientOrRole lets through requests authenticated by
+// APIKeyMiddleware or carrying role, for endpoints meant for trusted
+// clients such as entry gates rather than end users. Other requests are
+// rejected with 401.
+func RequireClientOrRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get(apiKeyKey); ok {
+			c.Next()
+			return
+		}
+		if got, ok := c.Get(roleKey); ok && got == role {
+			c.Next()
+			return
+		}
+
+		abortWithError(c, http.StatusUnauthorized, ErrorResponse{Error: "unauthorized"})
+	}
+}
+
+// authenticatedUser returns the user ID stored by JWTAuthMiddleware or
+// RequireUser. A non-zero bodyUserID must name the same user, otherwise the
+// request is answered with 403 and ok is false.
+func authenticatedUser(c *gin.Context, bodyUserID int64) (int64, bool) {
+	userID := c.GetInt64(userIDKey)
+	if userID == 0 {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{Error: "unauthorized"})
+		return 0, false
+	}
+	if bodyUserID != 0 && bodyUserID != userID {
+		writeError(c, http.StatusForbidden, ErrorResponse{Error: "user_id does not match the authenticated user"})
+		return 0, false
+	}
+
+	return userID, true
+}
+
+// APIKeyHeader is the request header carrying a server-to-server API key.
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyKey is the gin context key under which APIKeyMiddleware stores the
+// client's *domain.APIKey.
+const apiKeyKey = "api_key"
+
+// APIKeyAuthenticator resolves the client behind an API key.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*domain.APIKey, error)
+}
+
+// APIKeyMiddleware authenticates requests carrying APIKeyHeader and stores
+// the client's key for the access log. The key identifies the client only,
+// never a user. Requests without the header pass through. When limiter is
+// set, key-bearing requests are throttled per client IP before the key is
+// looked up. Unknown and revoked keys are rejected with 401; a key needs the
+// read scope for GET and HEAD requests and the write scope for any other
+// method, or the request is rejected with 403.
+func APIKeyMiddleware(keys APIKeyAuthenticator, limiter reservation.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if limiter != nil {
+			ok, _, retry, err := limiter.Allow(c.Request.Context(), "ip:"+c.ClientIP())
+			if err != nil {
+				requestLogger(c).WarnContext(c.Request.Context(), "api key limiter failed, allowing request",
+					"error", err,
+				)
+			} else if !ok {
+				c.Header("Retry-After", retryAfterSeconds(retry))
+				abortWithError(c, http.StatusTooManyRequests, ErrorResponse{Error: "rate limited"})
+				return
+			}
+		}
+
+		k, err := keys.AuthenticateAPIKey(c.Request.Context(), key)
+		if err != nil {
+			respondErr(c, err)
+			c.Abort()
+			return
+		}
+
+		scope := domain.ScopeWrite
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			scope = domain.ScopeRead
+		}
+		if !k.HasScope(scope) {
+			abortWithError(c, http.StatusForbidden, ErrorResponse{Error: "insufficient scope"})
+			return
+		}
+
+		c.Set(apiKeyKey, k)
+		c.Next()
+	}
+}
+
+// trustedClientKey is the gin context key under which
+// TrustedClientMiddleware marks requests from trusted networks.
+const trustedClientKey = "trusted_client"
+
+// TrustedClientMiddleware marks requests whose client IP lies in one of
+// prefixes. Hold endpoints skip the per-IP rate limit for such requests.
+func TrustedClientMiddleware(prefixes []netip.Prefix) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if addr, err := netip.ParseAddr(c.ClientIP()); err == nil {
+			addr = addr.Unmap()
+			for _, p := range prefixes {
+				if p.Contains(addr) {
+					c.Set(trustedClientKey, true)
+					break
+				}
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitKey returns the per-IP rate-limit key of the request, or an empty
+// key, which skips the limit, for clients marked by TrustedClientMiddleware.
+// The IP honors X-Forwarded-For only from RouterConfig.TrustedProxies, so
+// clients behind a trusted load balancer get their own buckets.
+func rateLimitKey(c *gin.Context) string {
+	if c.GetBool(trustedClientKey) {
+		return ""
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+// RateLimitMiddleware throttles requests per keyFn(c) and answers 429 with
+// Retry-After once the limit is exceeded. An empty key skips the limit. When
+// the limiter itself fails, the request is let through so a Redis outage does
+// not take the API down with it.
+func RateLimitMiddleware(limiter reservation.Limiter, keyFn func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := keyFn(c)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		ok, _, retry, err := limiter.Allow(c.Request.Context(), key)
+		if err != nil {
+			requestLogger(c).WarnContext(c.Request.Context(), "rate limiter failed, allowing request",
+				"route", c.FullPath(),
+				"error", err,
+			)
+			c.Next()
+			return
+		}
+		if !ok {
+			c.Header("Retry-After", retryAfterSeconds(retry))
+			abortWithError(c, http.StatusTooManyRequests, ErrorResponse{Error: "rate limited"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// BodyLimitMiddleware caps request bodies at maxBytes. Reading past the
+// limit fails, and JSONDepthMiddleware or bindError answers such requests
+// with 413.
+func BodyLimitMiddleware(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+
+		c.Next()
+	}
+}
+
+// JSONDepthMiddleware rejects request bodies whose JSON objects and arrays
+// nest deeper than maxDepth with 400, before a handler decodes them. The
+// body is buffered, so it must run after BodyLimitMiddleware; bodies over
+// that limit are answered with 413 here. Bodies that are not valid JSON are
+// passed on for the handler to reject.
+func JSONDepthMiddleware(maxDepth int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				abortWithError(c, http.StatusRequestEntityTooLarge, ErrorResponse{
+					Error: fmt.Sprintf("request body too large (max %d bytes)", maxErr.Limit),
+				})
+				return
+			}
+			abortWithError(c, http.StatusBadRequest, ErrorResponse{Error: "invalid request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		if jsonDepthExceeds(body, maxDepth) {
+			abortWithError(c, http.StatusBadRequest, ErrorResponse{
+				Error: fmt.Sprintf("request body nested too deeply (max depth %d)", maxDepth),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// jsonDepthExceeds reports whether body nests objects and arrays deeper than
+// maxDepth. It stops at the first syntax error, reporting false.
+func jsonDepthExceeds(body []byte, maxDepth int) bool {
+	dec := json.NewDecoder(bytes.NewReader(body))
+
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+			if depth > maxDepth {
+				return true
+			}
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+}
+
+// TracingMiddleware starts a server span per request, continuing any trace
+// propagated by the caller, and stores it in the request context so service
+// and repository spans become its children. With no tracer provider
+// installed it is a no-op.
+func TracingMiddleware() gin.HandlerFunc {
+	tracer := otel.Tracer("github.com/kirinyoku/tix-go/internal/transport/http/gin")
+
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(
+			c.Request.Context(),
+			propagation.HeaderCarrier(c.Request.Header),
+		)
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
 		}
 	}
 }
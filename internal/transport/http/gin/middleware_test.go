@@ -0,0 +1,472 @@
+package httpgin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kirinyoku/tix-go/internal/auth"
+	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/service/query"
+)
+
+func TestRequireUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowDevHeader bool
+		userID         int64
+		apiKey         *domain.APIKey
+		devHeader      string
+		wantStatus     int
+		wantUser       int64
+	}{
+		{name: "jwt user", userID: 7, wantStatus: http.StatusOK, wantUser: 7},
+		{name: "none", wantStatus: http.StatusUnauthorized},
+		{
+			name:       "api key only",
+			apiKey:     &domain.APIKey{ClientName: "partner", Scopes: []domain.APIKeyScope{domain.ScopeWrite}},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{name: "dev header enabled", allowDevHeader: true, devHeader: "9", wantStatus: http.StatusOK, wantUser: 9},
+		{name: "dev header disabled", devHeader: "9", wantStatus: http.StatusUnauthorized},
+		{name: "dev header invalid", allowDevHeader: true, devHeader: "x", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUser int64
+
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tt.userID != 0 {
+					c.Set(userIDKey, tt.userID)
+				}
+				if tt.apiKey != nil {
+					c.Set(apiKeyKey, tt.apiKey)
+				}
+			})
+			r.POST("/x", RequireUser(tt.allowDevHeader), func(c *gin.Context) {
+				gotUser = c.GetInt64(userIDKey)
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/x", nil)
+			if tt.devHeader != "" {
+				req.Header.Set(UserIDHeader, tt.devHeader)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if gotUser != tt.wantUser {
+				t.Errorf("user = %d, want %d", gotUser, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestRequireClientOrRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		apiKey     *domain.APIKey
+		wantStatus int
+	}{
+		{name: "none", wantStatus: http.StatusUnauthorized},
+		{name: "user", role: auth.RoleUser, wantStatus: http.StatusUnauthorized},
+		{name: "admin", role: auth.RoleAdmin, wantStatus: http.StatusOK},
+		{
+			name:       "api key",
+			apiKey:     &domain.APIKey{ClientName: "gate", Scopes: []domain.APIKeyScope{domain.ScopeRead}},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tt.role != "" {
+					c.Set(roleKey, tt.role)
+				}
+				if tt.apiKey != nil {
+					c.Set(apiKeyKey, tt.apiKey)
+				}
+			})
+			r.GET("/x", RequireClientOrRole(auth.RoleAdmin), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/x", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAuthenticatedUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int64
+		apiKey     bool
+		bodyUserID int64
+		wantOK     bool
+		wantUser   int64
+		wantStatus int
+	}{
+		{name: "no body user", userID: 7, wantOK: true, wantUser: 7},
+		{name: "matching body user", userID: 7, bodyUserID: 7, wantOK: true, wantUser: 7},
+		{name: "mismatching body user", userID: 7, bodyUserID: 8, wantStatus: http.StatusForbidden},
+		{name: "unauthenticated", bodyUserID: 8, wantStatus: http.StatusUnauthorized},
+		{name: "api key cannot act for body user", apiKey: true, bodyUserID: 8, wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/x", nil)
+			if tt.userID != 0 {
+				c.Set(userIDKey, tt.userID)
+			}
+			if tt.apiKey {
+				c.Set(apiKeyKey, &domain.APIKey{Scopes: []domain.APIKeyScope{domain.ScopeWrite}})
+			}
+
+			got, ok := authenticatedUser(c, tt.bodyUserID)
+			if ok != tt.wantOK || got != tt.wantUser {
+				t.Fatalf("authenticatedUser = %d, %v, want %d, %v", got, ok, tt.wantUser, tt.wantOK)
+			}
+			if !ok && w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+type fakeAPIKeys struct {
+	keys    map[string]*domain.APIKey
+	lookups int
+}
+
+func (f *fakeAPIKeys) AuthenticateAPIKey(_ context.Context, key string) (*domain.APIKey, error) {
+	f.lookups++
+	k, ok := f.keys[key]
+	if !ok {
+		return nil, query.ErrInvalidAPIKey
+	}
+	if k.RevokedAt != nil {
+		return nil, query.ErrAPIKeyRevoked
+	}
+
+	return k, nil
+}
+
+// fakeLimiter allows the first limit calls per key.
+type fakeLimiter struct {
+	limit int64
+	seen  map[string]int64
+}
+
+func (f *fakeLimiter) Allow(_ context.Context, key string) (bool, int64, time.Duration, error) {
+	if f.seen == nil {
+		f.seen = make(map[string]int64)
+	}
+	f.seen[key]++
+
+	return f.seen[key] <= f.limit, f.seen[key], time.Second, nil
+}
+
+func (f *fakeLimiter) Peek(_ context.Context, key string) (bool, int64, time.Duration, error) {
+	return f.seen[key] < f.limit, f.seen[key], time.Second, nil
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	revokedAt := time.Now()
+	keys := map[string]*domain.APIKey{
+		"read":    {ClientName: "reader", Scopes: []domain.APIKeyScope{domain.ScopeRead}},
+		"write":   {ClientName: "writer", Scopes: []domain.APIKeyScope{domain.ScopeRead, domain.ScopeWrite}},
+		"revoked": {ClientName: "old", Scopes: []domain.APIKeyScope{domain.ScopeRead}, RevokedAt: &revokedAt},
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		key        string
+		limit      int64
+		wantStatus int
+		wantClient string
+		wantLookup bool
+	}{
+		{name: "no key", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "valid read", method: http.MethodGet, key: "read", wantStatus: http.StatusOK, wantClient: "reader", wantLookup: true},
+		{name: "valid write", method: http.MethodPost, key: "write", wantStatus: http.StatusOK, wantClient: "writer", wantLookup: true},
+		{name: "insufficient scope", method: http.MethodPost, key: "read", wantStatus: http.StatusForbidden, wantLookup: true},
+		{name: "revoked", method: http.MethodGet, key: "revoked", wantStatus: http.StatusUnauthorized, wantLookup: true},
+		{name: "unknown", method: http.MethodGet, key: "nope", wantStatus: http.StatusUnauthorized, wantLookup: true},
+		{name: "rate limited", method: http.MethodGet, key: "read", limit: -1, wantStatus: http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authn := &fakeAPIKeys{keys: keys}
+			limiter := &fakeLimiter{limit: 10}
+			if tt.limit != 0 {
+				limiter.limit = tt.limit
+			}
+
+			var gotClient string
+			r := gin.New()
+			r.Use(APIKeyMiddleware(authn, limiter))
+			r.Handle(tt.method, "/x", func(c *gin.Context) {
+				if k, ok := c.Get(apiKeyKey); ok {
+					gotClient = k.(*domain.APIKey).ClientName
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/x", nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if gotClient != tt.wantClient {
+				t.Errorf("client = %q, want %q", gotClient, tt.wantClient)
+			}
+			if (authn.lookups > 0) != tt.wantLookup {
+				t.Errorf("lookups = %d, want lookup %v", authn.lookups, tt.wantLookup)
+			}
+		})
+	}
+}
+
+func TestAdminAuth(t *testing.T) {
+	secret := []byte("test-secret")
+	token := func(t *testing.T, role string, ttl time.Duration) string {
+		t.Helper()
+		tok, err := auth.IssueToken(1, role, ttl, secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + tok
+	}
+
+	tests := []struct {
+		name          string
+		adminToken    string
+		authorization string
+		staticToken   string
+		wantStatus    int
+		wantError     string
+	}{
+		{name: "admin jwt", authorization: token(t, auth.RoleAdmin, time.Hour), wantStatus: http.StatusOK},
+		{name: "user jwt", authorization: token(t, auth.RoleUser, time.Hour), wantStatus: http.StatusForbidden, wantError: "forbidden"},
+		{name: "expired jwt", authorization: token(t, auth.RoleAdmin, -time.Minute), wantStatus: http.StatusUnauthorized, wantError: "token expired"},
+		{name: "invalid jwt", authorization: "Bearer nope", wantStatus: http.StatusUnauthorized, wantError: "unauthorized"},
+		{name: "nothing", wantStatus: http.StatusUnauthorized, wantError: "unauthorized"},
+		{name: "static token", adminToken: "s3cret", staticToken: "s3cret", wantStatus: http.StatusOK},
+		{name: "wrong static token", adminToken: "s3cret", staticToken: "guess", wantStatus: http.StatusUnauthorized, wantError: "unauthorized"},
+		{name: "static token unset", staticToken: "guess", wantStatus: http.StatusUnauthorized, wantError: "unauthorized"},
+		{
+			name:          "user jwt with wrong static token",
+			adminToken:    "s3cret",
+			authorization: token(t, auth.RoleUser, time.Hour),
+			staticToken:   "guess",
+			wantStatus:    http.StatusUnauthorized,
+			wantError:     "unauthorized",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/admin/x",
+				JWTAuthMiddleware(secret),
+				AdminAuthMiddleware(tt.adminToken),
+				RequireRole(auth.RoleAdmin),
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/x", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.staticToken != "" {
+				req.Header.Set(AdminTokenHeader, tt.staticToken)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantError != "" && !strings.Contains(w.Body.String(), tt.wantError) {
+				t.Errorf("body = %s, want error %q", w.Body.String(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestJWTAuthMiddleware(t *testing.T) {
+	secret := []byte("test-secret")
+	valid, err := auth.IssueToken(42, auth.RoleUser, time.Hour, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantUser      int64
+		wantRole      string
+	}{
+		{name: "valid", authorization: "Bearer " + valid, wantStatus: http.StatusOK, wantUser: 42, wantRole: auth.RoleUser},
+		{name: "no token passes through", wantStatus: http.StatusOK},
+		{name: "other scheme passes through", authorization: "Basic abc", wantStatus: http.StatusOK},
+		{name: "invalid", authorization: "Bearer " + valid + "x", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUser int64
+			var gotRole string
+
+			r := gin.New()
+			r.GET("/x", JWTAuthMiddleware(secret), func(c *gin.Context) {
+				gotUser = c.GetInt64(userIDKey)
+				gotRole = c.GetString(roleKey)
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/x", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if gotUser != tt.wantUser || gotRole != tt.wantRole {
+				t.Errorf("got (%d, %q), want (%d, %q)", gotUser, gotRole, tt.wantUser, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestBodyLimits(t *testing.T) {
+	const maxBytes, maxDepth = 64, 3
+
+	r := gin.New()
+	r.Use(BodyLimitMiddleware(maxBytes), JSONDepthMiddleware(maxDepth))
+	r.POST("/x", func(c *gin.Context) {
+		var body any
+		if err := c.ShouldBindJSON(&body); err != nil {
+			bindError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	// padded returns a JSON object of exactly n bytes.
+	padded := func(n int) string {
+		return `{"s":"` + strings.Repeat("a", n-len(`{"s":""}`)) + `"}`
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantError  string
+	}{
+		{name: "at size limit", body: padded(maxBytes), wantStatus: http.StatusNoContent},
+		{name: "over size limit", body: padded(maxBytes + 1), wantStatus: http.StatusRequestEntityTooLarge, wantError: "request body too large (max 64 bytes)"},
+		{name: "at depth limit", body: `{"a":[{"b":1}]}`, wantStatus: http.StatusNoContent},
+		{name: "past depth limit", body: `{"a":[{"b":[1]}]}`, wantStatus: http.StatusBadRequest, wantError: "nested too deeply"},
+		{name: "invalid json", body: `{"a":`, wantStatus: http.StatusBadRequest, wantError: "invalid request body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodPost, "/x", tt.body, nil)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantError) {
+				t.Errorf("body = %s, want error %q", w.Body.String(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestRouterBodyLimits(t *testing.T) {
+	r := newTestRouter(RouterConfig{MaxBodyBytes: 32, MaxJSONDepth: 2})
+	owner := map[string]string{"Authorization": bearer(t, 7)}
+
+	w := serve(r, http.MethodPost, "/events/1/holds", `{"seat_ids":[1,2,3,4,5,6,7,8,9,10,11,12]}`, owner)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized: status = %d, want %d: %s", w.Code, http.StatusRequestEntityTooLarge, w.Body.String())
+	}
+
+	w = serve(r, http.MethodPost, "/events/1/holds", `{"seat_ids":[[1]]}`, owner)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "nested too deeply") {
+		t.Errorf("too deep: status = %d, body = %s, want %d nested too deeply", w.Code, w.Body.String(), http.StatusBadRequest)
+	}
+}
+
+func TestTrustedClientMiddleware(t *testing.T) {
+	r := gin.New()
+	if err := r.SetTrustedProxies([]string{"127.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+	r.Use(TrustedClientMiddleware([]netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}))
+	r.GET("/x", func(c *gin.Context) {
+		c.String(http.StatusOK, rateLimitKey(c))
+	})
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		wantKey    string
+	}{
+		{name: "allowlisted ipv4", remoteAddr: "10.1.2.3:1234", wantKey: ""},
+		{name: "allowlisted ipv6", remoteAddr: "[2001:db8::1]:1234", wantKey: ""},
+		{name: "ipv4-mapped ipv6 peer", remoteAddr: "[::ffff:10.1.2.3]:1234", wantKey: ""},
+		{name: "other ipv4", remoteAddr: "192.0.2.1:1234", wantKey: "ip:192.0.2.1"},
+		{name: "other ipv6", remoteAddr: "[2001:db9::1]:1234", wantKey: "ip:2001:db9::1"},
+		// Forwarded addresses reach ClientIP verbatim, so only Unmap lets
+		// the mapped form match the IPv4 prefix.
+		{name: "forwarded ipv4-mapped ipv6", remoteAddr: "127.0.0.1:1234", forwarded: "::ffff:10.1.2.3", wantKey: ""},
+		{name: "forwarded mapped other", remoteAddr: "127.0.0.1:1234", forwarded: "::ffff:192.0.2.1", wantKey: "ip:::ffff:192.0.2.1"},
+		{name: "proxy itself", remoteAddr: "127.0.0.1:1234", wantKey: "ip:127.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/x", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if got := w.Body.String(); got != tt.wantKey {
+				t.Errorf("rate-limit key = %q, want %q", got, tt.wantKey)
+			}
+		})
+	}
+}
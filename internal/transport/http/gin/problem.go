@@ -0,0 +1,72 @@
+package httpgin
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MIMEProblemJSON is the RFC 7807 media type. Clients that list it in Accept
+// get errors as ProblemDetails instead of ErrorResponse.
+const MIMEProblemJSON = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 error body. Fields and UnavailableSeatIDs
+// are extension members carrying the same details as in ErrorResponse.
+type ProblemDetails struct {
+	Type               string       `json:"type"`
+	Title              string       `json:"title"`
+	Status             int          `json:"status"`
+	Detail             string       `json:"detail,omitempty"`
+	Instance           string       `json:"instance,omitempty"`
+	Fields             []FieldError `json:"fields,omitempty"`
+	UnavailableSeatIDs []int64      `json:"unavailable_seat_ids,omitempty"`
+}
+
+// writeError is the single place error bodies are written. It renders resp
+// as is, or as ProblemDetails when the client accepts problem+json.
+func writeError(c *gin.Context, status int, resp ErrorResponse) {
+	if !acceptsProblemJSON(c.GetHeader("Accept")) {
+		c.JSON(status, resp)
+		return
+	}
+
+	reqID, _ := c.Get("request_id")
+	instance, _ := reqID.(string)
+
+	c.Header("Content-Type", MIMEProblemJSON)
+	c.JSON(status, ProblemDetails{
+		Type:               "about:blank",
+		Title:              http.StatusText(status),
+		Status:             status,
+		Detail:             resp.Error,
+		Instance:           instance,
+		Fields:             resp.Fields,
+		UnavailableSeatIDs: resp.UnavailableSeatIDs,
+	})
+}
+
+// abortWithError stops the handler chain and writes an error like writeError.
+func abortWithError(c *gin.Context, status int, resp ErrorResponse) {
+	c.Abort()
+	writeError(c, status, resp)
+}
+
+// acceptsProblemJSON reports whether the Accept header lists problem+json
+// with a non-zero quality.
+func acceptsProblemJSON(accept string) bool {
+	for part := range strings.SplitSeq(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mt != MIMEProblemJSON {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q <= 0 {
+			continue
+		}
+		return true
+	}
+
+	return false
+}
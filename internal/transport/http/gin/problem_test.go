@@ -0,0 +1,84 @@
+package httpgin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWriteErrorNegotiation(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		wantProblem bool
+	}{
+		{name: "no accept", accept: ""},
+		{name: "any", accept: "*/*"},
+		{name: "json", accept: "application/json"},
+		{name: "problem json", accept: MIMEProblemJSON, wantProblem: true},
+		{name: "problem json in list", accept: "application/json, application/problem+json;q=0.9", wantProblem: true},
+		{name: "problem json refused", accept: "application/problem+json;q=0, application/json"},
+		{name: "malformed", accept: "application/problem+json;;="},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/events/1/holds", nil)
+			if tt.accept != "" {
+				c.Request.Header.Set("Accept", tt.accept)
+			}
+			c.Set("request_id", "req-1")
+
+			writeError(c, http.StatusConflict, ErrorResponse{
+				Error:              "seats unavailable",
+				UnavailableSeatIDs: []int64{7},
+			})
+
+			if w.Code != http.StatusConflict {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+			}
+			ct := w.Header().Get("Content-Type")
+
+			if !tt.wantProblem {
+				if !strings.HasPrefix(ct, "application/json") {
+					t.Errorf("Content-Type = %q, want application/json", ct)
+				}
+				var got ErrorResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+					t.Fatal(err)
+				}
+				if got.Error != "seats unavailable" {
+					t.Errorf("error = %q, want %q", got.Error, "seats unavailable")
+				}
+				return
+			}
+
+			if !strings.HasPrefix(ct, MIMEProblemJSON) {
+				t.Errorf("Content-Type = %q, want %s", ct, MIMEProblemJSON)
+			}
+			var got ProblemDetails
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			want := ProblemDetails{
+				Type:               "about:blank",
+				Title:              http.StatusText(http.StatusConflict),
+				Status:             http.StatusConflict,
+				Detail:             "seats unavailable",
+				Instance:           "req-1",
+				UnavailableSeatIDs: []int64{7},
+			}
+			if got.Type != want.Type || got.Title != want.Title || got.Status != want.Status ||
+				got.Detail != want.Detail || got.Instance != want.Instance ||
+				!slices.Equal(got.UnavailableSeatIDs, want.UnavailableSeatIDs) {
+				t.Errorf("problem = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
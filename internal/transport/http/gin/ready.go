@@ -0,0 +1,56 @@
+package httpgin
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readyTimeout bounds every dependency check performed by /readyz.
+const readyTimeout = 2 * time.Second
+
+// ReadyCheck reports whether a dependency is reachable.
+type ReadyCheck func(ctx context.Context) error
+
+// @Summary  Readiness probe
+// @Description Pings every dependency (Postgres, Redis) and reports which ones failed.
+// @Success  200  {object}  ReadyResponse
+// @Failure  503  {object}  ReadyResponse
+// @Router   /readyz [get]
+func handleReady(checks map[string]ReadyCheck) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readyTimeout)
+		defer cancel()
+
+		var (
+			mu     sync.Mutex
+			wg     sync.WaitGroup
+			failed map[string]string
+		)
+		for name, check := range checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := check(ctx); err != nil {
+					mu.Lock()
+					if failed == nil {
+						failed = make(map[string]string)
+					}
+					failed[name] = err.Error()
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(failed) > 0 {
+			c.JSON(http.StatusServiceUnavailable, ReadyResponse{Status: "unavailable", Failed: failed})
+			return
+		}
+
+		c.JSON(http.StatusOK, ReadyResponse{Status: "ok"})
+	}
+}
@@ -0,0 +1,61 @@
+package httpgin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"testing"
+)
+
+func TestReadyz(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name       string
+		checks     map[string]ReadyCheck
+		wantStatus int
+		wantFailed map[string]string
+	}{
+		{name: "healthy", checks: map[string]ReadyCheck{"postgres": ok, "redis": ok}, wantStatus: http.StatusOK},
+		{name: "no checks", wantStatus: http.StatusOK},
+		{
+			name:       "redis down",
+			checks:     map[string]ReadyCheck{"postgres": ok, "redis": down},
+			wantStatus: http.StatusServiceUnavailable,
+			wantFailed: map[string]string{"redis": "connection refused"},
+		},
+		{
+			name:       "both down",
+			checks:     map[string]ReadyCheck{"postgres": down, "redis": down},
+			wantStatus: http.StatusServiceUnavailable,
+			wantFailed: map[string]string{"postgres": "connection refused", "redis": "connection refused"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRouter(RouterConfig{ReadyChecks: tt.checks})
+
+			w := serve(r, http.MethodGet, "/readyz", "", nil)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			var got ReadyResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			wantStatus := "ok"
+			if tt.wantFailed != nil {
+				wantStatus = "unavailable"
+			}
+			if got.Status != wantStatus {
+				t.Errorf("status = %q, want %q", got.Status, wantStatus)
+			}
+			if !maps.Equal(got.Failed, tt.wantFailed) {
+				t.Errorf("failed = %v, want %v", got.Failed, tt.wantFailed)
+			}
+		})
+	}
+}
@@ -1,36 +1,142 @@
 package httpgin
 
 import (
-	"encoding/json"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kirinyoku/tix-go/internal/auth"
 	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/metrics"
 	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
 	"github.com/kirinyoku/tix-go/internal/service"
 	"github.com/kirinyoku/tix-go/internal/service/admin"
 	"github.com/kirinyoku/tix-go/internal/service/orders"
 	"github.com/kirinyoku/tix-go/internal/service/query"
 	"github.com/kirinyoku/tix-go/internal/service/reservation"
+	"github.com/kirinyoku/tix-go/internal/ticket"
+	goredis "github.com/redis/go-redis/v9"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+type RouterConfig struct {
+	// AdminToken is the shared secret expected in the X-Admin-Token header
+	// on /admin routes, as an alternative to a JWT with the admin role. When
+	// empty, only such JWTs are accepted.
+	AdminToken string
+	// Metrics, when set, enables request instrumentation and GET /metrics.
+	Metrics *metrics.Metrics
+	// Events, when set, enables GET /events/:id/stream and GET /events/:id/ws.
+	// All their clients share one subscription per router.
+	Events *redisrepo.EventsPubSub
+	// WSIdleTimeout closes WebSocket connections that have not answered a
+	// ping within this duration. Defaults to 60s.
+	WSIdleTimeout time.Duration
+	// ReadyChecks are run by GET /readyz, keyed by dependency name.
+	ReadyChecks map[string]ReadyCheck
+	// MaxBodyBytes caps the size of request bodies. Defaults to 1 MiB.
+	MaxBodyBytes int64
+	// MaxJSONDepth caps how deeply objects and arrays may nest in request
+	// bodies. Defaults to 32.
+	MaxJSONDepth int
+	// GzipMinBytes is the smallest response body of the read endpoints that
+	// is gzip-compressed. Defaults to 1 KiB.
+	GzipMinBytes int
+	// TrustedCIDRs lists client networks exempt from the per-IP hold limit.
+	TrustedCIDRs []netip.Prefix
+	// WriteLimiter, when set, throttles the write endpoints outside hold
+	// creation (which the reservation service limits itself) per client IP.
+	// Admin reads are not throttled.
+	WriteLimiter reservation.Limiter
+	// APIKeyLimiter, when set, throttles requests carrying an API key per
+	// client IP before the key is looked up, so guessing keys costs neither
+	// unbounded attempts nor a database query each.
+	APIKeyLimiter reservation.Limiter
+	// TrustedProxies lists the proxies whose X-Forwarded-For and X-Real-IP
+	// headers are believed by c.ClientIP, and so by the per-IP rate limit.
+	// Empty trusts no proxy: the client IP is the TCP peer address.
+	TrustedProxies []netip.Prefix
+	// LogBodyMaxBytes, when positive, logs request and response bodies of up
+	// to this many bytes each at debug level. Credentials are redacted.
+	LogBodyMaxBytes int
+	// JWTSecret verifies the bearer JWTs that authenticate users on hold and
+	// confirm requests and admins on /admin. When empty, no JWT is accepted.
+	JWTSecret string
+	// AllowDevUserHeader lets requests without a token name their user in
+	// the X-User-ID header. For development only.
+	AllowDevUserHeader bool
+	// Postgres and Redis, when set, are reported by GET /admin/stats.
+	Postgres *pgxpool.Pool
+	Redis    *goredis.Client
+}
+
+// defaultMaxBodyBytes is used when RouterConfig.MaxBodyBytes is unset.
+const defaultMaxBodyBytes = 1 << 20
+
+// defaultMaxJSONDepth is used when RouterConfig.MaxJSONDepth is unset.
+const defaultMaxJSONDepth = 32
+
 func NewRouter(
 	svcs *service.Services,
 	idem *redisrepo.IdempotencyStore,
 	logger *slog.Logger,
+	cfg RouterConfig,
 	middlewares ...gin.HandlerFunc,
 ) *gin.Engine {
+	useJSONFieldNames()
+
+	maxBodyBytes := cfg.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = defaultMaxBodyBytes
+	}
+
+	maxJSONDepth := cfg.MaxJSONDepth
+	if maxJSONDepth <= 0 {
+		maxJSONDepth = defaultMaxJSONDepth
+	}
+
 	r := gin.New()
 
-	r.Use(gin.Recovery(), LoggingMiddleware(logger), RequestIDMiddleware(), CORS())
+	proxies := make([]string, 0, len(cfg.TrustedProxies))
+	for _, p := range cfg.TrustedProxies {
+		proxies = append(proxies, p.String())
+	}
+	if err := r.SetTrustedProxies(proxies); err != nil {
+		logger.Error("invalid trusted proxies, trusting none", "error", err)
+		_ = r.SetTrustedProxies(nil)
+	}
+
+	r.Use(
+		gin.Recovery(),
+		LoggingMiddleware(logger),
+		RequestIDMiddleware(),
+		CORS(),
+		TracingMiddleware(),
+		BodyLimitMiddleware(maxBodyBytes),
+		JSONDepthMiddleware(maxJSONDepth),
+	)
+	if cfg.LogBodyMaxBytes > 0 {
+		r.Use(BodyLogMiddleware(logger, cfg.LogBodyMaxBytes))
+	}
+	r.Use(APIKeyMiddleware(svcs.Query, cfg.APIKeyLimiter))
+	if len(cfg.TrustedCIDRs) > 0 {
+		r.Use(TrustedClientMiddleware(cfg.TrustedCIDRs))
+	}
+	if cfg.Metrics != nil {
+		r.Use(MetricsMiddleware(cfg.Metrics))
+		r.GET("/metrics", gin.WrapH(cfg.Metrics.Handler()))
+	}
 	for _, m := range middlewares {
 		if m != nil {
 			r.Use(m)
@@ -44,24 +150,91 @@ func NewRouter(
 	r.GET("/healthz", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
+	r.GET("/readyz", handleReady(cfg.ReadyChecks))
+
+	// Read endpoints with potentially large bodies are compressed.
+	gz := GzipMiddleware(cfg.GzipMinBytes)
+
+	// jwtAuth identifies the caller when a bearer token is sent; routes that
+	// need a user add RequireUser after it.
+	jwtAuth := JWTAuthMiddleware([]byte(cfg.JWTSecret))
 
 	// Public API
+	r.GET("/events", gz, handleListEvents(svcs))
 	r.GET("/events/:id", handleGetEvent(svcs))
 	r.GET("/events/:id/availability", handleGetAvailability(svcs))
-	r.GET("/events/:id/seats", handleListEventSeats(svcs))
+	r.GET("/events/:id/availability/sections", gz, handleGetSectionAvailability(svcs))
+	r.POST("/events/availability", gz, handleAvailabilityBatch(svcs))
+	r.GET("/events/:id/seats", gz, handleListEventSeats(svcs))
+	r.GET("/events/:id/seatmap", gz, jwtAuth, handleGetSeatMap(svcs))
+	if cfg.Events != nil {
+		hub := redisrepo.NewEventsHub(cfg.Events)
+		r.GET("/events/:id/stream", handleEventStream(svcs, hub))
+		r.GET("/events/:id/ws", handleSeatMapWS(svcs, hub, cfg.WSIdleTimeout))
+	}
+	r.POST("/events/:id/seats/status", gz, handleSeatStatuses(svcs))
+
+	// Writes outside hold creation share a per-IP limit.
+	writeLimit := func(c *gin.Context) { c.Next() }
+	if cfg.WriteLimiter != nil {
+		writeLimit = RateLimitMiddleware(cfg.WriteLimiter, rateLimitKey)
+	}
+
+	// Holds and orders are made on behalf of the authenticated user.
+	userAuth := RequireUser(cfg.AllowDevUserHeader)
+
+	r.POST("/events/:id/holds", jwtAuth, userAuth, handleCreateHold(svcs, idem))
+	r.POST("/events/:id/holds/auto", jwtAuth, userAuth, handleAutoHold(svcs, idem))
+	r.POST("/events/:id/holds/ga", jwtAuth, userAuth, handleGAHold(svcs, idem))
+
+	r.GET("/venues/:id", gz, handleGetVenue(svcs))
+	r.GET("/holds/:id", jwtAuth, userAuth, handleGetHold(svcs))
+	r.POST("/events/:id/waitlist", writeLimit, jwtAuth, userAuth, handleJoinWaitlist(svcs))
+
+	r.DELETE("/holds/:id", writeLimit, jwtAuth, userAuth, handleCancelHold(svcs))
+	r.POST("/holds/:id/extend", writeLimit, jwtAuth, userAuth, handleExtendHold(svcs))
 
-	r.POST("/events/:id/holds", handleCreateHold(svcs, idem))
+	r.POST("/orders/confirm", writeLimit, jwtAuth, userAuth, handleConfirmOrder(svcs, idem))
+	r.GET("/orders/:id", jwtAuth, userAuth, handleGetOrder(svcs))
+	r.POST("/orders/tickets", writeLimit, jwtAuth, userAuth, handleOrderTickets(svcs))
+	r.POST("/orders/:id/refund", writeLimit, jwtAuth, userAuth, handleRefundOrder(svcs))
+	r.GET("/users/:id/orders", gz, jwtAuth, userAuth, handleListUserOrders(svcs))
 
-	r.POST("/orders/confirm", handleConfirmOrder(svcs))
-	r.GET("/orders/:id", handleGetOrder(svcs))
+	r.GET("/tickets/:id", jwtAuth, userAuth, handleGetTicket(svcs))
+	r.GET("/tickets/:id/qr", jwtAuth, userAuth, handleTicketQR(svcs))
+
+	// Ticket checks are for entry gates, which authenticate with an API key
+	// or as admins, never for ticket holders.
+	gate := r.Group("/tickets", jwtAuth, AdminAuthMiddleware(cfg.AdminToken), RequireClientOrRole(auth.RoleAdmin))
+	{
+		gate.GET("/:id/verify", handleVerifyTicket(svcs))
+		gate.POST("/verify", handleVerifyTicketToken(svcs))
+	}
 
 	// Admin-API
-	// TODO: add admin middleware
-	admin := r.Group("/admin")
+	// Admins authenticate with a JWT carrying the admin role or with the
+	// static admin token; RequireRole checks either.
+	admin := r.Group("/admin", jwtAuth, AdminAuthMiddleware(cfg.AdminToken), RequireRole(auth.RoleAdmin))
 	{
-		admin.POST("/venues", handleCreateVenue(svcs))
-		admin.POST("/venues/:id/seats", handleBatchCreateSeats(svcs))
-		admin.POST("/events", handleCreateEvent(svcs))
+		admin.GET("/venues", handleListVenues(svcs))
+		admin.POST("/venues", writeLimit, handleCreateVenue(svcs))
+		admin.PUT("/venues/:id", writeLimit, handleUpdateVenue(svcs))
+		admin.POST("/venues/:id/seats", writeLimit, handleBatchCreateSeats(svcs, idem))
+		admin.POST("/venues/:id/seats/generate", writeLimit, handleGenerateSeats(svcs))
+		admin.POST("/events", writeLimit, handleCreateEvent(svcs))
+		admin.POST("/events/recurring", writeLimit, handleCreateRecurringEvents(svcs))
+		admin.PUT("/events/:id", writeLimit, handleUpdateEvent(svcs))
+		admin.PATCH("/seats/:id", writeLimit, handleUpdateSeat(svcs))
+		admin.POST("/events/:id/cancel", writeLimit, handleCancelEvent(svcs))
+		admin.GET("/events/:id/holds", handleListActiveHolds(svcs))
+		admin.PUT("/events/:id/ga", writeLimit, handleSetGACapacity(svcs))
+		admin.GET("/events/:id/log", handleListEventLog(svcs))
+		admin.GET("/events/:id/revenue", handleEventRevenue(svcs))
+		admin.GET("/orders", handleListOrders(svcs))
+		admin.POST("/holds/expire", writeLimit, handleExpireHolds(svcs))
+		admin.POST("/api-keys", writeLimit, handleCreateAPIKey(svcs))
+		admin.DELETE("/api-keys/:id", writeLimit, handleRevokeAPIKey(svcs))
+		admin.GET("/stats", handleStats(cfg.Postgres, cfg.Redis))
 	}
 
 	return r
@@ -69,6 +242,49 @@ func NewRouter(
 
 // --- Handlers with Swagger annotations ---
 
+// @Summary  List events
+// @Param    limit    query  int     false "page size"
+// @Param    offset   query  int     false "offset"
+// @Param    venue_id query  int     false "only events at this venue"
+// @Param    from     query  string  false "only events starting at or after (RFC3339)"
+// @Param    to       query  string  false "only events starting at or before (RFC3339)"
+// @Success  200  {array}   domain.Event
+// @Failure  400  {object}  ErrorResponse
+// @Router   /events [get]
+func handleListEvents(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := parseIntDefault(c.Query("limit"), 0)
+		offset := parseIntDefault(c.Query("offset"), 0)
+
+		var venueID *int64
+		if v := c.Query("venue_id"); v != "" {
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				badRequest(c, "invalid venue_id")
+				return
+			}
+			venueID = &id
+		}
+		from, ok := parseOptionalRFC3339(c, "from")
+		if !ok {
+			return
+		}
+		to, ok := parseOptionalRFC3339(c, "to")
+		if !ok {
+			return
+		}
+
+		events, pageSize, err := svcs.Query.ListEventsFiltered(c.Request.Context(), venueID, from, to, limit, offset)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		setPageLinks(c, pageSize, offset, len(events))
+		// ETag + Cache-Control 15s
+		writeJSONWithCache(c, http.StatusOK, events, "public, max-age=15", true)
+	}
+}
+
 // @Summary  Get event
 // @Param    id  path  int  true  "Event ID"
 // @Success  200  {object}  domain.Event
@@ -85,8 +301,31 @@ func handleGetEvent(svcs *service.Services) gin.HandlerFunc {
 			respondErr(c, err)
 			return
 		}
-		// ETag + Cache-Control 60s
-		writeJSONWithCache(c, http.StatusOK, e, "public, max-age=60", true)
+		// Strong ETag + Cache-Control 60s; the ETag is accepted by If-Match
+		// on PUT /admin/events/{id}.
+		writeJSONWithCache(c, http.StatusOK, e, "public, max-age=60", false)
+	}
+}
+
+// @Summary  Get venue
+// @Param    id  path  int  true  "Venue ID"
+// @Success  200  {object}  domain.Venue
+// @Failure  404  {object}  ErrorResponse
+// @Router   /venues/{id} [get]
+func handleGetVenue(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		venueID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		v, err := svcs.Query.GetVenue(c.Request.Context(), venueID)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		// Strong ETag + Cache-Control 5m (venue layouts rarely change); the
+		// ETag is accepted by If-Match on PUT /admin/venues/{id}.
+		writeJSONWithCache(c, http.StatusOK, v, "public, max-age=300", false)
 	}
 }
 
@@ -110,11 +349,55 @@ func handleGetAvailability(svcs *service.Services) gin.HandlerFunc {
 	}
 }
 
+// @Summary  Get availability counters per section
+// @Param    id  path  int  true  "Event ID"
+// @Success  200  {array}  domain.SectionCounts
+// @Router   /events/{id}/availability/sections [get]
+func handleGetSectionAvailability(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		counts, err := svcs.Query.CountsBySection(c.Request.Context(), eventID)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		// ETag + Cache-Control 15s
+		writeJSONWithCache(c, http.StatusOK, counts, "public, max-age=15", true)
+	}
+}
+
+// @Summary  Get availability counters for several events
+// @Description Unknown events are omitted from the result.
+// @Param    req  body  AvailabilityBatchRequest  true  "payload"
+// @Success  200  {object}  AvailabilityBatchResponse
+// @Failure  400  {object}  ErrorResponse
+// @Router   /events/availability [post]
+func handleAvailabilityBatch(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req AvailabilityBatchRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		counts, err := svcs.Query.CountsByStatusBatch(c.Request.Context(), req.EventIDs)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, AvailabilityBatchResponse{Counts: counts})
+	}
+}
+
 // @Summary  List event seats
 // @Param    id     path   int     true  "Event ID"
 // @Param    only   query  string  false "available"
 // @Param    limit  query  int     false "page size"
 // @Param    offset query  int     false "offset"
+// @Param    cursor query  string  false "keyset cursor; when present (even empty) the response is a SeatPageResponse"
+// @Param    meta   query  bool    false "when true the response is a SeatListResponse with the total count"
 // @Success  200  {array}   domain.SeatWithStatus
 // @Router   /events/{id}/seats [get]
 func handleListEventSeats(svcs *service.Services) gin.HandlerFunc {
@@ -132,7 +415,55 @@ func handleListEventSeats(svcs *service.Services) gin.HandlerFunc {
 		limit := parseIntDefault(c.Query("limit"), 100)
 		offset := parseIntDefault(c.Query("offset"), 0)
 
-		seats, err := svcs.Query.ListEventSeats(
+		if cursor, ok := c.GetQuery("cursor"); ok {
+			after, err := decodeSeatCursor(cursor)
+			if err != nil {
+				badRequest(c, "invalid cursor")
+				return
+			}
+			seats, pageSize, err := svcs.Query.ListEventSeatsAfter(
+				c.Request.Context(),
+				eventID,
+				onlyAvailable,
+				after,
+				limit,
+			)
+			if err != nil {
+				respondErr(c, err)
+				return
+			}
+			resp := SeatPageResponse{Items: seats}
+			if len(seats) == pageSize {
+				resp.NextCursor = encodeSeatCursor(seats[len(seats)-1].Seat)
+			}
+			writeJSONWithCache(c, http.StatusOK, resp, "public, max-age=15", true)
+			return
+		}
+
+		if c.Query("meta") == "true" {
+			seats, total, pageSize, err := svcs.Query.ListEventSeatsPage(
+				c.Request.Context(),
+				eventID,
+				onlyAvailable,
+				limit,
+				offset,
+			)
+			if err != nil {
+				respondErr(c, err)
+				return
+			}
+			resp := SeatListResponse{
+				Items:  seats,
+				Total:  total,
+				Limit:  pageSize,
+				Offset: offset,
+			}
+			setPageLinks(c, pageSize, offset, len(seats))
+			writeJSONWithCache(c, http.StatusOK, resp, "public, max-age=15", true)
+			return
+		}
+
+		seats, pageSize, err := svcs.Query.ListEventSeats(
 			c.Request.Context(),
 			eventID,
 			onlyAvailable,
@@ -143,19 +474,89 @@ func handleListEventSeats(svcs *service.Services) gin.HandlerFunc {
 			respondErr(c, err)
 			return
 		}
+		setPageLinks(c, pageSize, offset, len(seats))
 		// ETag + Cache-Control 15s (для списків — коротше)
 		writeJSONWithCache(c, http.StatusOK, seats, "public, max-age=15", true)
 	}
 }
 
+// @Summary  Get full seat map with statuses
+// @Description Anonymous requests get domain.SeatWithStatus items. With a bearer token, each seat also carries `held_by_me`, marking the authenticated user's own holds.
+// @Param    id       path   int  true   "Event ID"
+// @Success  200  {array}   domain.UserSeat
+// @Failure  400  {object}  ErrorResponse
+// @Failure  401  {object}  ErrorResponse "invalid or expired token"
+// @Failure  404  {object}  ErrorResponse
+// @Router   /events/{id}/seatmap [get]
+func handleGetSeatMap(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		c.Writer.Header().Add("Vary", "Authorization")
+		if userID := c.GetInt64(userIDKey); userID != 0 {
+			seats, err := svcs.Query.GetSeatMapForUser(c.Request.Context(), eventID, userID)
+			if err != nil {
+				respondErr(c, err)
+				return
+			}
+			// Per-user view: revalidate every time, never store in shared caches.
+			writeJSONWithCache(c, http.StatusOK, seats, "private, no-cache", true)
+			return
+		}
+		seats, err := svcs.Query.GetSeatMap(c.Request.Context(), eventID)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		// ETag + Cache-Control 15s
+		writeJSONWithCache(c, http.StatusOK, seats, "public, max-age=15", true)
+	}
+}
+
+// @Summary  Check status of selected seats
+// @Param    id  path  int  true  "Event ID"
+// @Param    req body  SeatStatusesRequest true "payload"
+// @Success  200 {object} SeatStatusesResponse
+// @Failure  400 {object} ErrorResponse
+// @Router   /events/{id}/seats/status [post]
+func handleSeatStatuses(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		var req SeatStatusesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		statuses, err := svcs.Query.SeatStatuses(
+			c.Request.Context(),
+			eventID,
+			req.SeatIDs,
+		)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, SeatStatusesResponse{Statuses: statuses})
+	}
+}
+
 // @Summary  Create hold (idempotent)
+// @Description With `allow_partial`, the available seats are held and the rest are listed in `unavailable_seat_ids`.
 // @Param    id  path  int  true  "Event ID"
 // @Param    req body  CreateHoldRequest true "payload"
 // @Header   201 {string} Idempotency-Key "echo"
 // @Success  201 {object} CreateHoldResponse
 // @Failure  400 {object} ErrorResponse
-// @Failure  409 {object} ErrorResponse "seats unavailable / idem in progress"
+// @Failure  409 {object} ErrorResponse "seats unavailable / event sold out / seat limit exceeded / idem in progress"
+// @Failure  422 {object} ErrorResponse "seats are not contiguous / idempotency key reused"
 // @Failure  429 {object} ErrorResponse "rate limited"
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "user_id does not match the authenticated user"
 // @Router   /events/{id}/holds [post]
 func handleCreateHold(
 	svcs *service.Services,
@@ -168,220 +569,947 @@ func handleCreateHold(
 		}
 		var req CreateHoldRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			badRequest(c, err.Error())
+			bindError(c, err)
+			return
+		}
+		if req.UserID, ok = authenticatedUser(c, req.UserID); !ok {
 			return
 		}
 
-		idemKey := strings.TrimSpace(c.GetHeader("Idempotency-Key"))
-		var idemStorageKey string
-		if idem != nil && idemKey != "" {
-			idemStorageKey = redisrepo.KeyIdemHold(eventID, idemKey)
+		serveIdempotent(c, idem, func(idemKey string) string {
+			return redisrepo.KeyIdemHold(eventID, idemKey)
+		}, &req, http.StatusCreated, func() (any, bool) {
+			ttl := time.Duration(req.TTLSec) * time.Second
+			rlKey := rateLimitKey(c)
 
-			if payload, ok, _ := idem.GetResult(
+			holdID, seatIDs, unavailable, err := svcs.Reservation.CreateHold(
 				c.Request.Context(),
-				idemStorageKey,
-			); ok {
-				c.Header("Idempotency-Key", idemKey)
-				c.Data(
-					http.StatusCreated,
-					"application/json; charset=utf-8",
-					[]byte(payload),
-				)
-				return
+				req.UserID,
+				eventID,
+				req.SeatIDs,
+				req.SeatVersions,
+				ttl,
+				rlKey,
+				req.Contiguous,
+				req.AllowPartial,
+			)
+			if err != nil {
+				respondErr(c, err)
+				return nil, false
 			}
 
-			locked, err := idem.AcquireLock(
+			return CreateHoldResponse{
+				HoldID:             holdID.String(),
+				SeatIDs:            seatIDs,
+				UnavailableSeatIDs: unavailable,
+			}, true
+		})
+	}
+}
+
+// @Summary  Hold the best available seats (idempotent)
+// @Description Picks `count` available seats, preferring the lowest row and the centre of a row, and holds them.
+// @Param    id  path  int  true  "Event ID"
+// @Param    req body  AutoHoldRequest true "payload"
+// @Header   201 {string} Idempotency-Key "echo"
+// @Success  201 {object} AutoHoldResponse
+// @Failure  400 {object} ErrorResponse
+// @Failure  409 {object} ErrorResponse "not enough seats available / event sold out / seat limit exceeded / idem in progress"
+// @Failure  422 {object} ErrorResponse "idempotency key reused"
+// @Failure  429 {object} ErrorResponse "rate limited"
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "user_id does not match the authenticated user"
+// @Router   /events/{id}/holds/auto [post]
+func handleAutoHold(
+	svcs *service.Services,
+	idem *redisrepo.IdempotencyStore,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		var req AutoHoldRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		if req.UserID, ok = authenticatedUser(c, req.UserID); !ok {
+			return
+		}
+
+		serveIdempotent(c, idem, func(idemKey string) string {
+			return redisrepo.KeyIdemAutoHold(eventID, idemKey)
+		}, &req, http.StatusCreated, func() (any, bool) {
+			ttl := time.Duration(req.TTLSec) * time.Second
+			rlKey := rateLimitKey(c)
+
+			holdID, seatIDs, err := svcs.Reservation.SuggestAndHold(
 				c.Request.Context(),
-				idemStorageKey,
-				60*time.Second,
+				req.UserID,
+				eventID,
+				req.Count,
+				ttl,
+				rlKey,
 			)
 			if err != nil {
 				respondErr(c, err)
-				return
-			}
-			if !locked {
-				if payload, ok, _ := idem.GetResult(
-					c.Request.Context(),
-					idemStorageKey,
-				); ok {
-					c.Header("Idempotency-Key", idemKey)
-					c.Data(
-						http.StatusCreated,
-						"application/json; charset=utf-8",
-						[]byte(payload),
-					)
-					return
-				}
-				c.Header("Retry-After", "1")
-				c.JSON(
-					http.StatusConflict,
-					ErrorResponse{Error: "idempotency key in progress"},
-				)
-				return
+				return nil, false
 			}
-		}
 
-		ttl := time.Duration(req.TTLSec) * time.Second
-		rlKey := "ip:" + c.ClientIP()
+			return AutoHoldResponse{HoldID: holdID.String(), SeatIDs: seatIDs}, true
+		})
+	}
+}
 
-		holdID, err := svcs.Reservation.CreateHold(
-			c.Request.Context(),
-			req.UserID,
-			eventID,
-			req.SeatIDs,
-			ttl,
-			rlKey,
-		)
-		if err != nil {
-			if idemStorageKey != "" && idem != nil {
-				_ = idem.Release(c.Request.Context(), idemStorageKey)
-			}
-			if isRateLimitedErr(err) {
-				c.Header("Retry-After", "60")
-				c.JSON(
-					http.StatusTooManyRequests,
-					ErrorResponse{Error: err.Error()},
-				)
-				return
-			}
-			respondErr(c, err)
+// @Summary  Hold general-admission tickets (idempotent)
+// @Description Holds `qty` tickets from the event's general-admission pool, which has no assigned seats.
+// @Param    id  path  int  true  "Event ID"
+// @Param    req body  GAHoldRequest true "payload"
+// @Header   201 {string} Idempotency-Key "echo"
+// @Success  201 {object} GAHoldResponse
+// @Failure  400 {object} ErrorResponse
+// @Failure  409 {object} ErrorResponse "not enough general admission tickets / seat limit exceeded / idem in progress"
+// @Failure  422 {object} ErrorResponse "idempotency key reused"
+// @Failure  429 {object} ErrorResponse "rate limited"
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "user_id does not match the authenticated user"
+// @Router   /events/{id}/holds/ga [post]
+func handleGAHold(
+	svcs *service.Services,
+	idem *redisrepo.IdempotencyStore,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		var req GAHoldRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		if req.UserID, ok = authenticatedUser(c, req.UserID); !ok {
 			return
 		}
 
-		resp := CreateHoldResponse{HoldID: holdID.String()}
+		serveIdempotent(c, idem, func(idemKey string) string {
+			return redisrepo.KeyIdemGAHold(eventID, idemKey)
+		}, &req, http.StatusCreated, func() (any, bool) {
+			ttl := time.Duration(req.TTLSec) * time.Second
+			rlKey := rateLimitKey(c)
 
-		if idemStorageKey != "" && idem != nil {
-			b, _ := json.Marshal(resp)
-			_ = idem.SaveResult(c.Request.Context(), idemStorageKey, string(b))
-			c.Header("Idempotency-Key", idemKey)
-		}
+			holdID, err := svcs.Reservation.CreateGAHold(
+				c.Request.Context(),
+				req.UserID,
+				eventID,
+				req.Qty,
+				ttl,
+				rlKey,
+			)
+			if err != nil {
+				respondErr(c, err)
+				return nil, false
+			}
 
-		c.JSON(http.StatusCreated, resp)
+			return GAHoldResponse{HoldID: holdID.String(), Qty: req.Qty}, true
+		})
 	}
 }
 
-// @Summary  Confirm order
-// @Param    req body  ConfirmOrderRequest true "payload"
-// @Success  201 {object} ConfirmOrderResponse
-// @Failure  409 {object} ErrorResponse
-// @Router   /orders/confirm [post]
-func handleConfirmOrder(svcs *service.Services) gin.HandlerFunc {
+// @Summary  Join event waitlist (idempotent per user)
+// @Param    id  path  int  true  "Event ID"
+// @Param    req body  JoinWaitlistRequest true "payload"
+// @Success  201 {object} domain.WaitlistEntry
+// @Failure  400 {object} ErrorResponse
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "user_id does not match the authenticated user"
+// @Failure  404 {object} ErrorResponse
+// @Router   /events/{id}/waitlist [post]
+func handleJoinWaitlist(svcs *service.Services) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req ConfirmOrderRequest
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		var req JoinWaitlistRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			badRequest(c, err.Error())
+			bindError(c, err)
 			return
 		}
-		hid, err := uuid.Parse(req.HoldID)
-		if err != nil {
-			badRequest(c, "invalid hold_id")
+		if req.UserID, ok = authenticatedUser(c, req.UserID); !ok {
 			return
 		}
-		orderID, eventID, err := svcs.Reservation.Confirm(
+		e, err := svcs.Reservation.JoinWaitlist(
 			c.Request.Context(),
-			hid,
-			req.TotalCents,
+			eventID,
+			req.UserID,
+			req.SeatCount,
 		)
 		if err != nil {
 			respondErr(c, err)
 			return
 		}
-		c.JSON(http.StatusCreated, ConfirmOrderResponse{
-			OrderID: orderID.String(),
-			EventID: eventID,
-		})
+		c.JSON(http.StatusCreated, e)
 	}
 }
 
-// @Summary  Get order with tickets
-// @Param    id  path  string  true  "Order ID (uuid)"
-// @Success  200 {object} domain.OrderWithTickets
-// @Router   /orders/{id} [get]
-func handleGetOrder(svcs *service.Services) gin.HandlerFunc {
+// @Summary  Get hold status
+// @Param    id  path  string  true  "Hold ID (uuid)"
+// @Success  200 {object} HoldStatusResponse
+// @Failure  400 {object} ErrorResponse
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "hold belongs to another user"
+// @Failure  404 {object} ErrorResponse
+// @Router   /holds/{id} [get]
+func handleGetHold(svcs *service.Services) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		orderID := c.Param("id")
-		o, err := svcs.Orders.GetOrderWithTickets(
-			c.Request.Context(),
-			orderID,
-		)
+		holdID, ok := parseUUIDParam(c, "id")
+		if !ok {
+			return
+		}
+		userID, ok := authenticatedUser(c, 0)
+		if !ok {
+			return
+		}
+		h, err := svcs.Reservation.GetHold(c.Request.Context(), holdID, userID)
 		if err != nil {
 			respondErr(c, err)
 			return
 		}
-		c.JSON(http.StatusOK, o)
+		seatIDs := h.SeatIDs
+		if seatIDs == nil {
+			seatIDs = []int64{}
+		}
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, HoldStatusResponse{
+			HoldID:          h.ID.String(),
+			EventID:         h.EventID,
+			UserID:          h.UserID,
+			CreatedAt:       h.CreatedAt,
+			ExpiresAt:       h.ExpiresAt,
+			TTLRemainingSec: ttlRemainingSec(h.ExpiresAt, time.Now()),
+			SeatIDs:         seatIDs,
+			GAQty:           h.GAQty,
+		})
+	}
+}
+
+// @Summary  Cancel hold
+// @Param    id  path  string  true  "Hold ID (uuid)"
+// @Success  204
+// @Failure  400 {object} ErrorResponse
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "hold belongs to another user"
+// @Failure  404 {object} ErrorResponse
+// @Router   /holds/{id} [delete]
+func handleCancelHold(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		holdID, ok := parseUUIDParam(c, "id")
+		if !ok {
+			return
+		}
+		userID, ok := authenticatedUser(c, 0)
+		if !ok {
+			return
+		}
+		if _, err := svcs.Reservation.Cancel(c.Request.Context(), holdID, userID); err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// @Summary  Extend hold
+// @Param    id  path  string  true  "Hold ID (uuid)"
+// @Param    req body  ExtendHoldRequest true "payload"
+// @Success  200 {object} ExtendHoldResponse
+// @Failure  400 {object} ErrorResponse
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "hold belongs to another user"
+// @Failure  404 {object} ErrorResponse
+// @Failure  409 {object} ErrorResponse "hold expired"
+// @Router   /holds/{id}/extend [post]
+func handleExtendHold(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		holdID, ok := parseUUIDParam(c, "id")
+		if !ok {
+			return
+		}
+		var req ExtendHoldRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		userID, ok := authenticatedUser(c, 0)
+		if !ok {
+			return
+		}
+		expiresAt, err := svcs.Reservation.ExtendHold(
+			c.Request.Context(),
+			holdID,
+			userID,
+			time.Duration(req.ExtraSec)*time.Second,
+		)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, ExtendHoldResponse{
+			HoldID:          holdID.String(),
+			ExpiresAt:       expiresAt,
+			TTLRemainingSec: ttlRemainingSec(expiresAt, time.Now()),
+		})
+	}
+}
+
+// @Summary  Confirm order (idempotent)
+// @Param    req body  ConfirmOrderRequest true "payload"
+// @Header   201 {string} Idempotency-Key "echo"
+// @Success  201 {object} ConfirmOrderResponse
+// @Failure  409 {object} ErrorResponse "hold expired / hold already confirmed / nothing to confirm / idem in progress"
+// @Failure  422 {object} ErrorResponse "idempotency key reused"
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "user_id does not match the authenticated user / hold belongs to another user"
+// @Router   /orders/confirm [post]
+func handleConfirmOrder(
+	svcs *service.Services,
+	idem *redisrepo.IdempotencyStore,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ConfirmOrderRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		hid, err := uuid.Parse(req.HoldID)
+		if err != nil {
+			badRequest(c, "invalid hold_id")
+			return
+		}
+		var ok bool
+		if req.UserID, ok = authenticatedUser(c, req.UserID); !ok {
+			return
+		}
+
+		serveIdempotent(c, idem, func(idemKey string) string {
+			return redisrepo.KeyIdemConfirm(hid.String(), idemKey)
+		}, &req, http.StatusCreated, func() (any, bool) {
+			orderID, eventID, err := svcs.Reservation.Confirm(c.Request.Context(), hid, req.UserID)
+			if err != nil {
+				respondErr(c, err)
+				return nil, false
+			}
+			return ConfirmOrderResponse{
+				OrderID: orderID.String(),
+				EventID: eventID,
+			}, true
+		})
+	}
+}
+
+// @Summary  Get ticket with its signature
+// @Param    id  path  string  true  "Ticket ID (uuid)"
+// @Success  200 {object} TicketResponse
+// @Failure  400 {object} ErrorResponse
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "ticket of another user"
+// @Failure  404 {object} ErrorResponse
+// @Failure  503 {object} ErrorResponse "ticket signing not configured"
+// @Router   /tickets/{id} [get]
+func handleGetTicket(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := authenticatedUser(c, 0)
+		if !ok {
+			return
+		}
+		ticketID, ok := parseUUIDParam(c, "id")
+		if !ok {
+			return
+		}
+		t, sig, err := svcs.Orders.GetTicket(c.Request.Context(), ticketID, userID)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, TicketResponse{Ticket: *t, Signature: sig})
+	}
+}
+
+// @Summary  Get ticket QR code
+// @Description Returns a QR code encoding the signed ticket token. Sends a PNG when the client accepts `image/png`, otherwise JSON with the token and a base64 PNG.
+// @Produce  json,png
+// @Param    id  path  string  true  "Ticket ID (uuid)"
+// @Success  200 {object} TicketQRResponse
+// @Failure  400 {object} ErrorResponse
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "ticket of another user"
+// @Failure  404 {object} ErrorResponse
+// @Failure  503 {object} ErrorResponse "ticket signing not configured"
+// @Router   /tickets/{id}/qr [get]
+func handleTicketQR(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := authenticatedUser(c, 0)
+		if !ok {
+			return
+		}
+		ticketID, ok := parseUUIDParam(c, "id")
+		if !ok {
+			return
+		}
+		token, err := svcs.Orders.GetTicketToken(c.Request.Context(), ticketID, userID)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		png, err := ticket.RenderQR(token)
+		if err != nil {
+			writeError(c, http.StatusInternalServerError, ErrorResponse{Error: "failed to render qr code"})
+			return
+		}
+		if c.NegotiateFormat(gin.MIMEJSON, "image/png") == "image/png" {
+			c.Data(http.StatusOK, "image/png", png)
+			return
+		}
+		c.JSON(http.StatusOK, TicketQRResponse{
+			Token: token,
+			PNG:   base64.StdEncoding.EncodeToString(png),
+		})
+	}
+}
+
+// @Summary  Verify a ticket signature
+// @Description For entry gates: requires an API key or the admin role.
+// @Param    id         path   string  true  "Ticket ID (uuid)"
+// @Param    signature  query  string  true  "Signature from GET /tickets/{id}"
+// @Success  200 {object} TicketVerifyResponse
+// @Failure  400 {object} ErrorResponse
+// @Failure  401 {object} ErrorResponse "not a gate client"
+// @Failure  404 {object} ErrorResponse
+// @Failure  503 {object} ErrorResponse "ticket signing not configured"
+// @Router   /tickets/{id}/verify [get]
+func handleVerifyTicket(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ticketID, ok := parseUUIDParam(c, "id")
+		if !ok {
+			return
+		}
+		sig := c.Query("signature")
+		if sig == "" {
+			badRequest(c, "signature is required")
+			return
+		}
+		valid, err := svcs.Orders.VerifyTicket(c.Request.Context(), ticketID, sig)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, TicketVerifyResponse{Valid: valid})
+	}
+}
+
+// @Summary  Verify a scanned ticket token
+// @Description Checks a token read from a ticket QR code. For entry gates: requires an API key with the write scope or the admin role. Tokens of refunded tickets get 404.
+// @Param    req  body  VerifyTicketTokenRequest  true  "payload"
+// @Success  200 {object} VerifyTicketTokenResponse
+// @Failure  400 {object} ErrorResponse
+// @Failure  401 {object} ErrorResponse "not a gate client"
+// @Failure  404 {object} ErrorResponse
+// @Failure  503 {object} ErrorResponse "ticket signing not configured"
+// @Router   /tickets/verify [post]
+func handleVerifyTicketToken(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req VerifyTicketTokenRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		t, valid, err := svcs.Orders.VerifyTicketToken(c.Request.Context(), req.Token)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, VerifyTicketTokenResponse{Valid: valid, Ticket: t})
+	}
+}
+
+// @Summary  Get tickets of several orders
+// @Description Tickets are keyed by order ID; unknown orders, orders of other users and orders without tickets are omitted.
+// @Param    req  body  OrderTicketsRequest  true  "payload"
+// @Success  200  {object}  OrderTicketsResponse
+// @Failure  400  {object}  ErrorResponse
+// @Failure  401  {object}  ErrorResponse "not authenticated"
+// @Failure  429  {object}  ErrorResponse "rate limited"
+// @Router   /orders/tickets [post]
+func handleOrderTickets(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := authenticatedUser(c, 0)
+		if !ok {
+			return
+		}
+		var req OrderTicketsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		orderIDs := make([]uuid.UUID, 0, len(req.OrderIDs))
+		for i, raw := range req.OrderIDs {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				badRequest(c, fmt.Sprintf("invalid order_ids[%d]", i))
+				return
+			}
+			orderIDs = append(orderIDs, id)
+		}
+		tickets, err := svcs.Query.TicketsByOrders(c.Request.Context(), orderIDs, userID)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, OrderTicketsResponse{Tickets: tickets})
+	}
+}
+
+// @Summary  List orders by creation time
+// @Description Lists all orders created within [from, to). The range may span at most 31 days.
+// @Param    from    query  string  true   "created at or after (RFC3339)"
+// @Param    to      query  string  true   "created before (RFC3339)"
+// @Param    sort    query  string  false  "asc or desc (default)"
+// @Param    limit   query  int     false  "page size"
+// @Param    offset  query  int     false  "offset"
+// @Success  200  {array}   domain.Order
+// @Failure  400  {object}  ErrorResponse
+// @Router   /admin/orders [get]
+func handleListOrders(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		from, ok := parseOptionalRFC3339(c, "from")
+		if !ok {
+			return
+		}
+		to, ok := parseOptionalRFC3339(c, "to")
+		if !ok {
+			return
+		}
+		if from == nil || to == nil {
+			badRequest(c, "from and to are required")
+			return
+		}
+		var desc bool
+		switch c.DefaultQuery("sort", "desc") {
+		case "desc":
+			desc = true
+		case "asc":
+		default:
+			badRequest(c, "invalid sort (asc or desc)")
+			return
+		}
+		limit := parseIntDefault(c.Query("limit"), 0)
+		offset := parseIntDefault(c.Query("offset"), 0)
+
+		orders, pageSize, err := svcs.Query.ListOrders(
+			c.Request.Context(),
+			*from,
+			*to,
+			desc,
+			limit,
+			offset,
+		)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		setPageLinks(c, pageSize, offset, len(orders))
+		c.JSON(http.StatusOK, orders)
+	}
+}
+
+// @Summary  Get order with tickets
+// @Param    id  path  string  true  "Order ID (uuid)"
+// @Success  200 {object} domain.OrderWithTickets
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "order belongs to another user"
+// @Failure  404 {object} ErrorResponse
+// @Router   /orders/{id} [get]
+func handleGetOrder(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		orderID := c.Param("id")
+		userID, ok := authenticatedUser(c, 0)
+		if !ok {
+			return
+		}
+		o, err := svcs.Orders.GetOrderWithTickets(
+			c.Request.Context(),
+			orderID,
+			userID,
+		)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, o)
+	}
+}
+
+// @Summary  Refund order
+// @Description Releases the order's seats, deletes its tickets and marks it refunded.
+// @Param    id  path  string  true  "Order ID (uuid)"
+// @Success  200 {object} RefundOrderResponse
+// @Failure  400 {object} ErrorResponse
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "order belongs to another user"
+// @Failure  404 {object} ErrorResponse
+// @Failure  409 {object} ErrorResponse "already refunded"
+// @Router   /orders/{id}/refund [post]
+func handleRefundOrder(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		orderID, ok := parseUUIDParam(c, "id")
+		if !ok {
+			return
+		}
+		userID, ok := authenticatedUser(c, 0)
+		if !ok {
+			return
+		}
+		released, err := svcs.Orders.RefundOrder(c.Request.Context(), orderID, userID)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, RefundOrderResponse{ReleasedSeats: released})
+	}
+}
+
+// @Summary  List user orders
+// @Param    id     path   int  true  "User ID"
+// @Param    limit  query  int  false "page size"
+// @Param    offset query  int  false "offset"
+// @Success  200 {array} domain.Order
+// @Failure  401 {object} ErrorResponse "not authenticated"
+// @Failure  403 {object} ErrorResponse "user_id does not match the authenticated user"
+// @Router   /users/{id}/orders [get]
+func handleListUserOrders(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pathUserID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		// Users may only list their own orders.
+		userID, ok := authenticatedUser(c, pathUserID)
+		if !ok {
+			return
+		}
+		limit := parseIntDefault(c.Query("limit"), 0)
+		offset := parseIntDefault(c.Query("offset"), 0)
+
+		orders, err := svcs.Query.ListOrdersByUser(
+			c.Request.Context(),
+			userID,
+			limit,
+			offset,
+		)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, orders)
+	}
+}
+
+// @Summary  List venues
+// @Param    limit  query  int  false "page size"
+// @Param    offset query  int  false "offset"
+// @Success  200 {array} domain.Venue
+// @Router   /admin/venues [get]
+func handleListVenues(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := parseIntDefault(c.Query("limit"), 0)
+		offset := parseIntDefault(c.Query("offset"), 0)
+
+		venues, err := svcs.Query.ListVenues(c.Request.Context(), limit, offset)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, venues)
+	}
+}
+
+// @Summary  Create venue
+// @Param    req body  CreateVenueRequest true "payload"
+// @Success  201 {object} CreateVenueResponse
+// @Failure  400 {object} ErrorResponse "invalid seating scheme"
+// @Router   /admin/venues [post]
+func handleCreateVenue(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req CreateVenueRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		id, err := svcs.Admin.CreateVenue(
+			c.Request.Context(),
+			req.Name,
+			req.SeatingScheme,
+		)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, CreateVenueResponse{VenueID: id})
+	}
+}
+
+// @Summary  Batch create seats
+// @Description Seats that already exist are skipped and not counted in `created`. Send an `Idempotency-Key` header to get the original response back when retrying.
+// @Param    id  path  int  true  "Venue ID"
+// @Param    Idempotency-Key header string false "Idempotency key"
+// @Param    req body  BatchCreateSeatsRequest true "payload"
+// @Success  201 {object} BatchCreateSeatsResponse
+// @Failure  400 {object} ErrorResponse "invalid payload / too many seats"
+// @Failure  409 {object} ErrorResponse "idem in progress"
+// @Failure  413 {object} ErrorResponse "request body too large"
+// @Failure  422 {object} ErrorResponse "idempotency key reused with a different request"
+// @Router   /admin/venues/{id}/seats [post]
+func handleBatchCreateSeats(svcs *service.Services, idem *redisrepo.IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		venueID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		var req BatchCreateSeatsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+
+		serveIdempotent(c, idem, func(idemKey string) string {
+			return redisrepo.KeyIdemBatchSeats(venueID, idemKey)
+		}, &req, http.StatusCreated, func() (any, bool) {
+			seats := make([]domain.Seat, 0, len(req.Seats))
+			for _, s := range req.Seats {
+				seats = append(seats, domain.Seat{
+					VenueID: venueID,
+					Section: s.Section,
+					Row:     s.Row,
+					Number:  s.Number,
+				})
+			}
+			created, err := svcs.Admin.BatchCreateSeats(
+				c.Request.Context(),
+				venueID,
+				seats,
+			)
+			if err != nil {
+				respondErr(c, err)
+				return nil, false
+			}
+			return BatchCreateSeatsResponse{Created: created}, true
+		})
+	}
+}
+
+// @Summary  Generate seats from the venue's seating scheme
+// @Description Creates every seat described by the stored scheme; existing seats are skipped.
+// @Param    id  path  int  true  "Venue ID"
+// @Success  201 {object} map[string]int64 "created"
+// @Failure  400 {object} ErrorResponse "invalid seating scheme"
+// @Failure  404 {object} ErrorResponse
+// @Router   /admin/venues/{id}/seats/generate [post]
+func handleGenerateSeats(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		venueID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		created, err := svcs.Admin.GenerateSeatsFromScheme(c.Request.Context(), venueID)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"created": created})
+	}
+}
+
+// @Summary  Create event and init seats
+// @Param    req body  CreateEventRequest true "payload"
+// @Param    dry_run query bool false "validate only; responds 204 without creating the event"
+// @Success  201 {object} CreateEventResponse
+// @Success  204 "dry run passed"
+// @Failure  404 {object} ErrorResponse
+// @Failure  409 {object} ErrorResponse "venue has no seats"
+// @Router   /admin/events [post]
+func handleCreateEvent(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dryRun := c.Query("dry_run") == "true"
+		var req CreateEventRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		starts, err := parseRFC3339(req.StartsAt)
+		if err != nil {
+			badRequest(c, "invalid starts_at (RFC3339)")
+			return
+		}
+		ends, err := parseRFC3339(req.EndsAt)
+		if err != nil {
+			badRequest(c, "invalid ends_at (RFC3339)")
+			return
+		}
+		id, err := svcs.Admin.CreateEventWithInit(
+			c.Request.Context(),
+			req.VenueID,
+			req.Title,
+			starts,
+			ends,
+			req.PriceCents,
+			dryRun,
+		)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		if dryRun {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		c.JSON(http.StatusCreated, CreateEventResponse{EventID: id})
 	}
 }
 
-// @Summary  Create venue
-// @Param    req body  CreateVenueRequest true "payload"
-// @Success  201 {object} CreateVenueResponse
-// @Router   /admin/venues [post]
-func handleCreateVenue(svcs *service.Services) gin.HandlerFunc {
+// @Summary  Create recurring events and init seats
+// @Description Creates one event per time slot. Either all events are created or none.
+// @Param    req body  CreateRecurringEventsRequest true "payload"
+// @Success  201 {object} CreateRecurringEventsResponse
+// @Failure  400 {object} ErrorResponse
+// @Failure  404 {object} ErrorResponse
+// @Failure  409 {object} ErrorResponse
+// @Router   /admin/events/recurring [post]
+func handleCreateRecurringEvents(svcs *service.Services) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req CreateVenueRequest
+		var req CreateRecurringEventsRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			badRequest(c, err.Error())
+			bindError(c, err)
 			return
 		}
-		id, err := svcs.Admin.CreateVenue(
+		slots := make([]admin.TimeSlot, 0, len(req.Slots))
+		for i, in := range req.Slots {
+			starts, err := parseRFC3339(in.StartsAt)
+			if err != nil {
+				badRequest(c, fmt.Sprintf("invalid slots[%d].starts_at (RFC3339)", i))
+				return
+			}
+			ends, err := parseRFC3339(in.EndsAt)
+			if err != nil {
+				badRequest(c, fmt.Sprintf("invalid slots[%d].ends_at (RFC3339)", i))
+				return
+			}
+			slots = append(slots, admin.TimeSlot{StartsAt: starts, EndsAt: ends})
+		}
+		ids, err := svcs.Admin.CreateRecurringEvents(
 			c.Request.Context(),
-			req.Name,
-			req.SeatingScheme,
+			req.VenueID,
+			req.Title,
+			slots,
+			req.PriceCents,
 		)
 		if err != nil {
 			respondErr(c, err)
 			return
 		}
-		c.JSON(http.StatusCreated, CreateVenueResponse{VenueID: id})
+		c.JSON(http.StatusCreated, CreateRecurringEventsResponse{EventIDs: ids})
 	}
 }
 
-// @Summary  Batch create seats
+// @Summary  Update venue
 // @Param    id  path  int  true  "Venue ID"
-// @Param    req body  BatchCreateSeatsRequest true "payload"
-// @Success  201 {object} map[string]int
-// @Router   /admin/venues/{id}/seats [post]
-func handleBatchCreateSeats(svcs *service.Services) gin.HandlerFunc {
+// @Param    req body  UpdateVenueRequest true "payload"
+// @Param    If-Match header string false "ETag from GET /venues/{id}"
+// @Success  200 {object} domain.Venue
+// @Failure  400 {object} ErrorResponse
+// @Failure  404 {object} ErrorResponse
+// @Failure  409 {object} ErrorResponse
+// @Failure  412 {object} ErrorResponse "venue changed since the ETag was issued"
+// @Router   /admin/venues/{id} [put]
+func handleUpdateVenue(svcs *service.Services) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		venueID, ok := parseInt64Param(c, "id")
 		if !ok {
 			return
 		}
-		var req BatchCreateSeatsRequest
+		var req UpdateVenueRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			badRequest(c, err.Error())
+			bindError(c, err)
 			return
 		}
-		var seats []domain.Seat
-		for _, s := range req.Seats {
-			seats = append(seats, domain.Seat{
-				VenueID: venueID,
-				Section: s.Section,
-				Row:     s.Row,
-				Number:  s.Number,
-			})
-		}
-		if err := svcs.Admin.BatchCreateSeats(
+		v, err := svcs.Admin.UpdateVenue(
 			c.Request.Context(),
 			venueID,
-			seats,
-		); err != nil {
+			req.Name,
+			req.SeatingScheme,
+			ifMatch[*domain.Venue](c),
+		)
+		if err != nil {
 			respondErr(c, err)
 			return
 		}
-		c.JSON(http.StatusCreated, gin.H{"created": len(seats)})
+		writeJSONWithETag(c, http.StatusOK, v)
 	}
 }
 
-// @Summary  Create event and init seats
-// @Param    req body  CreateEventRequest true "payload"
-// @Success  201 {object} CreateEventResponse
-// @Router   /admin/events [post]
-func handleCreateEvent(svcs *service.Services) gin.HandlerFunc {
+// @Summary  Move a seat to another section, row or number
+// @Param    id  path  int  true  "Seat ID"
+// @Param    req body  UpdateSeatRequest true "payload"
+// @Success  200 {object} domain.Seat
+// @Failure  400 {object} ErrorResponse
+// @Failure  404 {object} ErrorResponse
+// @Failure  409 {object} ErrorResponse "another seat already has this position, or the seat is held or sold for an upcoming event"
+// @Router   /admin/seats/{id} [patch]
+func handleUpdateSeat(svcs *service.Services) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req CreateEventRequest
+		seatID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		var req UpdateSeatRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		seat, err := svcs.Admin.UpdateSeat(
+			c.Request.Context(),
+			seatID,
+			req.Section,
+			req.Row,
+			req.Number,
+		)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, seat)
+	}
+}
+
+// @Summary  Update (reschedule) event
+// @Param    id  path  int  true  "Event ID"
+// @Param    req body  UpdateEventRequest true "payload"
+// @Param    If-Match header string false "ETag from GET /events/{id}"
+// @Success  200 {object} domain.Event
+// @Failure  400 {object} ErrorResponse
+// @Failure  404 {object} ErrorResponse
+// @Failure  412 {object} ErrorResponse "event changed since the ETag was issued"
+// @Router   /admin/events/{id} [put]
+func handleUpdateEvent(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		var req UpdateEventRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			badRequest(c, err.Error())
+			bindError(c, err)
 			return
 		}
 		starts, err := parseRFC3339(req.StartsAt)
@@ -394,18 +1522,228 @@ func handleCreateEvent(svcs *service.Services) gin.HandlerFunc {
 			badRequest(c, "invalid ends_at (RFC3339)")
 			return
 		}
-		id, err := svcs.Admin.CreateEventWithInit(
+		e, err := svcs.Admin.UpdateEvent(
 			c.Request.Context(),
-			req.VenueID,
+			eventID,
 			req.Title,
 			starts,
 			ends,
+			ifMatch[*domain.Event](c),
 		)
 		if err != nil {
 			respondErr(c, err)
 			return
 		}
-		c.JSON(http.StatusCreated, CreateEventResponse{EventID: id})
+		writeJSONWithETag(c, http.StatusOK, e)
+	}
+}
+
+// @Summary  Cancel event and release its holds
+// @Param    id  path  int  true  "Event ID"
+// @Success  200 {object} domain.EventCancellation
+// @Failure  404 {object} ErrorResponse
+// @Failure  409 {object} ErrorResponse "already cancelled"
+// @Router   /admin/events/{id}/cancel [post]
+func handleCancelEvent(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		res, err := svcs.Admin.CancelEvent(c.Request.Context(), eventID)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, res)
+	}
+}
+
+// @Summary  Set general-admission capacity of an event
+// @Param    id   path  int  true  "Event ID"
+// @Param    req  body  SetGACapacityRequest true "payload"
+// @Success  200 {object} domain.GACapacity
+// @Failure  400 {object} ErrorResponse
+// @Failure  404 {object} ErrorResponse
+// @Failure  409 {object} ErrorResponse "capacity below tickets held and sold"
+// @Router   /admin/events/{id}/ga [put]
+func handleSetGACapacity(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		var req SetGACapacityRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+
+		ga, err := svcs.Admin.SetGACapacity(c.Request.Context(), eventID, req.Capacity, req.PriceCents)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, ga)
+	}
+}
+
+// @Summary  List active holds of an event
+// @Param    id     path   int  true  "Event ID"
+// @Param    limit  query  int  false "page size"
+// @Param    offset query  int  false "offset"
+// @Success  200 {array} domain.HoldSummary
+// @Failure  404 {object} ErrorResponse
+// @Router   /admin/events/{id}/holds [get]
+func handleListActiveHolds(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		limit := parseIntDefault(c.Query("limit"), 0)
+		offset := parseIntDefault(c.Query("offset"), 0)
+
+		holds, err := svcs.Query.ListActiveHolds(c.Request.Context(), eventID, limit, offset)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, holds)
+	}
+}
+
+// @Summary  Get gross sales of an event
+// @Description Aggregates confirmed orders; refunded orders are not counted. Events without orders report zeros.
+// @Param    id  path  int  true  "Event ID"
+// @Success  200 {object} EventRevenueResponse
+// @Failure  404 {object} ErrorResponse
+// @Router   /admin/events/{id}/revenue [get]
+func handleEventRevenue(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		rev, err := svcs.Admin.EventRevenue(c.Request.Context(), eventID)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, EventRevenueResponse{
+			OrdersCount: rev.OrdersCount,
+			TicketsSold: rev.TicketsSold,
+			TotalCents:  rev.TotalCents,
+		})
+	}
+}
+
+// @Summary  Issue an API key
+// @Description Issues a key for a server-to-server client, sent in the `X-API-Key` header. Scope `read` allows GET requests, `write` the others. The key is only returned here.
+// @Param    req body  CreateAPIKeyRequest true "payload"
+// @Success  201 {object} CreateAPIKeyResponse
+// @Failure  400 {object} ErrorResponse
+// @Router   /admin/api-keys [post]
+func handleCreateAPIKey(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req CreateAPIKeyRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			bindError(c, err)
+			return
+		}
+		scopes := make([]domain.APIKeyScope, len(req.Scopes))
+		for i, sc := range req.Scopes {
+			scopes[i] = domain.APIKeyScope(sc)
+		}
+
+		k, key, err := svcs.Admin.CreateAPIKey(c.Request.Context(), req.ClientName, scopes)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, CreateAPIKeyResponse{
+			ID:         k.ID,
+			Key:        key,
+			ClientName: k.ClientName,
+			Scopes:     k.Scopes,
+			CreatedAt:  k.CreatedAt,
+		})
+	}
+}
+
+// @Summary  Revoke an API key
+// @Param    id  path  int  true  "API key ID"
+// @Success  204
+// @Failure  404 {object} ErrorResponse "not found or already revoked"
+// @Router   /admin/api-keys/{id} [delete]
+func handleRevokeAPIKey(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		if err := svcs.Admin.RevokeAPIKey(c.Request.Context(), id); err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// @Summary  List the hold lifecycle log of an event
+// @Description Entries record holds being created, confirmed, cancelled and expired, oldest first.
+// @Param    id     path   int  true  "Event ID"
+// @Param    limit  query  int  false "page size"
+// @Param    offset query  int  false "offset"
+// @Success  200 {array} domain.EventLogEntry
+// @Failure  404 {object} ErrorResponse
+// @Router   /admin/events/{id}/log [get]
+func handleListEventLog(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+		limit := parseIntDefault(c.Query("limit"), 0)
+		offset := parseIntDefault(c.Query("offset"), 0)
+
+		entries, err := svcs.Query.ListEventLog(c.Request.Context(), eventID, limit, offset)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, entries)
+	}
+}
+
+// @Summary  Expire holds that exceeded their TTL
+// @Description Releases seats of expired holds. Pass `event_id` to limit expiry to one event; omit the body to expire holds of every event.
+// @Param    req body  ExpireHoldsRequest false "payload"
+// @Success  200 {object} ExpireHoldsResponse
+// @Failure  400 {object} ErrorResponse
+// @Router   /admin/holds/expire [post]
+func handleExpireHolds(svcs *service.Services) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ExpireHoldsRequest
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			bindError(c, err)
+			return
+		}
+
+		var (
+			released int64
+			err      error
+		)
+		if req.EventID != nil {
+			released, err = svcs.Reservation.ExpireForEvent(c.Request.Context(), *req.EventID)
+		} else {
+			released, _, err = svcs.Reservation.Expire(c.Request.Context())
+		}
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, ExpireHoldsResponse{Released: released})
 	}
 }
 
@@ -421,6 +1759,31 @@ func parseInt64Param(c *gin.Context, name string) (int64, bool) {
 	return v, true
 }
 
+func parseUUIDParam(c *gin.Context, name string) (uuid.UUID, bool) {
+	v, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		badRequest(c, "invalid "+name)
+		return uuid.Nil, false
+	}
+	return v, true
+}
+
+// parseOptionalRFC3339 parses an optional RFC3339 query parameter. It
+// returns nil when the parameter is absent and writes a 400 when it is
+// malformed.
+func parseOptionalRFC3339(c *gin.Context, name string) (*time.Time, bool) {
+	v := c.Query(name)
+	if v == "" {
+		return nil, true
+	}
+	t, err := parseRFC3339(v)
+	if err != nil {
+		badRequest(c, "invalid "+name+" (RFC3339)")
+		return nil, false
+	}
+	return &t, true
+}
+
 func parseIntDefault(s string, def int) int {
 	if s == "" {
 		return def
@@ -433,11 +1796,18 @@ func parseIntDefault(s string, def int) int {
 }
 
 func badRequest(c *gin.Context, msg string) {
-	c.JSON(http.StatusBadRequest, ErrorResponse{Error: msg})
+	writeError(c, http.StatusBadRequest, ErrorResponse{Error: msg})
 }
 
-func isRateLimitedErr(err error) bool {
-	return err != nil && strings.Contains(err.Error(), "rate limited")
+// retryAfterSeconds formats d as a Retry-After value in whole seconds,
+// rounded up and never less than one.
+func retryAfterSeconds(d time.Duration) string {
+	secs := int64((d + time.Second - 1) / time.Second)
+	if secs < 1 {
+		secs = 1
+	}
+
+	return strconv.FormatInt(secs, 10)
 }
 
 func respondErr(c *gin.Context, err error) {
@@ -446,46 +1816,235 @@ func respondErr(c *gin.Context, err error) {
 		return
 	}
 
+	defer logRespondedErr(c, err)
+
 	switch {
 	// admin service
 	case errors.Is(err, admin.ErrEventConflict):
-		c.JSON(http.StatusConflict, ErrorResponse{Error: "event conflict"})
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "event conflict"})
 		return
-	case errors.Is(err, admin.ErrSeatsConflict):
-		c.JSON(http.StatusConflict, ErrorResponse{Error: "seats conflict"})
+	case errors.Is(err, admin.ErrTooManySeats):
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error: fmt.Sprintf("too many seats in one batch (max %d)", admin.MaxBatchSeats),
+		})
 		return
 	case errors.Is(err, admin.ErrVenueConflict):
-		c.JSON(http.StatusConflict, ErrorResponse{Error: "venue conflict"})
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "venue conflict"})
 		return
 	case errors.Is(err, admin.ErrFailedToInitEventSeats):
-		c.JSON(http.StatusNotFound, ErrorResponse{Error: "event or venue does not exist"})
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "event or venue does not exist"})
+		return
+	case errors.Is(err, admin.ErrEventNotFound):
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "event not found"})
+		return
+	case errors.Is(err, admin.ErrInvalidEventTime):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "event must end after it starts"})
+		return
+	case errors.Is(err, admin.ErrEventAlreadyCancelled):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "event already cancelled"})
+		return
+	case errors.Is(err, admin.ErrNoTimeSlots):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "at least one time slot is required"})
+		return
+	case errors.Is(err, admin.ErrTooManyEvents):
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error: fmt.Sprintf("too many events in one batch (max %d)", admin.MaxRecurringEvents),
+		})
+		return
+	case errors.Is(err, admin.ErrPreconditionFailed):
+		writeError(c, http.StatusPreconditionFailed, ErrorResponse{Error: "resource has changed"})
+		return
+	case errors.Is(err, admin.ErrVenueHasNoSeats):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "venue has no seats"})
+		return
+	case errors.Is(err, admin.ErrSeatNotFound):
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "seat not found"})
+		return
+	case errors.Is(err, admin.ErrSeatConflict):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "another seat already has this position"})
+		return
+	case errors.Is(err, admin.ErrInvalidSeatNumber):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "seat number must be positive"})
+		return
+	case errors.Is(err, admin.ErrSeatBooked):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "seat is held or sold for an upcoming event"})
+		return
+	case errors.Is(err, admin.ErrInvalidSeatRow):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "seat row must be a positive integer"})
+		return
+	case errors.Is(err, admin.ErrGACapacityTooLow):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "capacity below tickets held and sold"})
+		return
+	case errors.Is(err, admin.ErrVenueNotFound):
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "venue not found"})
+		return
+	case errors.Is(err, admin.ErrInvalidSeatingScheme):
+		resp := ErrorResponse{Error: "invalid seating scheme"}
+		var se admin.InvalidSeatingSchemeError
+		if errors.As(err, &se) {
+			resp.Fields = []FieldError{{Field: "seating_scheme", Reason: se.Reason}}
+		}
+		writeError(c, http.StatusBadRequest, resp)
 		return
 	// orders service
 	case errors.Is(err, orders.ErrOrderNotFound):
-		c.JSON(http.StatusNotFound, ErrorResponse{Error: "order not found"})
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "order not found"})
+		return
+	case errors.Is(err, orders.ErrOrderNotOwned):
+		writeError(c, http.StatusForbidden, ErrorResponse{Error: "order belongs to another user"})
+		return
+	case errors.Is(err, orders.ErrOrderAlreadyRefunded):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "order already refunded"})
+		return
+	case errors.Is(err, orders.ErrTicketNotFound):
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "ticket not found"})
+		return
+	case errors.Is(err, orders.ErrTicketNotOwned):
+		writeError(c, http.StatusForbidden, ErrorResponse{Error: "ticket belongs to another user"})
+		return
+	case errors.Is(err, orders.ErrTicketSigningDisabled):
+		writeError(c, http.StatusServiceUnavailable, ErrorResponse{Error: "ticket signing is not configured"})
 		return
 	// query service
 	case errors.Is(err, query.ErrEventNotFound):
-		c.JSON(http.StatusNotFound, ErrorResponse{Error: "event not found"})
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "event not found"})
 		return
 	case errors.Is(err, query.ErrOrderNotFound):
-		c.JSON(http.StatusNotFound, ErrorResponse{Error: "order not found"})
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "order not found"})
+		return
+	case errors.Is(err, query.ErrVenueNotFound):
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "venue not found"})
+		return
+	case errors.Is(err, query.ErrTooManyEvents):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "too many event ids"})
+		return
+	case errors.Is(err, query.ErrTooManyOrders):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "too many order ids"})
+		return
+	case errors.Is(err, query.ErrInvalidTimeRange):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "from must not be after to"})
+		return
+	case errors.Is(err, query.ErrTimeRangeTooWide):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "time range too wide"})
+		return
+	case errors.Is(err, query.ErrInvalidAPIKey):
+		writeError(c, http.StatusUnauthorized, ErrorResponse{Error: "invalid api key"})
+		return
+	case errors.Is(err, query.ErrAPIKeyRevoked):
+		writeError(c, http.StatusUnauthorized, ErrorResponse{Error: "api key revoked"})
+		return
+	case errors.Is(err, admin.ErrAPIKeyNotFound):
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "api key not found"})
+		return
+	case errors.Is(err, admin.ErrInvalidAPIKeyScope):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "invalid api key scope"})
+		return
+	case errors.Is(err, admin.ErrNoAPIKeyScopes):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "at least one api key scope is required"})
 		return
 	// reservation service
 	case errors.Is(err, reservation.ErrEventNotFound):
-		c.JSON(http.StatusNotFound, ErrorResponse{Error: "event not found"})
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "event not found"})
+		return
+	case errors.Is(err, reservation.ErrEventCancelled):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "event cancelled"})
+		return
+	case errors.Is(err, reservation.ErrEventEnded):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "event ended"})
 		return
 	case errors.Is(err, reservation.ErrHoldConflict):
-		c.JSON(http.StatusConflict, ErrorResponse{Error: "hold conflict"})
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "hold conflict"})
 		return
 	case errors.Is(err, reservation.ErrHoldExpired):
-		c.JSON(http.StatusConflict, ErrorResponse{Error: "hold expired"})
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "hold expired"})
+		return
+	case errors.Is(err, reservation.ErrAlreadyConfirmed):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "hold already confirmed"})
+		return
+	case errors.Is(err, reservation.ErrNothingToConfirm):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "nothing to confirm"})
+		return
+	case errors.Is(err, reservation.ErrGAUnavailable):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "not enough general admission tickets"})
+		return
+	case errors.Is(err, reservation.ErrNoTicketsRequested):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "no tickets requested"})
 		return
 	case errors.Is(err, reservation.ErrHoldNotFound):
-		c.JSON(http.StatusNotFound, ErrorResponse{Error: "hold not found"})
+		writeError(c, http.StatusNotFound, ErrorResponse{Error: "hold not found"})
+		return
+	case errors.Is(err, reservation.ErrHoldNotOwned):
+		writeError(c, http.StatusForbidden, ErrorResponse{Error: "hold belongs to another user"})
 		return
 	case errors.Is(err, reservation.ErrSeatsUnavailable):
-		c.JSON(http.StatusConflict, ErrorResponse{Error: "seats unavailable"})
+		resp := ErrorResponse{Error: "seats unavailable"}
+		var se reservation.SeatsUnavailableError
+		if errors.As(err, &se) {
+			resp.UnavailableSeatIDs = se.SeatIDs
+		}
+		writeError(c, http.StatusConflict, resp)
+		return
+	case errors.Is(err, reservation.ErrEventSoldOut):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "event sold out"})
 		return
+	case errors.Is(err, reservation.ErrSeatLimitExceeded):
+		writeError(c, http.StatusConflict, ErrorResponse{Error: "seat limit per user exceeded"})
+		return
+	case errors.Is(err, reservation.ErrTooManySeats):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "too many seats in one hold"})
+		return
+	case errors.Is(err, reservation.ErrInvalidExtension):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "extra_sec must be positive"})
+		return
+	case errors.Is(err, reservation.ErrSeatVersionsMismatch):
+		writeError(c, http.StatusBadRequest, ErrorResponse{Error: "seat_versions must have one version per seat_ids entry"})
+		return
+	case errors.Is(err, reservation.ErrSeatsNotContiguous):
+		writeError(c, http.StatusUnprocessableEntity, ErrorResponse{Error: "seats are not contiguous"})
+		return
+	case errors.Is(err, reservation.ErrRateLimited):
+		var rl reservation.RateLimitedError
+		if errors.As(err, &rl) {
+			c.Header("Retry-After", retryAfterSeconds(rl.RetryAfter))
+		}
+		writeError(c, http.StatusTooManyRequests, ErrorResponse{Error: "rate limited"})
+		return
+	default:
+		// Unmapped errors are unexpected; keep their details out of the
+		// response. logRespondedErr records them.
+		writeError(c, http.StatusInternalServerError, ErrorResponse{Error: "internal error"})
+	}
+}
+
+// logRespondedErr logs an error answered by respondErr with the request ID,
+// route and failing operation: at error level for 5xx responses, at debug
+// level otherwise.
+func logRespondedErr(c *gin.Context, err error) {
+	status := c.Writer.Status()
+	reqID, _ := c.Get("request_id")
+
+	level := slog.LevelDebug
+	if status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+
+	requestLogger(c).Log(c.Request.Context(), level, "request failed",
+		"request_id", reqID,
+		"route", c.FullPath(),
+		"op", errOp(err),
+		"status", status,
+		"error", err,
+	)
+}
+
+// errOp returns the outermost op prefix of err, such as
+// "reservation.Service.CreateHold", or "" when err carries none.
+func errOp(err error) string {
+	op, _, ok := strings.Cut(err.Error(), ":")
+	// Ops are dotted identifiers; anything else is a plain message.
+	if !ok || !strings.Contains(op, ".") || strings.ContainsAny(op, " \t") {
+		return ""
 	}
+
+	return op
 }
@@ -0,0 +1,1425 @@
+package httpgin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kirinyoku/tix-go/internal/auth"
+	"github.com/kirinyoku/tix-go/internal/metrics"
+	"github.com/kirinyoku/tix-go/internal/postgres/pgtest"
+	postgresrepo "github.com/kirinyoku/tix-go/internal/repository/postgres"
+	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+	"github.com/kirinyoku/tix-go/internal/service"
+	"github.com/kirinyoku/tix-go/internal/service/reservation"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+const testJWTSecret = "test-secret"
+
+// newTestRouter builds the router without backing services, for requests
+// that are answered before a service is reached.
+func newTestRouter(cfg RouterConfig) http.Handler {
+	cfg.JWTSecret = testJWTSecret
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return NewRouter(&service.Services{}, nil, logger, cfg)
+}
+
+// newDBRouter builds the router over real services backed by a fresh
+// schema and an in-memory Redis, without rate limits. It skips the test
+// unless TEST_DATABASE_URL is set.
+func newDBRouter(t *testing.T, cfg RouterConfig) (http.Handler, *pgxpool.Pool) {
+	t.Helper()
+
+	r, pool, _ := newDBRouterRedis(t, cfg)
+
+	return r, pool
+}
+
+// newDBRouterRedis is newDBRouter that also returns the in-memory Redis.
+// Event change notifications are enabled.
+func newDBRouterRedis(t *testing.T, cfg RouterConfig) (http.Handler, *pgxpool.Pool, *miniredis.Miniredis) {
+	t.Helper()
+
+	return newDBRouterServices(t, cfg, service.Config{})
+}
+
+// newDBRouterServices is newDBRouterRedis with the given service settings.
+func newDBRouterServices(
+	t *testing.T,
+	cfg RouterConfig,
+	svcCfg service.Config,
+) (http.Handler, *pgxpool.Pool, *miniredis.Miniredis) {
+	t.Helper()
+
+	pool := pgtest.New(t)
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	pubsub := redisrepo.NewEventsPubSub(rdb)
+	cfg.JWTSecret = testJWTSecret
+	cfg.Events = pubsub
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svcCfg.Logger = logger
+	svcs := service.NewServices(
+		postgresrepo.NewStore(pool),
+		redisrepo.New(rdb),
+		pubsub,
+		nil,
+		nil,
+		metrics.New(prometheus.NewRegistry()),
+		svcCfg,
+	)
+	idem := redisrepo.NewIdempotencyStore(rdb, time.Hour, time.Minute)
+
+	return NewRouter(svcs, idem, logger, cfg), pool, mr
+}
+
+// serve sends a request to h and returns the recorded response. A
+// non-empty body is sent as JSON.
+func serve(h http.Handler, method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, r)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	return w
+}
+
+func bearer(t *testing.T, userID int64) string {
+	t.Helper()
+	token, err := auth.IssueToken(userID, auth.RoleUser, time.Hour, []byte(testJWTSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return "Bearer " + token
+}
+
+func expiredBearer(t *testing.T, userID int64) string {
+	t.Helper()
+	token, err := auth.IssueToken(userID, auth.RoleUser, -time.Minute, []byte(testJWTSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return "Bearer " + token
+}
+
+// routeCase is a request against the router and the response expected
+// before any service is called.
+type routeCase struct {
+	name       string
+	method     string
+	path       string
+	body       string
+	header     map[string]string
+	devHeader  bool
+	wantStatus int
+	wantError  string
+}
+
+func runRouteCases(t *testing.T, tests []routeCase) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRouter(RouterConfig{AllowDevUserHeader: tt.devHeader})
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(tt.method, tt.path, body)
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantError != "" && !strings.Contains(w.Body.String(), tt.wantError) {
+				t.Errorf("body = %s, want error %q", w.Body.String(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestRefundRequiresUser(t *testing.T) {
+	const path = "/orders/6f1c1c9e-8d55-4a53-a0a4-1f0f5b0a3c11/refund"
+
+	runRouteCases(t, []routeCase{
+		{name: "no token", method: http.MethodPost, path: path, wantStatus: http.StatusUnauthorized},
+		{
+			name:       "dev header disabled",
+			method:     http.MethodPost,
+			path:       path,
+			header:     map[string]string{UserIDHeader: "7"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid token",
+			method:     http.MethodPost,
+			path:       path,
+			header:     map[string]string{"Authorization": "Bearer nope"},
+			wantStatus: http.StatusUnauthorized,
+		},
+	})
+}
+
+func TestUserRoutesTrustBoundary(t *testing.T) {
+	const (
+		holdPath  = "/holds/6f1c1c9e-8d55-4a53-a0a4-1f0f5b0a3c11"
+		orderPath = "/orders/6f1c1c9e-8d55-4a53-a0a4-1f0f5b0a3c11"
+	)
+	routes := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodPost, "/events/1/holds", `{"seat_ids":[1]}`},
+		{http.MethodPost, "/events/1/holds/auto", `{"count":1}`},
+		{http.MethodPost, "/events/1/holds/ga", `{"qty":1}`},
+		{http.MethodPost, "/orders/confirm", `{"hold_id":"6f1c1c9e-8d55-4a53-a0a4-1f0f5b0a3c11"}`},
+		{http.MethodGet, holdPath, ""},
+		{http.MethodDelete, holdPath, ""},
+		{http.MethodPost, holdPath + "/extend", `{"extra_sec":30}`},
+		{http.MethodPost, "/events/1/waitlist", `{"seat_count":2}`},
+		{http.MethodGet, orderPath, ""},
+		{http.MethodPost, orderPath + "/refund", ""},
+		{http.MethodPost, "/orders/tickets", `{"order_ids":["6f1c1c9e-8d55-4a53-a0a4-1f0f5b0a3c11"]}`},
+		{http.MethodGet, "/users/7/orders", ""},
+		{http.MethodGet, "/tickets/6f1c1c9e-8d55-4a53-a0a4-1f0f5b0a3c11", ""},
+		{http.MethodGet, "/tickets/6f1c1c9e-8d55-4a53-a0a4-1f0f5b0a3c11/qr", ""},
+	}
+
+	var tests []routeCase
+	for _, rt := range routes {
+		name := rt.method + " " + rt.path
+		tests = append(tests,
+			routeCase{
+				name:       name + " without token",
+				method:     rt.method,
+				path:       rt.path,
+				body:       rt.body,
+				wantStatus: http.StatusUnauthorized,
+			},
+			routeCase{
+				name:       name + " with dev header disabled",
+				method:     rt.method,
+				path:       rt.path,
+				body:       rt.body,
+				header:     map[string]string{UserIDHeader: "7"},
+				wantStatus: http.StatusUnauthorized,
+			},
+			routeCase{
+				name:       name + " with expired token",
+				method:     rt.method,
+				path:       rt.path,
+				body:       rt.body,
+				header:     map[string]string{"Authorization": expiredBearer(t, 7)},
+				wantStatus: http.StatusUnauthorized,
+				wantError:  "token expired",
+			},
+		)
+	}
+
+	mismatch := "user_id does not match the authenticated user"
+	tests = append(tests,
+		routeCase{
+			name:       "hold for another user",
+			method:     http.MethodPost,
+			path:       "/events/1/holds",
+			body:       `{"user_id":8,"seat_ids":[1]}`,
+			header:     map[string]string{"Authorization": bearer(t, 7)},
+			wantStatus: http.StatusForbidden,
+			wantError:  mismatch,
+		},
+		routeCase{
+			name:       "confirm for another user",
+			method:     http.MethodPost,
+			path:       "/orders/confirm",
+			body:       `{"hold_id":"6f1c1c9e-8d55-4a53-a0a4-1f0f5b0a3c11","user_id":8}`,
+			header:     map[string]string{"Authorization": bearer(t, 7)},
+			wantStatus: http.StatusForbidden,
+			wantError:  mismatch,
+		},
+		routeCase{
+			name:       "waitlist for another user",
+			method:     http.MethodPost,
+			path:       "/events/1/waitlist",
+			body:       `{"user_id":8,"seat_count":2}`,
+			header:     map[string]string{"Authorization": bearer(t, 7)},
+			wantStatus: http.StatusForbidden,
+			wantError:  mismatch,
+		},
+		routeCase{
+			name:       "orders of another user",
+			method:     http.MethodGet,
+			path:       "/users/8/orders",
+			header:     map[string]string{"Authorization": bearer(t, 7)},
+			wantStatus: http.StatusForbidden,
+			wantError:  mismatch,
+		},
+		routeCase{
+			name:       "dev header for another user",
+			method:     http.MethodGet,
+			path:       "/users/8/orders",
+			header:     map[string]string{UserIDHeader: "7"},
+			devHeader:  true,
+			wantStatus: http.StatusForbidden,
+			wantError:  mismatch,
+		},
+	)
+
+	runRouteCases(t, tests)
+}
+
+func TestSeatMapRejectsBadToken(t *testing.T) {
+	runRouteCases(t, []routeCase{
+		{
+			name:       "invalid token",
+			method:     http.MethodGet,
+			path:       "/events/1/seatmap",
+			header:     map[string]string{"Authorization": "Bearer nope"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "expired token",
+			method:     http.MethodGet,
+			path:       "/events/1/seatmap",
+			header:     map[string]string{"Authorization": expiredBearer(t, 7)},
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "token expired",
+		},
+	})
+}
+
+func TestTicketVerifyRequiresGateClient(t *testing.T) {
+	routes := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/tickets/6f1c1c9e-8d55-4a53-a0a4-1f0f5b0a3c11/verify?signature=ab", ""},
+		{http.MethodPost, "/tickets/verify", `{"token":"a.b"}`},
+	}
+
+	var tests []routeCase
+	for _, rt := range routes {
+		name := rt.method + " " + rt.path
+		tests = append(tests,
+			routeCase{
+				name:       name + " anonymous",
+				method:     rt.method,
+				path:       rt.path,
+				body:       rt.body,
+				wantStatus: http.StatusUnauthorized,
+			},
+			routeCase{
+				name:       name + " as ticket holder",
+				method:     rt.method,
+				path:       rt.path,
+				body:       rt.body,
+				header:     map[string]string{"Authorization": bearer(t, 7)},
+				wantStatus: http.StatusUnauthorized,
+			},
+			routeCase{
+				name:       name + " with wrong admin token",
+				method:     rt.method,
+				path:       rt.path,
+				body:       rt.body,
+				header:     map[string]string{AdminTokenHeader: "nope"},
+				wantStatus: http.StatusUnauthorized,
+			},
+		)
+	}
+
+	runRouteCases(t, tests)
+}
+
+func TestBatchCreateSeatsValidatesRow(t *testing.T) {
+	r := newTestRouter(RouterConfig{AdminToken: "admin"})
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/venues/1/seats",
+		strings.NewReader(`{"seats":[{"section":"A","row":"B","number":1}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(AdminTokenHeader, "admin")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body.String())
+	}
+	if want := `{"field":"seats[0].row","reason":"seat_row"}`; !strings.Contains(w.Body.String(), want) {
+		t.Errorf("body = %s, want field error %s", w.Body.String(), want)
+	}
+}
+
+func TestBatchCreateSeatsReplay(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{AdminToken: "admin"})
+
+	var venueID int64
+	if err := pool.QueryRow(context.Background(),
+		`INSERT INTO venues (name) VALUES ('Hall') RETURNING id`,
+	).Scan(&venueID); err != nil {
+		t.Fatal(err)
+	}
+	path := fmt.Sprintf("/admin/venues/%d/seats", venueID)
+	body := `{"seats":[{"section":"A","row":"1","number":1},{"section":"A","row":"1","number":2}]}`
+	header := func(key string) map[string]string {
+		return map[string]string{AdminTokenHeader: "admin", idempotencyHeader: key}
+	}
+
+	first := serve(r, http.MethodPost, path, body, header("k1"))
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first: status = %d, want %d: %s", first.Code, http.StatusCreated, first.Body.String())
+	}
+	if got, want := strings.TrimSpace(first.Body.String()), `{"created":2}`; got != want {
+		t.Fatalf("first: body = %s, want %s", got, want)
+	}
+
+	// The seats exist now, so only a replay can still report them created.
+	replay := serve(r, http.MethodPost, path, body, header("k1"))
+	if replay.Code != http.StatusCreated {
+		t.Fatalf("replay: status = %d, want %d: %s", replay.Code, http.StatusCreated, replay.Body.String())
+	}
+	if replay.Body.String() != first.Body.String() {
+		t.Errorf("replay: body = %s, want %s", replay.Body.String(), first.Body.String())
+	}
+	if got := replay.Header().Get(idempotencyHeader); got != "k1" {
+		t.Errorf("replay: %s = %q, want %q", idempotencyHeader, got, "k1")
+	}
+
+	fresh := serve(r, http.MethodPost, path, body, header("k2"))
+	if got, want := strings.TrimSpace(fresh.Body.String()), `{"created":0}`; got != want {
+		t.Errorf("new key: body = %s, want %s", got, want)
+	}
+
+	var seats int
+	if err := pool.QueryRow(context.Background(),
+		`SELECT count(*) FROM seats WHERE venue_id = $1`, venueID,
+	).Scan(&seats); err != nil {
+		t.Fatal(err)
+	}
+	if seats != 2 {
+		t.Errorf("seats = %d, want 2", seats)
+	}
+}
+
+func TestAdminRoutesRequireToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		adminToken string
+		method     string
+		path       string
+		token      string
+		wantStatus int
+	}{
+		// Public requests stop at the invalid event ID, past any auth.
+		{name: "public read", adminToken: "admin", method: http.MethodGet, path: "/events/x", wantStatus: http.StatusBadRequest},
+		{name: "public seats", adminToken: "admin", method: http.MethodGet, path: "/events/x/seats", wantStatus: http.StatusBadRequest},
+		{name: "health", adminToken: "admin", method: http.MethodGet, path: "/healthz", wantStatus: http.StatusOK},
+		{name: "admin without token", adminToken: "admin", method: http.MethodGet, path: "/admin/events/x/log", wantStatus: http.StatusUnauthorized},
+		{name: "admin wrong token", adminToken: "admin", method: http.MethodGet, path: "/admin/events/x/log", token: "guess", wantStatus: http.StatusUnauthorized},
+		{name: "admin write without token", adminToken: "admin", method: http.MethodPut, path: "/admin/events/x/ga", wantStatus: http.StatusUnauthorized},
+		{name: "admin token", adminToken: "admin", method: http.MethodGet, path: "/admin/events/x/log", token: "admin", wantStatus: http.StatusBadRequest},
+		{name: "admin token unset", method: http.MethodGet, path: "/admin/events/x/log", token: "admin", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRouter(RouterConfig{AdminToken: tt.adminToken})
+
+			header := map[string]string{}
+			if tt.token != "" {
+				header[AdminTokenHeader] = tt.token
+			}
+			w := serve(r, tt.method, tt.path, "", header)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusUnauthorized {
+				var got ErrorResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || got.Error == "" {
+					t.Errorf("body = %s, want an ErrorResponse", w.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestAdminWriteLimit(t *testing.T) {
+	r := newTestRouter(RouterConfig{AdminToken: "admin", WriteLimiter: &fakeLimiter{limit: 1}})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		// Requests stop at the invalid event ID, before any service call.
+		{name: "first read", method: http.MethodGet, path: "/admin/events/x/log", wantStatus: http.StatusBadRequest},
+		{name: "second read", method: http.MethodGet, path: "/admin/events/x/log", wantStatus: http.StatusBadRequest},
+		{name: "first write", method: http.MethodPut, path: "/admin/events/x/ga", wantStatus: http.StatusBadRequest},
+		{name: "second write", method: http.MethodPut, path: "/admin/events/x/ga", wantStatus: http.StatusTooManyRequests},
+		{name: "read after writes", method: http.MethodGet, path: "/admin/events/x/log", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		req.Header.Set(AdminTokenHeader, "admin")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus {
+			t.Fatalf("%s: status = %d, want %d: %s", tt.name, w.Code, tt.wantStatus, w.Body.String())
+		}
+	}
+}
+
+func TestForwardedForTrust(t *testing.T) {
+	tests := []struct {
+		name     string
+		proxies  []netip.Prefix
+		wantKeys []string
+	}{
+		// Without trusted proxies the header is ignored, so a client cannot
+		// dodge its limit by rotating a spoofed X-Forwarded-For.
+		{name: "no trusted proxies", wantKeys: []string{"ip:192.0.2.10"}},
+		{
+			name:     "trusted proxy",
+			proxies:  []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")},
+			wantKeys: []string{"ip:198.51.100.1", "ip:198.51.100.2"},
+		},
+		{
+			name:     "untrusted peer",
+			proxies:  []netip.Prefix{netip.MustParsePrefix("203.0.113.0/24")},
+			wantKeys: []string{"ip:192.0.2.10"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := &fakeLimiter{limit: 1}
+			r := newTestRouter(RouterConfig{
+				AdminToken:     "admin",
+				WriteLimiter:   limiter,
+				TrustedProxies: tt.proxies,
+			})
+
+			for _, xff := range []string{"198.51.100.1", "198.51.100.2"} {
+				req := httptest.NewRequest(http.MethodPut, "/admin/events/x/ga", nil)
+				req.RemoteAddr = "192.0.2.10:1234"
+				req.Header.Set(AdminTokenHeader, "admin")
+				req.Header.Set("X-Forwarded-For", xff)
+				r.ServeHTTP(httptest.NewRecorder(), req)
+			}
+
+			got := make([]string, 0, len(limiter.seen))
+			for k := range limiter.seen {
+				got = append(got, k)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.wantKeys) {
+				t.Errorf("limiter keys = %v, want %v", got, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestCancelHold(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	holdID, _, err := postgresrepo.NewStore(pool).Reservations().
+		HoldSeats(context.Background(), eventID, 7, seatIDs, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := map[string]string{"Authorization": bearer(t, 7)}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "bad uuid", path: "/holds/nope", wantStatus: http.StatusBadRequest},
+		{name: "not found", path: "/holds/" + uuid.NewString(), wantStatus: http.StatusNotFound},
+		{name: "success", path: "/holds/" + holdID.String(), wantStatus: http.StatusNoContent},
+		{name: "already cancelled", path: "/holds/" + holdID.String(), wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		w := serve(r, http.MethodDelete, tt.path, "", auth)
+		if w.Code != tt.wantStatus {
+			t.Fatalf("%s: status = %d, want %d: %s", tt.name, w.Code, tt.wantStatus, w.Body.String())
+		}
+	}
+
+	var available int
+	if err := pool.QueryRow(context.Background(),
+		`SELECT count(*) FROM event_seats WHERE event_id = $1 AND status = 'available'`, eventID,
+	).Scan(&available); err != nil {
+		t.Fatal(err)
+	}
+	if available != len(seatIDs) {
+		t.Errorf("available seats = %d, want %d", available, len(seatIDs))
+	}
+}
+
+func TestGetHold(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{})
+	ctx := context.Background()
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 3, 0)
+	holds := postgresrepo.NewStore(pool).Reservations()
+
+	active, _, err := holds.HoldSeats(ctx, eventID, 7, seatIDs[:2], time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lapsed, _, err := holds.HoldSeats(ctx, eventID, 7, seatIDs[2:], time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Exec(ctx,
+		`UPDATE holds SET expires_at = now() - interval '1 second' WHERE id = $1`, lapsed,
+	); err != nil {
+		t.Fatal(err)
+	}
+	owner := map[string]string{"Authorization": bearer(t, 7)}
+
+	w := serve(r, http.MethodGet, "/holds/"+active.String(), "", owner)
+	if w.Code != http.StatusOK {
+		t.Fatalf("active: status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var got HoldStatusResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.TTLRemainingSec < 1 || got.TTLRemainingSec > 60 {
+		t.Errorf("ttl_remaining_sec = %d, want within (0, 60]", got.TTLRemainingSec)
+	}
+	if !slices.Equal(got.SeatIDs, seatIDs[:2]) {
+		t.Errorf("seat_ids = %v, want %v", got.SeatIDs, seatIDs[:2])
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		header     map[string]string
+		wantStatus int
+	}{
+		{name: "lapsed", path: "/holds/" + lapsed.String(), header: owner, wantStatus: http.StatusNotFound},
+		{name: "unknown", path: "/holds/" + uuid.NewString(), header: owner, wantStatus: http.StatusNotFound},
+		{
+			name:       "other user",
+			path:       "/holds/" + active.String(),
+			header:     map[string]string{"Authorization": bearer(t, 8)},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		if w := serve(r, http.MethodGet, tt.path, "", tt.header); w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d: %s", tt.name, w.Code, tt.wantStatus, w.Body.String())
+		}
+	}
+}
+
+func TestExtendHold(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{})
+	ctx := context.Background()
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	holds := postgresrepo.NewStore(pool).Reservations()
+
+	active, _, err := holds.HoldSeats(ctx, eventID, 7, seatIDs[:1], time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lapsed, _, err := holds.HoldSeats(ctx, eventID, 7, seatIDs[1:], time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Exec(ctx,
+		`UPDATE holds SET expires_at = now() - interval '1 second' WHERE id = $1`, lapsed,
+	); err != nil {
+		t.Fatal(err)
+	}
+	owner := map[string]string{"Authorization": bearer(t, 7)}
+
+	w := serve(r, http.MethodPost, "/holds/"+active.String()+"/extend", `{"extra_sec":30}`, owner)
+	if w.Code != http.StatusOK {
+		t.Fatalf("active: status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var got ExtendHoldResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.TTLRemainingSec <= 60 || got.TTLRemainingSec > 90 {
+		t.Errorf("ttl_remaining_sec = %d, want within (60, 90]", got.TTLRemainingSec)
+	}
+
+	w = serve(r, http.MethodPost, "/holds/"+lapsed.String()+"/extend", `{"extra_sec":30}`, owner)
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "hold expired") {
+		t.Errorf("lapsed: status = %d, body = %s, want %d hold expired", w.Code, w.Body.String(), http.StatusConflict)
+	}
+}
+
+func TestHoldSizeCapFromConfig(t *testing.T) {
+	r, pool, _ := newDBRouterServices(t, RouterConfig{}, service.Config{
+		Reservation: reservation.Config{MaxSeatsPerHold: 150},
+	})
+	eventID, _ := pgtest.SeedEvent(t, pool, 2, 80, 200)
+	owner := map[string]string{"Authorization": bearer(t, 7)}
+	path := fmt.Sprintf("/events/%d/holds/", eventID)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"auto at cap", path + "auto", `{"count":150}`, http.StatusCreated},
+		{"auto over cap", path + "auto", `{"count":151}`, http.StatusBadRequest},
+		{"ga at cap", path + "ga", `{"qty":150}`, http.StatusCreated},
+		{"ga over cap", path + "ga", `{"qty":151}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodPost, tt.path, tt.body, owner)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusBadRequest && !strings.Contains(w.Body.String(), "too many seats in one hold") {
+				t.Errorf("body = %s, want too many seats in one hold", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCreateHoldSeatIDs(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{})
+	ctx := context.Background()
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 6, 0)
+	taken := seatIDs[2]
+	if _, _, err := postgresrepo.NewStore(pool).Reservations().
+		HoldSeats(ctx, eventID, 8, []int64{taken}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	owner := map[string]string{"Authorization": bearer(t, 7)}
+
+	tests := []struct {
+		name            string
+		path            string
+		body            string
+		wantSeats       []int64
+		wantUnavailable []int64
+	}{
+		{
+			name:      "requested seats",
+			path:      fmt.Sprintf("/events/%d/holds", eventID),
+			body:      fmt.Sprintf(`{"seat_ids":[%d,%d]}`, seatIDs[0], seatIDs[1]),
+			wantSeats: seatIDs[:2],
+		},
+		{
+			name:            "allow partial",
+			path:            fmt.Sprintf("/events/%d/holds", eventID),
+			body:            fmt.Sprintf(`{"seat_ids":[%d,%d,%d],"allow_partial":true}`, taken, seatIDs[3], seatIDs[4]),
+			wantSeats:       seatIDs[3:5],
+			wantUnavailable: []int64{taken},
+		},
+		{
+			name:      "auto",
+			path:      fmt.Sprintf("/events/%d/holds/auto", eventID),
+			body:      `{"count":1}`,
+			wantSeats: seatIDs[5:],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodPost, tt.path, tt.body, owner)
+			if w.Code != http.StatusCreated {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body.String())
+			}
+			var got CreateHoldResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			rows, err := pool.Query(ctx,
+				`SELECT seat_id FROM event_seats WHERE hold_id = $1 ORDER BY seat_id`, got.HoldID,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var held []int64
+			for rows.Next() {
+				var id int64
+				if err := rows.Scan(&id); err != nil {
+					t.Fatal(err)
+				}
+				held = append(held, id)
+			}
+			if err := rows.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			seats := slices.Sorted(slices.Values(got.SeatIDs))
+			if !slices.Equal(seats, tt.wantSeats) {
+				t.Errorf("seat_ids = %v, want %v", got.SeatIDs, tt.wantSeats)
+			}
+			if !slices.Equal(seats, held) {
+				t.Errorf("seat_ids = %v, held seats %v", got.SeatIDs, held)
+			}
+			if !slices.Equal(got.UnavailableSeatIDs, tt.wantUnavailable) {
+				t.Errorf("unavailable_seat_ids = %v, want %v", got.UnavailableSeatIDs, tt.wantUnavailable)
+			}
+		})
+	}
+}
+
+func TestMetricsRoute(t *testing.T) {
+	r := newTestRouter(RouterConfig{Metrics: metrics.New(prometheus.NewRegistry())})
+
+	for range 2 {
+		serve(r, http.MethodGet, "/events/x", "", nil)
+	}
+	serve(r, http.MethodGet, "/nowhere", "", nil)
+
+	w := serve(r, http.MethodGet, "/metrics", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	for _, want := range []string{
+		`tixgo_http_requests_total{method="GET",route="/events/:id",status="400"} 2`,
+		`tixgo_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`tixgo_http_request_duration_seconds_count{method="GET",route="/events/:id"} 2`,
+	} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("metrics lack %s", want)
+		}
+	}
+}
+
+func TestListUserOrdersEmpty(t *testing.T) {
+	r, _ := newDBRouter(t, RouterConfig{})
+
+	w := serve(r, http.MethodGet, "/users/7/orders", "", map[string]string{"Authorization": bearer(t, 7)})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if got := strings.TrimSpace(w.Body.String()); got != "[]" {
+		t.Errorf("body = %s, want []", got)
+	}
+}
+
+func TestAdminListOrders(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{AdminToken: "admin"})
+	ctx := context.Background()
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 3, 0)
+	store := postgresrepo.NewStore(pool)
+
+	// Three orders created an hour apart; oldest first.
+	base := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	var ids []string
+	for i, seatID := range seatIDs {
+		holdID, _, err := store.Reservations().HoldSeats(ctx, eventID, int64(i+1), []int64{seatID}, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		orderID, err := store.Reservations().ConfirmHold(ctx, holdID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := pool.Exec(ctx,
+			`UPDATE orders SET created_at = $2 WHERE id = $1`, orderID, base.Add(time.Duration(i)*time.Hour),
+		); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, orderID.String())
+	}
+	admin := map[string]string{AdminTokenHeader: "admin"}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		want       []string
+	}{
+		{name: "default newest first", query: "from=2030-01-01T10:00:00Z&to=2030-01-01T13:00:00Z", wantStatus: http.StatusOK, want: []string{ids[2], ids[1], ids[0]}},
+		{name: "oldest first", query: "from=2030-01-01T10:00:00Z&to=2030-01-01T13:00:00Z&sort=asc", wantStatus: http.StatusOK, want: ids},
+		{name: "range excludes to", query: "from=2030-01-01T10:30:00Z&to=2030-01-01T12:00:00Z&sort=asc", wantStatus: http.StatusOK, want: ids[1:2]},
+		{name: "range newest first", query: "from=2030-01-01T11:00:00Z&to=2030-01-01T13:00:00Z&sort=desc", wantStatus: http.StatusOK, want: []string{ids[2], ids[1]}},
+		{name: "missing to", query: "from=2030-01-01T10:00:00Z", wantStatus: http.StatusBadRequest},
+		{name: "bad sort", query: "from=2030-01-01T10:00:00Z&to=2030-01-01T13:00:00Z&sort=up", wantStatus: http.StatusBadRequest},
+		{name: "inverted", query: "from=2030-01-02T00:00:00Z&to=2030-01-01T00:00:00Z", wantStatus: http.StatusBadRequest},
+		{name: "too wide", query: "from=2029-01-01T00:00:00Z&to=2030-01-01T00:00:00Z", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodGet, "/admin/orders?"+tt.query, "", admin)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got []struct{ ID string }
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			gotIDs := make([]string, 0, len(got))
+			for _, o := range got {
+				gotIDs = append(gotIDs, o.ID)
+			}
+			if !slices.Equal(gotIDs, tt.want) {
+				t.Errorf("orders = %v, want %v", gotIDs, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminEventRevenue(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{AdminToken: "admin"})
+	ctx := context.Background()
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 5, 0)
+	reservations := postgresrepo.NewStore(pool).Reservations()
+
+	order := func(userID int64, seats []int64) uuid.UUID {
+		t.Helper()
+		holdID, _, err := reservations.HoldSeats(ctx, eventID, userID, seats, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		orderID, err := reservations.ConfirmHold(ctx, holdID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return orderID
+	}
+	order(1, seatIDs[:2])
+	order(2, seatIDs[2:3])
+	refunded := order(3, seatIDs[3:])
+	if _, _, err := reservations.RefundOrder(ctx, refunded); err != nil {
+		t.Fatal(err)
+	}
+
+	var emptyID int64
+	if err := pool.QueryRow(ctx,
+		`INSERT INTO events (venue_id, title, starts_at, ends_at)
+		 SELECT venue_id, 'No Sales', starts_at, ends_at FROM events WHERE id = $1
+		 RETURNING id`,
+		eventID,
+	).Scan(&emptyID); err != nil {
+		t.Fatal(err)
+	}
+	admin := map[string]string{AdminTokenHeader: "admin"}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		want       EventRevenueResponse
+	}{
+		{
+			name:       "refund excluded",
+			path:       fmt.Sprintf("/admin/events/%d/revenue", eventID),
+			wantStatus: http.StatusOK,
+			want:       EventRevenueResponse{OrdersCount: 2, TicketsSold: 3, TotalCents: 3000},
+		},
+		{name: "no orders", path: fmt.Sprintf("/admin/events/%d/revenue", emptyID), wantStatus: http.StatusOK},
+		{name: "unknown event", path: "/admin/events/999999/revenue", wantStatus: http.StatusNotFound},
+		{name: "invalid id", path: "/admin/events/x/revenue", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodGet, tt.path, "", admin)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got EventRevenueResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("revenue = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListEventSeatsCursor(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{})
+	// Twelve rows, so that row 10 must sort after row 9 rather than row 1.
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 12, 3, 0)
+
+	for _, limit := range []int{4, 5} {
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			var got []int64
+			cursor := ""
+			for pages := 0; ; pages++ {
+				if pages > len(seatIDs) {
+					t.Fatal("pagination does not terminate")
+				}
+				path := fmt.Sprintf("/events/%d/seats?limit=%d&cursor=%s", eventID, limit, cursor)
+				w := serve(r, http.MethodGet, path, "", nil)
+				if w.Code != http.StatusOK {
+					t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+				}
+				var page SeatPageResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+					t.Fatal(err)
+				}
+				if len(page.Items) > limit {
+					t.Fatalf("page of %d seats, limit %d", len(page.Items), limit)
+				}
+				for _, s := range page.Items {
+					got = append(got, s.ID)
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				cursor = page.NextCursor
+			}
+
+			// Seeded seat IDs are in (section, row, number) order.
+			if !slices.Equal(got, seatIDs) {
+				t.Errorf("got seats %v, want %v", got, seatIDs)
+			}
+		})
+	}
+
+	w := serve(r, http.MethodGet, fmt.Sprintf("/events/%d/seats?cursor=nope", eventID), "", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("invalid cursor: status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestListEventSeatsMeta(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 2, 5, 0)
+	if _, _, err := postgresrepo.NewStore(pool).Reservations().
+		HoldSeats(context.Background(), eventID, 7, seatIDs[:3], time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantItems int
+	}{
+		{name: "first page", query: "limit=4", wantItems: 4},
+		{name: "last page", query: "limit=4&offset=8", wantItems: 2},
+		{name: "past the end", query: "limit=4&offset=20", wantItems: 0},
+		{name: "available", query: "only=available&limit=4", wantItems: 4},
+		{name: "available last page", query: "only=available&limit=4&offset=4", wantItems: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := fmt.Sprintf("/events/%d/seats?", eventID)
+			w := serve(r, http.MethodGet, base+"meta=true&"+tt.query, "", nil)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+			}
+			var page SeatListResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+				t.Fatal(err)
+			}
+			if len(page.Items) != tt.wantItems {
+				t.Errorf("items = %d, want %d", len(page.Items), tt.wantItems)
+			}
+
+			// The total is the size of the same listing without paging.
+			filter, _, _ := strings.Cut(tt.query, "limit=")
+			w = serve(r, http.MethodGet, base+filter+"limit=1000", "", nil)
+			var all []json.RawMessage
+			if err := json.Unmarshal(w.Body.Bytes(), &all); err != nil {
+				t.Fatalf("unpaginated: %v: %s", err, w.Body.String())
+			}
+			if page.Total != int64(len(all)) {
+				t.Errorf("total = %d, want %d", page.Total, len(all))
+			}
+		})
+	}
+}
+
+func TestListVenuesSeatingScheme(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{AdminToken: "admin"})
+	if _, err := pool.Exec(context.Background(),
+		`INSERT INTO venues (name, seating_scheme) VALUES ('Hall', '{"sections":[{"name":"A","rows":2}]}')`,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	w := serve(r, http.MethodGet, "/admin/venues", "", map[string]string{AdminTokenHeader: "admin"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var got []map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d venues, want 1", len(got))
+	}
+	if _, ok := got[0]["SeatingScheme"].(map[string]any); !ok {
+		t.Errorf("SeatingScheme = %#v, want a JSON object", got[0]["SeatingScheme"])
+	}
+}
+
+func TestGetVenue(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{})
+	var venueID int64
+	if err := pool.QueryRow(context.Background(),
+		`INSERT INTO venues (name, seating_scheme) VALUES ('Hall', '{"sections":[]}') RETURNING id`,
+	).Scan(&venueID); err != nil {
+		t.Fatal(err)
+	}
+
+	w := serve(r, http.MethodGet, fmt.Sprintf("/venues/%d", venueID), "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("found: status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if w.Header().Get("ETag") == "" {
+		t.Error("found: no ETag")
+	}
+	if got, want := w.Header().Get("Cache-Control"), "public, max-age=300"; got != want {
+		t.Errorf("found: Cache-Control = %q, want %q", got, want)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["Name"] != "Hall" {
+		t.Errorf("Name = %v, want Hall", got["Name"])
+	}
+	if _, ok := got["SeatingScheme"].(map[string]any); !ok {
+		t.Errorf("SeatingScheme = %#v, want a JSON object", got["SeatingScheme"])
+	}
+
+	w = serve(r, http.MethodGet, fmt.Sprintf("/venues/%d", venueID+1), "", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("not found: status = %d, want %d: %s", w.Code, http.StatusNotFound, w.Body.String())
+	}
+}
+
+func TestUpdateEventRejectsTimes(t *testing.T) {
+	r := newTestRouter(RouterConfig{AdminToken: "admin"})
+
+	w := serve(r, http.MethodPut, "/admin/events/1",
+		`{"title":"x","starts_at":"2030-01-01T20:00:00Z","ends_at":"2030-01-01T18:00:00Z"}`,
+		map[string]string{AdminTokenHeader: "admin"})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "event must end after it starts") {
+		t.Errorf("body = %s, want the time ordering error", w.Body.String())
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 0, want: "1"},
+		{d: -time.Second, want: "1"},
+		{d: time.Millisecond, want: "1"},
+		{d: time.Second, want: "1"},
+		{d: time.Second + time.Nanosecond, want: "2"},
+		{d: 1500 * time.Millisecond, want: "2"},
+		{d: time.Minute, want: "60"},
+	}
+	for _, tt := range tests {
+		if got := retryAfterSeconds(tt.d); got != tt.want {
+			t.Errorf("retryAfterSeconds(%v) = %s, want %s", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestRespondErrRateLimited(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "limiter estimate",
+			err:  fmt.Errorf("op:%w", reservation.RateLimitedError{RetryAfter: 2300 * time.Millisecond}),
+			want: "3",
+		},
+		{name: "no estimate", err: fmt.Errorf("op:%w", reservation.ErrRateLimited)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/events/1/holds", nil)
+
+			respondErr(c, tt.err)
+
+			if w.Code != http.StatusTooManyRequests {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.want {
+				t.Errorf("Retry-After = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespondErrSeatsUnavailable(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/events/1/holds", nil)
+
+	respondErr(c, fmt.Errorf("op:%w", reservation.SeatsUnavailableError{SeatIDs: []int64{3, 5}}))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+	var got ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{3, 5}; !slices.Equal(got.UnavailableSeatIDs, want) {
+		t.Errorf("unavailable_seat_ids = %v, want %v", got.UnavailableSeatIDs, want)
+	}
+}
+
+func TestRespondErrNothingToConfirm(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders/confirm", nil)
+
+	respondErr(c, fmt.Errorf("op:%w", reservation.ErrNothingToConfirm))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+	var got ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Error != "nothing to confirm" {
+		t.Errorf("error = %q, want %q", got.Error, "nothing to confirm")
+	}
+}
+
+func TestRespondErrUnmapped(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/events/1", nil)
+
+	respondErr(c, errors.New("postgres.EventRepo.Get:dial tcp 10.0.0.5:5432: connection refused"))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/json") {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	var got ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("body %q: %v", w.Body.String(), err)
+	}
+	if got.Error != "internal error" {
+		t.Errorf("error = %q, want %q", got.Error, "internal error")
+	}
+	for _, leak := range []string{"postgres", "10.0.0.5", "refused"} {
+		if strings.Contains(w.Body.String(), leak) {
+			t.Errorf("body %s leaks %q", w.Body.String(), leak)
+		}
+	}
+}
+
+func TestRespondErrLogs(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantLevel string
+		wantOp    string
+	}{
+		{
+			name:      "server error",
+			err:       errors.New("reservation.Service.ConfirmHold:postgres.OrderRepo.Create:boom"),
+			wantLevel: "ERROR",
+			wantOp:    "reservation.Service.ConfirmHold",
+		},
+		{
+			name:      "no op prefix",
+			err:       errors.New("boom: no op here"),
+			wantLevel: "ERROR",
+		},
+		{
+			name:      "client error",
+			err:       fmt.Errorf("reservation.Service.CreateHold:%w", reservation.ErrRateLimited),
+			wantLevel: "DEBUG",
+			wantOp:    "reservation.Service.CreateHold",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/orders/confirm", nil)
+			c.Set(loggerKey, logger)
+			c.Set("request_id", "req-123")
+
+			respondErr(c, tt.err)
+
+			var line map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+				t.Fatalf("log %q: %v", buf.String(), err)
+			}
+			if got := line["level"]; got != tt.wantLevel {
+				t.Errorf("level = %v, want %v", got, tt.wantLevel)
+			}
+			if got := line["request_id"]; got != "req-123" {
+				t.Errorf("request_id = %v, want %v", got, "req-123")
+			}
+			if got := line["op"]; got != tt.wantOp {
+				t.Errorf("op = %v, want %v", got, tt.wantOp)
+			}
+			if got := line["error"]; got != tt.err.Error() {
+				t.Errorf("error = %v, want %v", got, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestUpdateVenueRejectsScheme(t *testing.T) {
+	r := newTestRouter(RouterConfig{AdminToken: "admin"})
+
+	tests := []struct {
+		name      string
+		body      string
+		wantError string
+	}{
+		{name: "malformed body", body: `{"name":"Hall","seating_scheme":{`, wantError: "invalid request body"},
+		{name: "invalid scheme", body: `{"name":"Hall","seating_scheme":{"sections":[{"rows":[]}]}}`, wantError: "invalid seating scheme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Both are rejected before the store is reached.
+			w := serve(r, http.MethodPut, "/admin/venues/1", tt.body, map[string]string{AdminTokenHeader: "admin"})
+			if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), tt.wantError) {
+				t.Errorf("status = %d, body = %s; want %d %s", w.Code, w.Body.String(), http.StatusBadRequest, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestExpireHolds(t *testing.T) {
+	r, pool := newDBRouter(t, RouterConfig{AdminToken: "admin"})
+	ctx := context.Background()
+	holds := postgresrepo.NewStore(pool).Reservations()
+
+	lapse := func(holdID uuid.UUID) {
+		t.Helper()
+		if _, err := pool.Exec(ctx,
+			`UPDATE holds SET expires_at = now() - interval '1 second' WHERE id = $1`, holdID,
+		); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := pool.Exec(ctx,
+			`UPDATE event_seats SET hold_expires_at = now() - interval '1 second' WHERE hold_id = $1`, holdID,
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	eventA, seatsA := pgtest.SeedEvent(t, pool, 1, 3, 0)
+	eventB, seatsB := pgtest.SeedEvent(t, pool, 1, 2, 0)
+	lapsedA, _, err := holds.HoldSeats(ctx, eventA, 7, seatsA[:2], time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := holds.HoldSeats(ctx, eventA, 7, seatsA[2:], time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	lapsedB, _, err := holds.HoldSeats(ctx, eventB, 7, seatsB, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lapse(lapsedA)
+	lapse(lapsedB)
+
+	admin := map[string]string{AdminTokenHeader: "admin"}
+	tests := []struct {
+		name         string
+		body         string
+		wantStatus   int
+		wantReleased int64
+	}{
+		{name: "invalid event", body: `{"event_id":0}`, wantStatus: http.StatusBadRequest},
+		{name: "scoped", body: fmt.Sprintf(`{"event_id":%d}`, eventB), wantStatus: http.StatusOK, wantReleased: 2},
+		{name: "scoped again", body: fmt.Sprintf(`{"event_id":%d}`, eventB), wantStatus: http.StatusOK},
+		{name: "global", wantStatus: http.StatusOK, wantReleased: 2},
+		{name: "global again", body: `{}`, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		w := serve(r, http.MethodPost, "/admin/holds/expire", tt.body, admin)
+		if w.Code != tt.wantStatus {
+			t.Fatalf("%s: status = %d, want %d: %s", tt.name, w.Code, tt.wantStatus, w.Body.String())
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		var got ExpireHoldsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Released != tt.wantReleased {
+			t.Errorf("%s: released = %d, want %d", tt.name, got.Released, tt.wantReleased)
+		}
+	}
+
+	var held int
+	if err := pool.QueryRow(ctx,
+		`SELECT count(*) FROM event_seats WHERE event_id = $1 AND status = 'held'`, eventA,
+	).Scan(&held); err != nil {
+		t.Fatal(err)
+	}
+	if held != 1 {
+		t.Errorf("event %d: %d seats still held, want the 1 active", eventA, held)
+	}
+
+	if w := serve(r, http.MethodPost, "/admin/holds/expire", "", nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("no token: status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
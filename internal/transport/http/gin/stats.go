@@ -0,0 +1,77 @@
+package httpgin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// StatsResponse reports connection pool usage. A section is omitted when the
+// router was not given the corresponding client.
+type StatsResponse struct {
+	Postgres *PostgresPoolStats `json:"postgres,omitempty"`
+	Redis    *RedisPoolStats    `json:"redis,omitempty"`
+}
+
+type PostgresPoolStats struct {
+	AcquiredConns        int32 `json:"acquired_conns"`
+	IdleConns            int32 `json:"idle_conns"`
+	ConstructingConns    int32 `json:"constructing_conns"`
+	TotalConns           int32 `json:"total_conns"`
+	MaxConns             int32 `json:"max_conns"`
+	AcquireCount         int64 `json:"acquire_count"`
+	EmptyAcquireCount    int64 `json:"empty_acquire_count"`
+	CanceledAcquireCount int64 `json:"canceled_acquire_count"`
+	AcquireDurationMs    int64 `json:"acquire_duration_ms"`
+}
+
+type RedisPoolStats struct {
+	Hits       uint32 `json:"hits"`
+	Misses     uint32 `json:"misses"`
+	Timeouts   uint32 `json:"timeouts"`
+	TotalConns uint32 `json:"total_conns"`
+	IdleConns  uint32 `json:"idle_conns"`
+	StaleConns uint32 `json:"stale_conns"`
+}
+
+// @Summary  Connection pool statistics
+// @Description Reports Postgres and Redis pool usage to spot saturation.
+// @Success  200  {object}  StatsResponse
+// @Failure  401  {object}  ErrorResponse
+// @Router   /admin/stats [get]
+func handleStats(pool *pgxpool.Pool, rdb *goredis.Client) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var resp StatsResponse
+
+		if pool != nil {
+			st := pool.Stat()
+			resp.Postgres = &PostgresPoolStats{
+				AcquiredConns:        st.AcquiredConns(),
+				IdleConns:            st.IdleConns(),
+				ConstructingConns:    st.ConstructingConns(),
+				TotalConns:           st.TotalConns(),
+				MaxConns:             st.MaxConns(),
+				AcquireCount:         st.AcquireCount(),
+				EmptyAcquireCount:    st.EmptyAcquireCount(),
+				CanceledAcquireCount: st.CanceledAcquireCount(),
+				AcquireDurationMs:    st.AcquireDuration().Milliseconds(),
+			}
+		}
+
+		if rdb != nil {
+			st := rdb.PoolStats()
+			resp.Redis = &RedisPoolStats{
+				Hits:       st.Hits,
+				Misses:     st.Misses,
+				Timeouts:   st.Timeouts,
+				TotalConns: st.TotalConns,
+				IdleConns:  st.IdleConns,
+				StaleConns: st.StaleConns,
+			}
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
@@ -0,0 +1,74 @@
+package httpgin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+
+	// The pool connects lazily, so its stats are readable without a server.
+	cfg, err := pgxpool.ParseConfig("postgres://tix@127.0.0.1:1/tix?pool_max_conns=7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	admin := map[string]string{AdminTokenHeader: "admin"}
+
+	r := newTestRouter(RouterConfig{AdminToken: "admin", Postgres: pool, Redis: rdb})
+	w := serve(r, http.MethodGet, "/admin/stats", "", admin)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var got map[string]map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	wantFields := map[string][]string{
+		"postgres": {
+			"acquired_conns", "idle_conns", "constructing_conns", "total_conns", "max_conns",
+			"acquire_count", "empty_acquire_count", "canceled_acquire_count", "acquire_duration_ms",
+		},
+		"redis": {"hits", "misses", "timeouts", "total_conns", "idle_conns", "stale_conns"},
+	}
+	for section, fields := range wantFields {
+		for _, f := range fields {
+			if _, ok := got[section][f].(float64); !ok {
+				t.Errorf("%s.%s = %v, want a number", section, f, got[section][f])
+			}
+		}
+	}
+	if got["postgres"]["max_conns"] != float64(7) {
+		t.Errorf("postgres.max_conns = %v, want 7", got["postgres"]["max_conns"])
+	}
+	if got["redis"]["total_conns"] != float64(1) {
+		t.Errorf("redis.total_conns = %v, want 1", got["redis"]["total_conns"])
+	}
+
+	w = serve(newTestRouter(RouterConfig{AdminToken: "admin"}), http.MethodGet, "/admin/stats", "", admin)
+	if w.Code != http.StatusOK {
+		t.Fatalf("no clients: status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if got := w.Body.String(); got != "{}" {
+		t.Errorf("no clients: body = %s, want {}", got)
+	}
+}
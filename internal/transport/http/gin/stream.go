@@ -0,0 +1,101 @@
+package httpgin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+	"github.com/kirinyoku/tix-go/internal/service"
+)
+
+// streamKeepAlive is how often a comment frame is written to idle streams so
+// proxies and clients do not drop the connection.
+const streamKeepAlive = 15 * time.Second
+
+// @Summary  Stream availability changes (Server-Sent Events)
+// @Description Sends an `availability` event with the current counters on connect and after every change to the event.
+// @Produce  text/event-stream
+// @Param    id  path  int  true  "Event ID"
+// @Success  200  {object}  domain.EventCounts
+// @Failure  404  {object}  ErrorResponse
+// @Router   /events/{id}/stream [get]
+func handleEventStream(svcs *service.Services, events *redisrepo.EventsHub) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		// Resolve the event before switching to streaming so unknown IDs get a
+		// regular JSON error.
+		if _, err := svcs.Query.GetEvent(ctx, eventID); err != nil {
+			respondErr(c, err)
+			return
+		}
+
+		// Notifications come from the process-wide subscription; bursts are
+		// coalesced into a single refresh.
+		changed, stop := events.Watch(eventID)
+		defer stop()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		if err := writeAvailabilityFrame(c, svcs, eventID); err != nil {
+			return
+		}
+
+		ticker := time.NewTicker(streamKeepAlive)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-changed:
+				if !ok {
+					return
+				}
+				if err := writeAvailabilityFrame(c, svcs, eventID); err != nil {
+					return
+				}
+			case <-ticker.C:
+				if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+					return
+				}
+				c.Writer.Flush()
+			}
+		}
+	}
+}
+
+// writeAvailabilityFrame loads the current counters for the event and writes
+// them as an `availability` SSE frame.
+func writeAvailabilityFrame(c *gin.Context, svcs *service.Services, eventID int64) error {
+	cnt, err := svcs.Query.CountsByStatus(c.Request.Context(), eventID)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(cnt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(c.Writer, "event: availability\ndata: %s\n\n", b); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+
+	return nil
+}
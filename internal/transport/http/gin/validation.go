@@ -0,0 +1,93 @@
+package httpgin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/kirinyoku/tix-go/internal/domain"
+)
+
+var registerTagNameOnce sync.Once
+
+// useJSONFieldNames makes validation errors report the JSON name of a field
+// (e.g. "seat_ids") instead of the Go struct field name, and registers the
+// "seat_row" tag checking domain.ValidRow.
+func useJSONFieldNames() {
+	registerTagNameOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return f.Name
+			}
+			return name
+		})
+		_ = v.RegisterValidation("seat_row", func(fl validator.FieldLevel) bool {
+			return domain.ValidRow(fl.Field().String())
+		})
+	})
+}
+
+// bindError responds 400 for a failed ShouldBindJSON call. Validation
+// failures are reported per field; other decoding errors get a generic
+// message so gin's internal error strings never reach the client.
+func bindError(c *gin.Context, err error) {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		writeError(c, http.StatusRequestEntityTooLarge, ErrorResponse{
+			Error: fmt.Sprintf("request body too large (max %d bytes)", maxErr.Limit),
+		})
+		return
+	}
+
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		fields := make([]FieldError, 0, len(verrs))
+		for _, fe := range verrs {
+			fields = append(fields, FieldError{
+				Field:  fieldPath(fe),
+				Reason: fe.Tag(),
+			})
+		}
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:  "validation failed",
+			Fields: fields,
+		})
+		return
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:  "invalid request body",
+			Fields: []FieldError{{Field: typeErr.Field, Reason: "type"}},
+		})
+		return
+	}
+
+	badRequest(c, "invalid request body")
+}
+
+// fieldPath strips the top-level struct name from the validator namespace,
+// e.g. "CreateHoldRequest.seat_ids[0]" becomes "seat_ids[0]".
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.IndexByte(ns, '.'); i >= 0 {
+		return ns[i+1:]
+	}
+	return fe.Field()
+}
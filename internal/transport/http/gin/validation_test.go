@@ -0,0 +1,69 @@
+package httpgin
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestBindErrorFields(t *testing.T) {
+	r := newTestRouter(RouterConfig{})
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want []FieldError
+	}{
+		{
+			// user_id is optional: the authenticated user is used.
+			name: "no user_id nor seat_ids",
+			path: "/events/1/holds",
+			body: `{}`,
+			want: []FieldError{{Field: "seat_ids", Reason: "required"}},
+		},
+		{
+			name: "empty seat_ids",
+			path: "/events/1/holds",
+			body: `{"seat_ids":[]}`,
+			want: []FieldError{{Field: "seat_ids", Reason: "min"}},
+		},
+		{
+			name: "zero seat id",
+			path: "/events/1/holds",
+			body: `{"user_id":7,"seat_ids":[3,0]}`,
+			want: []FieldError{{Field: "seat_ids[1]", Reason: "required"}},
+		},
+		{
+			name: "negative count",
+			path: "/events/1/holds/auto",
+			body: `{"count":-1}`,
+			want: []FieldError{{Field: "count", Reason: "min"}},
+		},
+		{
+			name: "wrong type",
+			path: "/events/1/holds",
+			body: `{"seat_ids":"1"}`,
+			want: []FieldError{{Field: "seat_ids", Reason: "type"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodPost, tt.path, tt.body, map[string]string{"Authorization": bearer(t, 7)})
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body.String())
+			}
+			var got ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Error == "" {
+				t.Error("no top-level error")
+			}
+			if !slices.Equal(got.Fields, tt.want) {
+				t.Errorf("fields = %+v, want %+v", got.Fields, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package httpgin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/kirinyoku/tix-go/internal/domain"
+	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+	"github.com/kirinyoku/tix-go/internal/service"
+)
+
+const (
+	// defaultWSIdleTimeout is used when RouterConfig.WSIdleTimeout is unset.
+	defaultWSIdleTimeout = 60 * time.Second
+	// wsWriteTimeout bounds every single write to the client.
+	wsWriteTimeout = 10 * time.Second
+)
+
+// The API is public and CORS already allows any origin.
+var wsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// SeatMapMessage is sent over the seat-map WebSocket. The first message has
+// type "snapshot" and carries every seat; subsequent messages have type
+// "update" and carry only seats whose status changed.
+type SeatMapMessage struct {
+	Type    string                  `json:"type"`
+	Seats   []domain.SeatWithStatus `json:"seats,omitempty"`
+	Changes []SeatStatusChange      `json:"changes,omitempty"`
+}
+
+type SeatStatusChange struct {
+	SeatID int64             `json:"seat_id"`
+	Status domain.SeatStatus `json:"status"`
+}
+
+// @Summary  Live seat status updates (WebSocket)
+// @Description Upgrades to a WebSocket. Sends a `snapshot` message with the full seat map, then `update` messages with changed seats. Idle connections are closed.
+// @Param    id  path  int  true  "Event ID"
+// @Success  101  {object}  SeatMapMessage
+// @Failure  404  {object}  ErrorResponse
+// @Router   /events/{id}/ws [get]
+func handleSeatMapWS(
+	svcs *service.Services,
+	events *redisrepo.EventsHub,
+	idleTimeout time.Duration,
+) gin.HandlerFunc {
+	if idleTimeout <= 0 {
+		idleTimeout = defaultWSIdleTimeout
+	}
+	pingEvery := idleTimeout / 2
+
+	return func(c *gin.Context) {
+		eventID, ok := parseInt64Param(c, "id")
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		// Load the snapshot before upgrading so unknown IDs get a regular
+		// JSON error.
+		seats, err := svcs.Query.GetSeatMap(ctx, eventID)
+		if err != nil {
+			respondErr(c, err)
+			return
+		}
+
+		conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			// Upgrade has already written an HTTP error.
+			return
+		}
+		defer conn.Close()
+
+		// Reader: clients are not expected to send data, but reading is
+		// required to process pongs and close frames. Any activity extends
+		// the idle deadline.
+		readDone := make(chan struct{})
+		go func() {
+			defer close(readDone)
+			_ = conn.SetReadDeadline(time.Now().Add(idleTimeout))
+			conn.SetPongHandler(func(string) error {
+				return conn.SetReadDeadline(time.Now().Add(idleTimeout))
+			})
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+				_ = conn.SetReadDeadline(time.Now().Add(idleTimeout))
+			}
+		}()
+
+		// Watch through the shared hub rather than subscribing per connection.
+		changed, stop := events.Watch(eventID)
+		defer stop()
+
+		write := func(v any) error {
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			return conn.WriteJSON(v)
+		}
+
+		if err := write(SeatMapMessage{Type: "snapshot", Seats: seats}); err != nil {
+			return
+		}
+		last := seatStatusIndex(seats)
+
+		ticker := time.NewTicker(pingEvery)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-readDone:
+				return
+			case _, ok := <-changed:
+				if !ok {
+					return
+				}
+				seats, err := svcs.Query.GetSeatMap(ctx, eventID)
+				if err != nil {
+					continue
+				}
+				next := seatStatusIndex(seats)
+				changes := diffSeatStatuses(last, next)
+				last = next
+				if len(changes) == 0 {
+					continue
+				}
+				if err := write(SeatMapMessage{Type: "update", Changes: changes}); err != nil {
+					return
+				}
+			case <-ticker.C:
+				deadline := time.Now().Add(wsWriteTimeout)
+				if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+func seatStatusIndex(seats []domain.SeatWithStatus) map[int64]domain.SeatStatus {
+	out := make(map[int64]domain.SeatStatus, len(seats))
+	for _, s := range seats {
+		out[s.ID] = s.Status
+	}
+
+	return out
+}
+
+func diffSeatStatuses(prev, next map[int64]domain.SeatStatus) []SeatStatusChange {
+	var out []SeatStatusChange
+	for id, status := range next {
+		if prev[id] != status {
+			out = append(out, SeatStatusChange{SeatID: id, Status: status})
+		}
+	}
+
+	return out
+}
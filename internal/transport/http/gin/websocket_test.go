@@ -0,0 +1,70 @@
+package httpgin
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/kirinyoku/tix-go/internal/domain"
+	"github.com/kirinyoku/tix-go/internal/postgres/pgtest"
+	redisrepo "github.com/kirinyoku/tix-go/internal/repository/redis"
+)
+
+func TestSeatMapWS(t *testing.T) {
+	r, pool, mr := newDBRouterRedis(t, RouterConfig{})
+	eventID, seatIDs := pgtest.SeedEvent(t, pool, 1, 3, 0)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	url := fmt.Sprintf("ws%s/events/%d/ws", strings.TrimPrefix(srv.URL, "http"), eventID)
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var snap SeatMapMessage
+	if err := conn.ReadJSON(&snap); err != nil {
+		t.Fatal(err)
+	}
+	if snap.Type != "snapshot" || len(snap.Seats) != len(seatIDs) {
+		t.Fatalf("got %s with %d seats, want snapshot with %d", snap.Type, len(snap.Seats), len(seatIDs))
+	}
+	for _, s := range snap.Seats {
+		if s.Status != domain.SeatAvailable {
+			t.Errorf("snapshot: seat %d is %q, want available", s.ID, s.Status)
+		}
+	}
+
+	// The snapshot is sent once the connection watches the event; wait for
+	// the shared subscription to reach Redis so the hold is not missed.
+	channel := redisrepo.ChannelEventsChanged()
+	deadline := time.Now().Add(2 * time.Second)
+	for mr.PubSubNumSub(channel)[channel] != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("seat map did not subscribe to event changes")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	w := serve(r, http.MethodPost, fmt.Sprintf("/events/%d/holds", eventID),
+		fmt.Sprintf(`{"seat_ids":[%d]}`, seatIDs[1]), map[string]string{"Authorization": bearer(t, 7)})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("hold: status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+
+	var update SeatMapMessage
+	if err := conn.ReadJSON(&update); err != nil {
+		t.Fatal(err)
+	}
+	want := []SeatStatusChange{{SeatID: seatIDs[1], Status: domain.SeatHeld}}
+	if update.Type != "update" || !slices.Equal(update.Changes, want) {
+		t.Errorf("got %+v, want an update with %+v", update, want)
+	}
+}
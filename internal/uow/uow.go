@@ -2,22 +2,80 @@ package uow
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
 	postgres "github.com/kirinyoku/tix-go/internal/repository/postgres"
 )
 
+const (
+	defaultMaxAttempts = 3
+	defaultBackoff     = 10 * time.Millisecond
+	// hookTimeout bounds how long after-commit hooks may run once detached
+	// from the caller's context.
+	hookTimeout = 5 * time.Second
+)
+
 // AfterCommit is a function that runs after a successful transaction commit.
-type AfterCommit func(ctx context.Context)
+// Its error is reported but cannot undo the already-committed transaction.
+type AfterCommit func(ctx context.Context) error
 
 // UoW represents a unit of work.
 type UoW struct {
-	store *postgres.Store
+	store       *postgres.Store
+	maxAttempts int
+	backoff     time.Duration
+	logger      *slog.Logger
 }
 
-func NewUoW(store *postgres.Store) *UoW {
-	return &UoW{store: store}
+// Option configures optional UoW behaviour.
+type Option func(*UoW)
+
+// WithMaxAttempts sets how many times a transaction is run in total when it
+// fails with a serialization failure or deadlock. Values below 1 are ignored.
+func WithMaxAttempts(n int) Option {
+	return func(u *UoW) {
+		if n >= 1 {
+			u.maxAttempts = n
+		}
+	}
+}
+
+// WithBackoff sets the delay before the first retry; it doubles on every
+// subsequent retry.
+func WithBackoff(d time.Duration) Option {
+	return func(u *UoW) {
+		if d >= 0 {
+			u.backoff = d
+		}
+	}
+}
+
+// WithLogger sets the logger used to report failed after-commit hooks.
+// A nil logger is ignored.
+func WithLogger(l *slog.Logger) Option {
+	return func(u *UoW) {
+		if l != nil {
+			u.logger = l
+		}
+	}
+}
+
+func NewUoW(store *postgres.Store, opts ...Option) *UoW {
+	u := &UoW{
+		store:       store,
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+		logger:      slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
 }
 
 // Do runs fn inside the transaction. After a successful commit,
@@ -31,6 +89,15 @@ func (u *UoW) Do(
 
 // DoWithOpts runs fn inside the transaction with the given options. After a successful commit,
 // it executes all after-commit hooks.
+//
+// If the transaction fails with a retryable error (serialization failure or
+// deadlock), fn is run again in a fresh transaction, up to the configured
+// number of attempts. Hooks registered by a failed attempt are discarded.
+//
+// Hooks run on a context detached from ctx's cancellation, so cache
+// invalidation and notifications still happen when the caller has gone away.
+// Hook errors are joined and logged; they never fail the committed
+// transaction, so the returned error only reflects the transaction itself.
 func (u *UoW) DoWithOpts(
 	ctx context.Context,
 	opts *pgx.TxOptions,
@@ -38,18 +105,52 @@ func (u *UoW) DoWithOpts(
 ) error {
 	var hooks []AfterCommit
 
-	err := u.store.RunTx(ctx, opts, func(ctx context.Context, tx postgres.DB) error {
-		return fn(ctx, tx, func(h AfterCommit) {
-			hooks = append(hooks, h)
+	backoff := u.backoff
+	for attempt := 1; ; attempt++ {
+		hooks = hooks[:0]
+
+		err := u.store.RunTx(ctx, opts, func(ctx context.Context, tx postgres.DB) error {
+			return fn(ctx, tx, func(h AfterCommit) {
+				hooks = append(hooks, h)
+			})
 		})
-	})
-	if err != nil {
-		return err
+		if err == nil {
+			break
+		}
+
+		if attempt >= u.maxAttempts || !postgres.IsRetryable(err) {
+			return err
+		}
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+		backoff *= 2
 	}
 
-	for _, h := range hooks {
-		h(ctx)
+	hookCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), hookTimeout)
+	defer cancel()
+
+	if err := runHooks(hookCtx, hooks); err != nil {
+		u.logger.WarnContext(ctx, "after-commit hooks failed", "error", err)
 	}
 
 	return nil
 }
+
+// runHooks runs every hook, even if earlier ones fail, and returns their
+// errors joined together.
+func runHooks(ctx context.Context, hooks []AfterCommit) error {
+	var errs []error
+	for _, h := range hooks {
+		if err := h(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,204 @@
+package uow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kirinyoku/tix-go/internal/postgres/pgtest"
+	postgres "github.com/kirinyoku/tix-go/internal/repository/postgres"
+)
+
+func newTestUoW(t *testing.T, opts ...Option) (*UoW, *pgxpool.Pool) {
+	t.Helper()
+
+	pool := pgtest.New(t)
+
+	return NewUoW(postgres.NewStore(pool), append([]Option{WithBackoff(0)}, opts...)...), pool
+}
+
+// countVenues returns the number of venues named name.
+func countVenues(t *testing.T, pool *pgxpool.Pool, name string) int {
+	t.Helper()
+
+	var n int
+	if err := pool.QueryRow(context.Background(),
+		`SELECT count(*) FROM venues WHERE name = $1`, name,
+	).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+
+	return n
+}
+
+func TestDoRetry(t *testing.T) {
+	serialization := &pgconn.PgError{Code: "40001"}
+	deadlock := &pgconn.PgError{Code: "40P01"}
+	other := errors.New("boom")
+
+	tests := []struct {
+		name         string
+		failures     []error
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "first attempt", wantAttempts: 1},
+		{name: "serialization failure", failures: []error{serialization}, wantAttempts: 2},
+		{name: "deadlock then serialization", failures: []error{deadlock, serialization}, wantAttempts: 3},
+		{
+			name:         "out of attempts",
+			failures:     []error{serialization, serialization, serialization},
+			wantAttempts: 3,
+			wantErr:      serialization,
+		},
+		{name: "not retryable", failures: []error{other}, wantAttempts: 1, wantErr: other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, pool := newTestUoW(t)
+
+			attempts := 0
+			var ran []int
+			err := u.Do(context.Background(), func(ctx context.Context, tx postgres.DB, after func(AfterCommit)) error {
+				attempts++
+				attempt := attempts
+				after(func(context.Context) error {
+					ran = append(ran, attempt)
+					return nil
+				})
+				if _, err := tx.Exec(ctx, `INSERT INTO venues (name) VALUES ('Retried')`); err != nil {
+					return err
+				}
+				if attempt <= len(tt.failures) {
+					return tt.failures[attempt-1]
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+
+			// Only a committed attempt leaves a row and runs its hooks.
+			wantRows, wantRan := 0, []int(nil)
+			if tt.wantErr == nil {
+				wantRows, wantRan = 1, []int{attempts}
+			}
+			if n := countVenues(t, pool, "Retried"); n != wantRows {
+				t.Errorf("rows = %d, want %d", n, wantRows)
+			}
+			if !slices.Equal(ran, wantRan) {
+				t.Errorf("hooks of attempts %v ran, want %v", ran, wantRan)
+			}
+		})
+	}
+}
+
+func TestDoHooksOutliveCaller(t *testing.T) {
+	u, _ := newTestUoW(t)
+
+	type key struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "request"))
+	defer cancel()
+
+	var ran bool
+	err := u.Do(ctx, func(_ context.Context, _ postgres.DB, after func(AfterCommit)) error {
+		// The caller goes away right after the commit, before the second
+		// hook runs.
+		after(func(context.Context) error {
+			cancel()
+			return nil
+		})
+		after(func(hookCtx context.Context) error {
+			ran = true
+			if err := hookCtx.Err(); err != nil {
+				t.Errorf("hook context: %v", err)
+			}
+			if _, ok := hookCtx.Deadline(); !ok {
+				t.Error("hook context has no deadline")
+			}
+			if hookCtx.Value(key{}) != "request" {
+				t.Error("hook context lost the caller's values")
+			}
+			return nil
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("caller context not cancelled")
+	}
+	if !ran {
+		t.Error("hook did not run")
+	}
+}
+
+func TestRunHooks(t *testing.T) {
+	errA, errB := errors.New("cache down"), errors.New("publish failed")
+
+	var ran int
+	hook := func(err error) AfterCommit {
+		return func(context.Context) error {
+			ran++
+			return err
+		}
+	}
+
+	err := runHooks(context.Background(), []AfterCommit{hook(errA), hook(nil), hook(errB)})
+	if ran != 3 {
+		t.Errorf("ran %d hooks, want 3", ran)
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("err = %v, want both hook errors", err)
+	}
+
+	if err := runHooks(context.Background(), []AfterCommit{hook(nil)}); err != nil {
+		t.Errorf("no failures: err = %v", err)
+	}
+}
+
+func TestDoLogsHookErrors(t *testing.T) {
+	var buf bytes.Buffer
+	u, pool := newTestUoW(t, WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+
+	err := u.Do(context.Background(), func(ctx context.Context, tx postgres.DB, after func(AfterCommit)) error {
+		after(func(context.Context) error { return errors.New("cache down") })
+		after(func(context.Context) error { return errors.New("publish failed") })
+		_, err := tx.Exec(ctx, `INSERT INTO venues (name) VALUES ('Hooked')`)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("err = %v, want the committed transaction to succeed", err)
+	}
+	if n := countVenues(t, pool, "Hooked"); n != 1 {
+		t.Errorf("rows = %d, want 1", n)
+	}
+
+	var entry struct {
+		Level string `json:"level"`
+		Msg   string `json:"msg"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log = %q: %v", buf.String(), err)
+	}
+	if entry.Level != "WARN" || entry.Msg != "after-commit hooks failed" {
+		t.Errorf("logged %s %q, want WARN after-commit hooks failed", entry.Level, entry.Msg)
+	}
+	for _, want := range []string{"cache down", "publish failed"} {
+		if !strings.Contains(entry.Error, want) {
+			t.Errorf("error = %q, want it to contain %q", entry.Error, want)
+		}
+	}
+}
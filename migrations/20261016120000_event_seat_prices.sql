@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE event_seats
+    ADD COLUMN IF NOT EXISTS price_cents INT NOT NULL DEFAULT 0 CHECK (price_cents >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE event_seats DROP COLUMN IF EXISTS price_cents;
+-- +goose StatementEnd
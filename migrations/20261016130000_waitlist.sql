@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS waitlist (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    seat_count INT NOT NULL CHECK (seat_count > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    notified_at TIMESTAMPTZ NULL,
+    UNIQUE (event_id, user_id)
+);
+
+CREATE INDEX idx_waitlist_event_pending
+  ON waitlist(event_id, created_at, id)
+  WHERE notified_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_waitlist_event_pending;
+DROP TABLE waitlist;
+-- +goose StatementEnd
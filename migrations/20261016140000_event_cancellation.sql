@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE events ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE events DROP COLUMN IF EXISTS cancelled_at;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE event_seats ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE event_seats DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS event_log (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    hold_id UUID NOT NULL,
+    user_id BIGINT NOT NULL,
+    kind TEXT NOT NULL
+      CHECK (kind IN ('hold_created', 'hold_confirmed', 'hold_cancelled', 'hold_expired')),
+    seat_count INT NOT NULL,
+    order_id UUID NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_event_log_event
+  ON event_log(event_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_event_log_event;
+DROP TABLE event_log;
+-- +goose StatementEnd
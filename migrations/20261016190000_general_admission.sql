@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE events
+  ADD COLUMN IF NOT EXISTS ga_capacity INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS ga_held INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS ga_sold INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS ga_price_cents INT NOT NULL DEFAULT 0,
+  ADD CONSTRAINT events_ga_counters_check
+    CHECK (ga_held >= 0 AND ga_sold >= 0 AND ga_held + ga_sold <= ga_capacity);
+
+ALTER TABLE holds ADD COLUMN IF NOT EXISTS ga_qty INT NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS ga_qty INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN IF EXISTS ga_qty;
+
+ALTER TABLE holds DROP COLUMN IF EXISTS ga_qty;
+
+ALTER TABLE events
+  DROP CONSTRAINT IF EXISTS events_ga_counters_check,
+  DROP COLUMN IF EXISTS ga_price_cents,
+  DROP COLUMN IF EXISTS ga_sold,
+  DROP COLUMN IF EXISTS ga_held,
+  DROP COLUMN IF EXISTS ga_capacity;
+-- +goose StatementEnd
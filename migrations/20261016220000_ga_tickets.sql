@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- General-admission tickets have no seat. UNIQUE (event_id, seat_id) treats
+-- NULLs as distinct, so any number of them fits one event.
+ALTER TABLE tickets ALTER COLUMN seat_id DROP NOT NULL;
+
+-- Issue the tickets of general-admission orders confirmed before.
+INSERT INTO tickets(id, order_id, event_id, seat_id, created_at)
+SELECT gen_random_uuid(), o.id, o.event_id, NULL, o.created_at
+FROM orders o
+CROSS JOIN LATERAL generate_series(1, o.ga_qty)
+WHERE o.status = 'confirmed' AND o.ga_qty > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM tickets WHERE seat_id IS NULL;
+
+ALTER TABLE tickets ALTER COLUMN seat_id SET NOT NULL;
+-- +goose StatementEnd
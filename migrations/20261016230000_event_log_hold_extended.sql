@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE event_log DROP CONSTRAINT IF EXISTS event_log_kind_check;
+ALTER TABLE event_log ADD CONSTRAINT event_log_kind_check
+  CHECK (kind IN ('hold_created', 'hold_confirmed', 'hold_cancelled', 'hold_expired', 'hold_extended'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM event_log WHERE kind = 'hold_extended';
+ALTER TABLE event_log DROP CONSTRAINT IF EXISTS event_log_kind_check;
+ALTER TABLE event_log ADD CONSTRAINT event_log_kind_check
+  CHECK (kind IN ('hold_created', 'hold_confirmed', 'hold_cancelled', 'hold_expired'));
+-- +goose StatementEnd
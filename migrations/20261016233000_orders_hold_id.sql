@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS hold_id UUID NULL;
+
+UPDATE orders o
+SET hold_id = l.hold_id
+FROM event_log l
+WHERE l.kind = 'hold_confirmed' AND l.order_id = o.id AND o.hold_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_hold_id
+  ON orders(hold_id) WHERE hold_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_orders_hold_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS hold_id;
+-- +goose StatementEnd